@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -18,7 +22,7 @@ type IAMPolicy struct {
 }
 
 func missingProjectIDError() error {
-	return fmt.Errorf("project ID is not configured. Specify --project, set GSECUTIL_PROJECT, or set project in the config file")
+	return fmt.Errorf("no project configured: specify --project, set project in the config file, set GSECUTIL_PROJECT, or run 'gcloud config set project PROJECT_ID'")
 }
 
 // Binding represents an IAM policy binding
@@ -44,6 +48,19 @@ var SecretManagerRoles = map[string]string{
 	"roles/secretmanager.secretVersionAdder":   "Version Adder (can add new versions)",
 }
 
+// validateRoleFormat checks that a role is a predefined role ID
+// (roles/NAME) or a custom one (projects/ID/roles/NAME or
+// organizations/ID/roles/NAME)
+func validateRoleFormat(role string) error {
+	parts := strings.Split(role, "/")
+	valid := len(parts) == 2 && parts[0] == "roles" ||
+		len(parts) == 4 && (parts[0] == "projects" || parts[0] == "organizations") && parts[1] != "" && parts[2] == "roles"
+	if !valid || parts[len(parts)-1] == "" {
+		return fmt.Errorf("invalid role '%s' (use roles/NAME, projects/ID/roles/NAME, or organizations/ID/roles/NAME)", role)
+	}
+	return nil
+}
+
 var accessCmd = &cobra.Command{
 	Use:   "access",
 	Short: "Manage access permissions for secrets",
@@ -66,20 +83,131 @@ grant access to the secret.
 Examples:
   gsecutil access list my-secret                    # List all access for my-secret
   gsecutil access list my-secret --project my-proj  # List access with specific project
-  gsecutil access list my-secret --include-project  # Include project-level permissions`,
+  gsecutil access list my-secret --include-project  # Include project-level permissions
+  gsecutil access list my-secret --include-ancestors  # Include folder and organization roles
+  gsecutil access list my-secret --by-principal     # One entry per principal with all of its roles
+  gsecutil access list my-secret --min-role accessor --include-project  # Who can read the value
+  gsecutil access list my-secret --format json      # Bindings with full conditions as JSON
+  gsecutil access list my-secret --format markdown  # One row per member, for access reviews
+
+Members are normalized (surrounding spaces trimmed, type prefix in canonical
+case) and deduplicated, and principals holding more than one role are listed
+after the bindings to make over-grants easy to spot.
+
+Conditional bindings are marked CONDITIONAL with their full expression, title,
+and description. When the condition is a time bound (request.time <
+timestamp(...)), the expiry is shown, e.g. "expires 2025-01-01 00:00 UTC", or
+"expired" once it has passed. --format json or yaml prints the bindings with
+the full condition object and an "expires" timestamp for time-bound grants;
+with --include-project, the project-level bindings that give access to the
+secret are added as "projectBindings", for diffing access over time in CI.
+--format markdown prints one Markdown table row per member with its role,
+condition, and expiry.
+
+--min-role hides bindings whose role grants less than the given capability, using
+this ranking: viewer < versionAdder < versionManager < accessor < admin. The
+project-level roles/owner and roles/editor rank as admin. Bindings with other
+roles (such as custom roles) cannot be ranked; they are hidden and counted.
+
+--include-ancestors resolves the project's folders and organization with
+'gcloud projects get-ancestors' and lists the Secret Manager roles granted on
+each of them, which every secret in the project inherits. Each binding shows
+its origin (folder or organization ID). Reading those policies requires
+resourcemanager.folders.getIamPolicy and
+resourcemanager.organizations.getIamPolicy; an ancestor whose policy cannot be
+read is reported and skipped.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		project, _ := cmd.Flags().GetString("project")
-		project = GetProject(project) // Use configuration-based project resolution
 		includeProject, _ := cmd.Flags().GetBool("include-project")
+		includeAncestors, _ := cmd.Flags().GetBool("include-ancestors")
+		byPrincipal, _ := cmd.Flags().GetBool("by-principal")
+		minRole, _ := cmd.Flags().GetString("min-role")
+		format, _ := cmd.Flags().GetString("format")
 		userInputName := args[0]                           // What the user typed
 		secretName := AddPrefixToSecretName(userInputName) // Add prefix if configured
-		return listSecretAccess(secretName, project, includeProject)
+
+		minRank := 0
+		if minRole != "" {
+			var err error
+			if minRank, err = parseMinRole(minRole); err != nil {
+				return err
+			}
+		}
+		if format == "text" || format == "table" {
+			format = ""
+		}
+		if format != "" && format != "json" && format != "yaml" && format != markdownFormat {
+			return fmt.Errorf("unsupported format '%s' (use text, json, yaml, or markdown)", format)
+		}
+		if format != "" && byPrincipal {
+			return fmt.Errorf("--format %s cannot be combined with --by-principal", format)
+		}
+		if format == markdownFormat && includeProject {
+			return fmt.Errorf("--format %s cannot be combined with --include-project", format)
+		}
+		if format != "" && includeAncestors {
+			return fmt.Errorf("--format %s cannot be combined with --include-ancestors", format)
+		}
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
+		return listSecretAccess(secretName, project, includeProject, includeAncestors, byPrincipal, minRank, format)
 	},
 }
 
+// roleRanks orders the roles that grant Secret Manager access by capability,
+// from metadata-only to full control. Project-level owner and editor rank with admin.
+var roleRanks = map[string]int{
+	"roles/secretmanager.viewer":               1,
+	"roles/secretmanager.secretVersionAdder":   2,
+	"roles/secretmanager.secretVersionManager": 3,
+	"roles/secretmanager.secretAccessor":       4,
+	"roles/secretmanager.admin":                5,
+	"roles/editor":                             5,
+	"roles/owner":                              5,
+}
+
+// minRoleAliases are the short names accepted by --min-role
+var minRoleAliases = map[string]string{
+	"viewer":         "roles/secretmanager.viewer",
+	"versionAdder":   "roles/secretmanager.secretVersionAdder",
+	"versionManager": "roles/secretmanager.secretVersionManager",
+	"accessor":       "roles/secretmanager.secretAccessor",
+	"admin":          "roles/secretmanager.admin",
+}
+
+// parseMinRole returns the rank of a --min-role value, given as a short name or a role ID
+func parseMinRole(minRole string) (int, error) {
+	role := minRole
+	if alias, ok := minRoleAliases[minRole]; ok {
+		role = alias
+	}
+	rank, ok := roleRanks[role]
+	if !ok {
+		return 0, fmt.Errorf("unknown --min-role '%s' (use viewer, versionAdder, versionManager, accessor, admin, or a Secret Manager role ID)", minRole)
+	}
+	return rank, nil
+}
+
+// filterBindingsByMinRank keeps the bindings whose role ranks at least minRank
+// and returns how many were dropped, including bindings with unranked roles
+func filterBindingsByMinRank(bindings []Binding, minRank int) ([]Binding, int) {
+	var kept []Binding
+	for _, binding := range bindings {
+		if rank := roleRanks[binding.Role]; rank > 0 && rank >= minRank {
+			kept = append(kept, binding)
+		}
+	}
+	return kept, len(bindings) - len(kept)
+}
+
+// defaultAccessRole is the role used by grant and revoke when none is given
+const defaultAccessRole = "roles/secretmanager.secretAccessor"
+
 var accessGrantCmd = &cobra.Command{
-	Use:   "grant SECRET_NAME",
+	Use:   "grant [SECRET_NAME]",
 	Short: "Grant access to a principal for a secret",
 	Long: `Grant access to a user, group, or service account for the specified secret.
 
@@ -88,23 +216,44 @@ The principal should be specified using the --principal flag and should be in on
   - group:group@domain.com
   - serviceAccount:sa@project.iam.gserviceaccount.com
   - domain:domain.com
+  - principal://iam.googleapis.com/... (a single workforce or workload identity)
+  - principalSet://iam.googleapis.com/... (a group or attribute set of federated identities)
 
 The role defaults to roles/secretmanager.secretAccessor but can be customized with --role.
 
+--principal may be repeated (or given a comma-separated list) to grant the same
+role to several principals in one pass. Each principal is granted in turn with
+one line per success; a failure is reported and the rest are still granted, and
+the command exits non-zero if any failed.
+
+With --project-level (and no SECRET_NAME) the role is granted on the project IAM
+policy instead, which gives access to every secret in the project. Only Secret
+Manager roles are accepted. A summary of the change is shown and the project ID
+must be retyped to confirm; --force skips the confirmation.
+
+--condition-title and --condition-expression attach an IAM condition written
+in CEL to the binding, for example to make access expire; --condition-description
+is optional. The condition applies at the secret and the project level.
+
+--dry-run validates the principal and prints the gcloud command that would
+run, with the resolved secret name, without changing IAM.
+
+Granting to allUsers or allAuthenticatedUsers makes the secret readable by
+anyone on the internet or with a Google account, so a warning is printed and
+the grant must be confirmed. Without a terminal the grant is refused unless
+--force is given.
+
 Examples:
   gsecutil access grant my-secret --principal user:alice@example.com
+  gsecutil access grant my-secret --principal user:contractor@example.com --condition-title "Until end of 2025" --condition-expression 'request.time < timestamp("2026-01-01T00:00:00Z")'
   gsecutil access grant my-secret --principal user:alice@example.com --role roles/secretmanager.viewer
-  gsecutil access grant my-secret --principal serviceAccount:app@project.iam.gserviceaccount.com`,
-	Args: cobra.ExactArgs(1),
+  gsecutil access grant my-secret --principal user:alice@example.com --principal group:devs@example.com
+  gsecutil access grant my-secret --principal serviceAccount:app@project.iam.gserviceaccount.com
+  gsecutil access grant my-secret --principal principalSet://iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/github/attribute.repository/my-org/my-repo
+  gsecutil access grant --project-level --principal group:sre@example.com --role roles/secretmanager.viewer`,
+	Args: secretOrProjectLevelArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		project, _ := cmd.Flags().GetString("project")
-		project = GetProject(project) // Use configuration-based project resolution
-		principal, _ := cmd.Flags().GetString("principal")
-		role, _ := cmd.Flags().GetString("role")
-		userInputName := args[0]                           // What the user typed
-		secretName := AddPrefixToSecretName(userInputName) // Add prefix if configured
-
-		return grantSecretAccess(secretName, principal, role, project)
+		return runAccessChange(cmd, args, projectAccessGrant)
 	},
 }
 
@@ -116,19 +265,40 @@ var accessProjectCmd = &cobra.Command{
 This command displays all roles at the project level that provide access to secrets,
 including specific Secret Manager roles and broader roles like Editor/Owner.
 
+Use --format json or --format csv to export the permissions for periodic access
+reviews, with the role, role description, scope, members, and any condition of
+each binding. CSV has one row per member. --output writes the export to a file.
+
 Examples:
   gsecutil access project                    # Show project-level permissions for default project
-  gsecutil access project --project my-proj # Show project-level permissions for specific project`,
+  gsecutil access project --project my-proj # Show project-level permissions for specific project
+  gsecutil access project --format json      # Export as JSON
+  gsecutil access project --format csv --output access-review.csv`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		project, _ := cmd.Flags().GetString("project")
-		project = GetProject(project) // Use configuration-based project resolution
-		return showProjectLevelPermissions(project)
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
+		format, _ := cmd.Flags().GetString("format")
+		outputPath, _ := cmd.Flags().GetString("output")
+
+		if format == "text" {
+			format = ""
+		}
+		if format != "" && format != "json" && format != "csv" {
+			return fmt.Errorf("unsupported format '%s' (use text, json, or csv)", format)
+		}
+		if outputPath != "" && format == "" {
+			return fmt.Errorf("--output requires --format json or csv")
+		}
+		return showProjectLevelPermissions(project, format, outputPath)
 	},
 }
 
 var accessRevokeCmd = &cobra.Command{
-	Use:   "revoke SECRET_NAME",
+	Use:   "revoke [SECRET_NAME]",
 	Short: "Revoke access from a principal for a secret",
 	Long: `Revoke access from a user, group, or service account for the specified secret.
 
@@ -137,28 +307,82 @@ The principal should be specified using the --principal flag and should be in on
   - group:group@domain.com
   - serviceAccount:sa@project.iam.gserviceaccount.com
   - domain:domain.com
+  - principal://iam.googleapis.com/... (a single workforce or workload identity)
+  - principalSet://iam.googleapis.com/... (a group or attribute set of federated identities)
 
 You can optionally specify the role to revoke with --role. If no role is specified,
 the default role (roles/secretmanager.secretAccessor) will be revoked.
 
+With --project-level (and no SECRET_NAME) the role is revoked from the project
+IAM policy instead. As with grant, only Secret Manager roles are accepted and the
+project ID must be retyped to confirm unless --force is given.
+
 Examples:
   gsecutil access revoke my-secret --principal user:alice@example.com
-  gsecutil access revoke my-secret --principal user:alice@example.com --role roles/secretmanager.viewer`,
-	Args: cobra.ExactArgs(1),
+  gsecutil access revoke my-secret --principal user:alice@example.com --role roles/secretmanager.viewer
+  gsecutil access revoke --project-level --principal group:sre@example.com --role roles/secretmanager.viewer`,
+	Args: secretOrProjectLevelArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		project, _ := cmd.Flags().GetString("project")
-		project = GetProject(project) // Use configuration-based project resolution
-		principal, _ := cmd.Flags().GetString("principal")
-		role, _ := cmd.Flags().GetString("role")
-		userInputName := args[0]                           // What the user typed
-		secretName := AddPrefixToSecretName(userInputName) // Add prefix if configured
-
-		return revokeSecretAccess(secretName, principal, role, project)
+		return runAccessChange(cmd, args, projectAccessRevoke)
 	},
 }
 
-// listSecretAccess lists all principals with access to a secret
-func listSecretAccess(secretName, project string, includeProject bool) error {
+// listSecretAccess lists all principals with access to a secret. A minRank
+// above 0 hides bindings whose role ranks below it. includeAncestors adds the
+// roles inherited from the project's folders and organization.
+func listSecretAccess(secretName, project string, includeProject, includeAncestors, byPrincipal bool, minRank int, format string) error {
+	policy, err := secretManager.GetIamPolicy(secretName, project)
+	if err != nil {
+		return err
+	}
+
+	if minRank > 0 {
+		var hidden int
+		policy.Bindings, hidden = filterBindingsByMinRank(policy.Bindings, minRank)
+		if format != "" {
+			if hidden > 0 {
+				fmt.Fprintf(os.Stderr, "Hiding %d binding(s) below the minimum role or with unranked roles\n", hidden)
+			}
+		} else if hidden > 0 {
+			fmt.Printf("Hiding %d binding(s) below the minimum role or with unranked roles\n\n", hidden)
+		}
+	}
+
+	if format == markdownFormat {
+		printMarkdownTable(secretAccessTableColumns(newSecretAccessReport(secretName, *policy), time.Now()))
+		return nil
+	}
+	if format != "" {
+		report := newSecretAccessReport(secretName, *policy)
+		if includeProject {
+			projectID := getProjectID(project)
+			if projectID == "" {
+				return missingProjectIDError()
+			}
+			projectPolicy, err := secretManager.GetProjectIamPolicy(projectID)
+			if err != nil {
+				return err
+			}
+			report.addProjectBindings(projectID, *projectPolicy, minRank)
+		}
+		return printStructuredOutput(report, format)
+	}
+
+	// Display the access information
+	if byPrincipal {
+		displaySecretAccessByPrincipal(secretName, *policy, includeProject, project, minRank)
+	} else {
+		displaySecretAccess(secretName, *policy, includeProject, project, minRank)
+	}
+	if includeAncestors {
+		displayAncestorAccess(project, minRank)
+	}
+
+	return nil
+}
+
+// fetchSecretIAMPolicy retrieves the IAM policy attached to a secret
+func fetchSecretIAMPolicy(secretName, project string) (*IAMPolicy, error) {
 	// Build gcloud command to get IAM policy
 	gcloudArgs := []string{"secrets", "get-iam-policy", secretName, "--format", "json"}
 	if project != "" {
@@ -166,83 +390,193 @@ func listSecretAccess(secretName, project string, includeProject bool) error {
 	}
 
 	// Execute gcloud command
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
 	output, err := gcloudCmd.Output()
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
-			return formatGcloudError(string(exitError.Stderr))
+			return nil, formatGcloudError(string(exitError.Stderr))
 		}
-		return fmt.Errorf("failed to execute gcloud command: %w", err)
+		return nil, fmt.Errorf("failed to execute gcloud command: %w", err)
 	}
 
 	// Parse JSON response
 	var policy IAMPolicy
 	if err := json.Unmarshal(output, &policy); err != nil {
-		return fmt.Errorf("failed to parse IAM policy: %w", err)
+		return nil, fmt.Errorf("failed to parse IAM policy: %w", err)
 	}
 
-	// Display the access information
-	displaySecretAccess(secretName, policy, includeProject, project)
+	return &policy, nil
+}
 
-	return nil
+// grantConditionFromFlags builds the IAM condition set by the grant
+// --condition-* flags, or nil when none is set. A condition needs both a
+// title and an expression; the description is optional.
+func grantConditionFromFlags(title, expression, description string) (*Condition, error) {
+	title, expression, description = strings.TrimSpace(title), strings.TrimSpace(expression), strings.TrimSpace(description)
+	if title == "" && expression == "" && description == "" {
+		return nil, nil
+	}
+	if expression == "" {
+		return nil, fmt.Errorf("--condition-expression is required with --condition-title or --condition-description")
+	}
+	if title == "" {
+		return nil, fmt.Errorf("--condition-title is required with --condition-expression")
+	}
+	return &Condition{Title: title, Expression: expression, Description: description}, nil
 }
 
-// grantSecretAccess grants access to a principal for a secret
-func grantSecretAccess(secretName, principal, role, project string) error {
-	// Validate the principal format
-	if err := validatePrincipalFormat(principal); err != nil {
-		return err
+// conditionFlagValue formats a condition as the value of gcloud's
+// --condition flag. A comma in a value, such as a CEL function call with
+// several arguments, is handled by gcloudDictFlagValue.
+func conditionFlagValue(condition *Condition) string {
+	parts := []string{"title=" + condition.Title, "expression=" + condition.Expression}
+	if condition.Description != "" {
+		parts = append(parts, "description="+condition.Description)
 	}
+	return gcloudDictFlagValue(parts)
+}
 
-	// Build gcloud command to add IAM policy binding
+// gcloudDictFlagValue joins key=value parts into one value of a gcloud
+// dictionary flag. When a part contains a comma, gcloud's ^DELIM^ syntax
+// switches to a separator that does not occur in any part.
+func gcloudDictFlagValue(parts []string) string {
+	joined := strings.Join(parts, "")
+	if !strings.Contains(joined, ",") {
+		return strings.Join(parts, ",")
+	}
+	for _, separator := range []string{";", "|", "~", "#", "@"} {
+		if !strings.Contains(joined, separator) {
+			return "^" + separator + "^" + strings.Join(parts, separator)
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// grantAccessArgs builds the gcloud arguments that grant role on a secret
+func grantAccessArgs(secretName, principal, role, project string, condition *Condition) []string {
 	gcloudArgs := []string{
 		"secrets", "add-iam-policy-binding", secretName,
 		"--member", principal,
 		"--role", role,
 	}
+	if condition != nil {
+		gcloudArgs = append(gcloudArgs, "--condition", conditionFlagValue(condition))
+	}
 	if project != "" {
 		gcloudArgs = append(gcloudArgs, "--project", project)
 	}
+	return gcloudArgs
+}
 
-	// Execute gcloud command
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-	_, err := gcloudCmd.Output()
-	if err != nil {
+// revokeAccessArgs builds the gcloud arguments that revoke role on a secret
+func revokeAccessArgs(secretName, principal, role, project string) []string {
+	gcloudArgs := []string{
+		"secrets", "remove-iam-policy-binding", secretName,
+		"--member", principal,
+		"--role", role,
+	}
+	if project != "" {
+		gcloudArgs = append(gcloudArgs, "--project", project)
+	}
+	return gcloudArgs
+}
+
+// runGcloudIAMChange runs a gcloud command that adds or removes an IAM binding
+func runGcloudIAMChange(gcloudArgs []string) error {
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
+	if _, err := gcloudCmd.Output(); err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
 			return formatGcloudError(string(exitError.Stderr))
 		}
 		return fmt.Errorf("failed to execute gcloud command: %w", err)
 	}
+	return nil
+}
 
-	fmt.Printf("Secret '%s': access granted to %s (%s)\n", secretName, principal, role)
+// formatGcloudCommandLine renders a gcloud invocation as a shell command
+// line, quoting the arguments that a shell would otherwise split or expand
+func formatGcloudCommandLine(gcloudArgs []string) string {
+	words := make([]string, 0, len(gcloudArgs)+1)
+	for _, arg := range append([]string{gcloudBinary()}, gcloudArgs...) {
+		if arg == "" || strings.ContainsFunc(arg, func(r rune) bool {
+			return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("@%+=:,./_-", r))
+		}) {
+			arg = shellQuote(arg)
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
+
+// previewAccessChange validates principal and prints the gcloud command an
+// access change would run, for --dry-run
+func previewAccessChange(description, principal string, gcloudArgs []string) error {
+	if err := validatePrincipalFormat(principal); err != nil {
+		return err
+	}
+	fmt.Printf("Dry run: would %s\n  %s\n", description, formatGcloudCommandLine(gcloudArgs))
 	return nil
 }
 
-// revokeSecretAccess revokes access from a principal for a secret
-func revokeSecretAccess(secretName, principal, role, project string) error {
+// grantSecretAccess grants access to a principal for a secret, bound by
+// condition when it is not nil
+func grantSecretAccess(secretName, principal, role, project string, condition *Condition) error {
 	// Validate the principal format
 	if err := validatePrincipalFormat(principal); err != nil {
 		return err
 	}
 
-	// Build gcloud command to remove IAM policy binding
-	gcloudArgs := []string{
-		"secrets", "remove-iam-policy-binding", secretName,
-		"--member", principal,
-		"--role", role,
+	if err := secretManager.AddIamBinding(secretName, project, principal, role, condition); err != nil {
+		return err
 	}
-	if project != "" {
-		gcloudArgs = append(gcloudArgs, "--project", project)
+
+	if condition != nil {
+		fmt.Printf("Secret '%s': access granted to %s (%s) with condition '%s'\n", secretName, principal, role, condition.Title)
+		return nil
 	}
+	fmt.Printf("Secret '%s': access granted to %s (%s)\n", secretName, principal, role)
+	return nil
+}
 
-	// Execute gcloud command
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-	_, err := gcloudCmd.Output()
-	if err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
-			return formatGcloudError(string(exitError.Stderr))
+// grantSecretAccessToPrincipals grants role on a secret to each principal in
+// turn. A failure is reported and the remaining principals are still granted;
+// the returned error counts the failures. A single principal's error is
+// returned as is. With dryRun the gcloud commands are printed instead.
+func grantSecretAccessToPrincipals(secretName string, principals []string, role, project string, condition *Condition, dryRun bool) error {
+	grant := func(principal string) error {
+		if dryRun {
+			return previewAccessChange(fmt.Sprintf("grant %s on secret '%s' to %s", role, secretName, principal),
+				principal, grantAccessArgs(secretName, principal, role, project, condition))
 		}
-		return fmt.Errorf("failed to execute gcloud command: %w", err)
+		return grantSecretAccess(secretName, principal, role, project, condition)
+	}
+	if len(principals) == 1 {
+		return grant(principals[0])
+	}
+
+	var failed []string
+	for _, principal := range principals {
+		if err := grant(principal); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to grant access to %s: %v\n", principal, err)
+			failed = append(failed, principal)
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("failed to grant access to %d of %d principals: %s", len(failed), len(principals), strings.Join(failed, ", "))
+}
+
+// revokeSecretAccess revokes access from a principal for a secret
+func revokeSecretAccess(secretName, principal, role, project string) error {
+	// Validate the principal format
+	if err := validatePrincipalFormat(principal); err != nil {
+		return err
+	}
+
+	if err := secretManager.RemoveIamBinding(secretName, project, principal, role); err != nil {
+		return err
 	}
 
 	fmt.Printf("Secret '%s': access revoked from %s (%s)\n", secretName, principal, role)
@@ -250,7 +584,7 @@ func revokeSecretAccess(secretName, principal, role, project string) error {
 }
 
 // displaySecretAccess formats and displays the access information
-func displaySecretAccess(secretName string, policy IAMPolicy, includeProject bool, project string) {
+func displaySecretAccess(secretName string, policy IAMPolicy, includeProject bool, project string, minRank int) {
 	if len(policy.Bindings) == 0 {
 		fmt.Printf("No explicit access permissions found for secret '%s'\n", secretName)
 		fmt.Println("Note: Project-level IAM permissions may still provide access")
@@ -275,18 +609,18 @@ func displaySecretAccess(secretName string, policy IAMPolicy, includeProject boo
 
 		if len(binding.Members) > 0 {
 			fmt.Println("  Members:")
-			// Sort members for consistent output
-			sortedMembers := make([]string, len(binding.Members))
-			copy(sortedMembers, binding.Members)
-			sort.Strings(sortedMembers)
-
-			for _, member := range sortedMembers {
+			for _, member := range normalizeMembers(binding.Members) {
 				fmt.Printf("    - %s\n", formatPrincipal(member))
 			}
 		}
 
 		if binding.Condition != nil {
-			fmt.Printf("  Condition: %s\n", binding.Condition.Expression)
+			indicator := "CONDITIONAL"
+			if expiry, ok := conditionExpiry(binding.Condition.Expression); ok {
+				indicator += " (" + formatConditionExpiry(expiry, time.Now()) + ")"
+			}
+			fmt.Printf("  Condition: %s\n", indicator)
+			fmt.Printf("    Expression: %s\n", binding.Condition.Expression)
 			if binding.Condition.Title != "" {
 				fmt.Printf("    Title: %s\n", binding.Condition.Title)
 			}
@@ -298,14 +632,159 @@ func displaySecretAccess(secretName string, policy IAMPolicy, includeProject boo
 		fmt.Println()
 	}
 
+	// Point out principals granted more than one role, a common sign of over-granting
+	roles := principalRoles(policy)
+	var multiRole []string
+	for member, memberRoles := range roles {
+		if len(memberRoles) > 1 {
+			multiRole = append(multiRole, member)
+		}
+	}
+	if len(multiRole) > 0 {
+		sort.Strings(multiRole)
+		fmt.Println("Principals with multiple roles:")
+		for _, member := range multiRole {
+			var shortRoles []string
+			for _, role := range roles[member] {
+				shortRoles = append(shortRoles, shortRoleName(role))
+			}
+			fmt.Printf("  - %s has %s\n", formatPrincipal(member), strings.Join(shortRoles, ", "))
+		}
+		fmt.Println()
+	}
+
 	// Display project-level permissions if requested
 	if includeProject {
-		displayProjectLevelAccess(project)
+		displayProjectLevelAccess(project, minRank)
+	}
+}
+
+// displaySecretAccessByPrincipal prints a secret's IAM policy grouped by
+// principal, listing every role each principal holds
+func displaySecretAccessByPrincipal(secretName string, policy IAMPolicy, includeProject bool, project string, minRank int) {
+	roles := principalRoles(policy)
+	if len(roles) == 0 {
+		fmt.Printf("No explicit access permissions found for secret '%s'\n", secretName)
+		fmt.Println("Note: Project-level IAM permissions may still provide access")
+		return
+	}
+
+	// Conditional grants are marked, since they may not apply at all times
+	now := time.Now()
+	conditional := make(map[string]string)
+	for _, binding := range policy.Bindings {
+		if binding.Condition == nil {
+			continue
+		}
+		marker := "conditional"
+		if expiry, ok := conditionExpiry(binding.Condition.Expression); ok {
+			marker += ", " + formatConditionExpiry(expiry, now)
+		}
+		for _, member := range binding.Members {
+			conditional[normalizeMember(member)+" "+binding.Role] = marker
+		}
+	}
+
+	members := make([]string, 0, len(roles))
+	for member := range roles {
+		members = append(members, member)
+	}
+	sort.Strings(members)
+
+	fmt.Printf("Access permissions for secret '%s' by principal:\n\n", secretName)
+	for _, member := range members {
+		fmt.Println(formatPrincipal(member))
+		for _, role := range roles[member] {
+			line := "  - " + role
+			if description := SecretManagerRoles[role]; description != "" {
+				line += " - " + description
+			}
+			if marker := conditional[member+" "+role]; marker != "" {
+				line += " (" + marker + ")"
+			}
+			fmt.Println(line)
+		}
+		fmt.Println()
+	}
+
+	if includeProject {
+		displayProjectLevelAccess(project, minRank)
+	}
+}
+
+// memberTypes maps lowercased member type prefixes to their canonical IAM spelling
+var memberTypes = map[string]string{
+	"user":                  "user",
+	"group":                 "group",
+	"serviceaccount":        "serviceAccount",
+	"domain":                "domain",
+	"principal":             "principal",
+	"principalset":          "principalSet",
+	"deleted":               "deleted",
+	"allusers":              "allUsers",
+	"allauthenticatedusers": "allAuthenticatedUsers",
+}
+
+// normalizeMember trims a member and restores the canonical casing of its type
+// prefix (User:a@b.com becomes user:a@b.com), so the same identity compares equal
+func normalizeMember(member string) string {
+	member = strings.TrimSpace(member)
+	parts := strings.SplitN(member, ":", 2)
+	canonical, ok := memberTypes[strings.ToLower(parts[0])]
+	if !ok {
+		return member
+	}
+	if len(parts) == 1 {
+		return canonical
+	}
+	return canonical + ":" + strings.TrimSpace(parts[1])
+}
+
+// normalizeMembers returns the normalized, deduplicated members in sorted order
+func normalizeMembers(members []string) []string {
+	seen := make(map[string]bool, len(members))
+	var normalized []string
+	for _, member := range members {
+		member = normalizeMember(member)
+		if !seen[member] {
+			seen[member] = true
+			normalized = append(normalized, member)
+		}
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// principalRoles maps each normalized member of a policy to the distinct roles
+// it holds across all bindings, sorted
+func principalRoles(policy IAMPolicy) map[string][]string {
+	roles := make(map[string][]string)
+	for _, binding := range policy.Bindings {
+		for _, member := range normalizeMembers(binding.Members) {
+			found := false
+			for _, role := range roles[member] {
+				if role == binding.Role {
+					found = true
+					break
+				}
+			}
+			if !found {
+				roles[member] = append(roles[member], binding.Role)
+			}
+		}
+	}
+	for member := range roles {
+		sort.Strings(roles[member])
 	}
+	return roles
 }
 
 // formatPrincipal
 func formatPrincipal(principal string) string {
+	if federated, ok := parseFederatedPrincipal(principal); ok {
+		return federated.String()
+	}
+
 	parts := strings.SplitN(principal, ":", 2)
 	if len(parts) != 2 {
 		return principal
@@ -328,14 +807,94 @@ func formatPrincipal(principal string) string {
 	}
 }
 
-// getProjectID gets the project ID, using gcloud config if not provided
+// Member prefixes for federated (workforce and workload identity) principals
+const (
+	federatedPrincipalPrefix    = "principal://"
+	federatedPrincipalSetPrefix = "principalSet://"
+)
+
+// federatedPrincipal is a parsed principal:// or principalSet:// member
+type federatedPrincipal struct {
+	IsSet    bool   // principalSet:// rather than principal://
+	PoolType string // "Workforce Identity", "Workload Identity", or "" when the pool is not recognized
+	Pool     string // pool ID
+	Selector string // subject, group/ID, attribute.NAME/VALUE, or * within the pool
+	Path     string // everything after the scheme, used when the pool is not recognized
+}
+
+// parseFederatedPrincipal parses workforce and workload identity members such as
+// principal://iam.googleapis.com/locations/global/workforcePools/POOL/subject/SUBJECT or
+// principalSet://iam.googleapis.com/projects/NUMBER/locations/global/workloadIdentityPools/POOL/group/GROUP
+func parseFederatedPrincipal(member string) (federatedPrincipal, bool) {
+	var parsed federatedPrincipal
+	switch {
+	case strings.HasPrefix(member, federatedPrincipalPrefix):
+		parsed.Path = strings.TrimPrefix(member, federatedPrincipalPrefix)
+	case strings.HasPrefix(member, federatedPrincipalSetPrefix):
+		parsed.IsSet = true
+		parsed.Path = strings.TrimPrefix(member, federatedPrincipalSetPrefix)
+	default:
+		return parsed, false
+	}
+
+	segments := strings.Split(parsed.Path, "/")
+	for i := 0; i+2 < len(segments); i++ {
+		switch segments[i] {
+		case "workforcePools":
+			parsed.PoolType = "Workforce Identity"
+		case "workloadIdentityPools":
+			parsed.PoolType = "Workload Identity"
+		default:
+			continue
+		}
+		parsed.Pool = segments[i+1]
+		parsed.Selector = strings.Join(segments[i+2:], "/")
+		return parsed, true
+	}
+
+	return parsed, true
+}
+
+// String describes the federated principal for display
+func (p federatedPrincipal) String() string {
+	if p.PoolType == "" {
+		if p.IsSet {
+			return fmt.Sprintf("Principal Set: %s", p.Path)
+		}
+		return fmt.Sprintf("Principal: %s", p.Path)
+	}
+
+	if !p.IsSet {
+		return fmt.Sprintf("%s: %s (pool %s)", p.PoolType, strings.TrimPrefix(p.Selector, "subject/"), p.Pool)
+	}
+
+	description := p.Selector
+	switch {
+	case p.Selector == "*":
+		description = "all identities"
+	case strings.HasPrefix(p.Selector, "group/"):
+		description = "group " + strings.TrimPrefix(p.Selector, "group/")
+	case strings.HasPrefix(p.Selector, "attribute."):
+		if name, value, ok := strings.Cut(strings.TrimPrefix(p.Selector, "attribute."), "/"); ok {
+			description = fmt.Sprintf("%s=%s", name, value)
+		}
+	}
+	return fmt.Sprintf("%s Set: %s (pool %s)", p.PoolType, description, p.Pool)
+}
+
+// getProjectID gets the project ID, using the backend's default project if
+// not provided
 func getProjectID(project string) string {
 	if project != "" {
 		return project
 	}
+	return secretManager.DefaultProject()
+}
 
-	// Try to get from gcloud config
-	gcloudCmd := exec.Command("gcloud", "config", "get-value", "project")
+// gcloudConfigProject returns the project set in the gcloud config, or an
+// empty string when there is none
+func gcloudConfigProject() string {
+	gcloudCmd := exec.Command(gcloudBinary(), "config", "get-value", "project")
 	output, err := gcloudCmd.Output()
 	if err != nil {
 		return ""
@@ -348,7 +907,7 @@ func getProjectID(project string) string {
 }
 
 // displayProjectLevelAccess displays project-level permissions that affect Secret Manager access
-func displayProjectLevelAccess(project string) {
+func displayProjectLevelAccess(project string, minRank int) {
 	projectID := getProjectID(project)
 	if projectID == "" {
 		fmt.Printf("Warning: %v\n", missingProjectIDError())
@@ -357,39 +916,16 @@ func displayProjectLevelAccess(project string) {
 
 	fmt.Printf("\n--- Project-Level Permissions (Project: %s) ---\n\n", projectID)
 
-	// Get project IAM policy
-	gcloudArgs := []string{"projects", "get-iam-policy", projectID, "--format", "json"}
-
-	// Execute gcloud command
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-	output, err := gcloudCmd.Output()
+	policy, err := secretManager.GetProjectIamPolicy(projectID)
 	if err != nil {
 		fmt.Printf("Warning: Could not retrieve project-level IAM policy: %v\n", err)
 		return
 	}
 
-	// Parse JSON response
-	var policy IAMPolicy
-	if err := json.Unmarshal(output, &policy); err != nil {
-		fmt.Printf("Warning: Could not parse project-level IAM policy: %v\n", err)
-		return
-	}
-
-	// Define roles that provide Secret Manager access
-	secretManagerRoles := map[string]bool{
-		"roles/secretmanager.admin":                true,
-		"roles/secretmanager.secretAccessor":       true,
-		"roles/secretmanager.viewer":               true,
-		"roles/secretmanager.secretVersionManager": true,
-		"roles/secretmanager.secretVersionAdder":   true,
-		"roles/editor":                             true,
-		"roles/owner":                              true,
-	}
-
-	// Filter and display only Secret Manager related roles
+	// Filter and display only Secret Manager related roles (those with a rank)
 	found := false
 	for _, binding := range policy.Bindings {
-		if secretManagerRoles[binding.Role] && len(binding.Members) > 0 {
+		if rank := roleRanks[binding.Role]; rank > 0 && rank >= minRank && len(binding.Members) > 0 {
 			if !found {
 				found = true
 			}
@@ -441,100 +977,184 @@ func displayProjectLevelAccess(project string) {
 	}
 }
 
-// showProjectLevelPermissions shows project-level permissions without requiring a secret
-func showProjectLevelPermissions(project string) error {
-	projectID := getProjectID(project)
-	if projectID == "" {
-		return missingProjectIDError()
+// ProjectAccessBinding is a project-level binding that grants Secret Manager
+// access, as exported by 'access project --format'
+type ProjectAccessBinding struct {
+	Role            string     `json:"role"`
+	RoleDescription string     `json:"roleDescription"`
+	Scope           string     `json:"scope"`
+	Members         []string   `json:"members"`
+	Condition       *Condition `json:"condition,omitempty"`
+}
+
+// ProjectAccessReport is the JSON document written by 'access project --format json'
+type ProjectAccessReport struct {
+	Project  string                 `json:"project"`
+	Bindings []ProjectAccessBinding `json:"bindings"`
+}
+
+// projectAccessScope is the scope reported for project-level bindings
+const projectAccessScope = "project"
+
+// projectSecretManagerRoles are the project-level roles that provide Secret Manager access
+var projectSecretManagerRoles = map[string]bool{
+	"roles/secretmanager.admin":                true,
+	"roles/secretmanager.secretAccessor":       true,
+	"roles/secretmanager.viewer":               true,
+	"roles/secretmanager.secretVersionManager": true,
+	"roles/secretmanager.secretVersionAdder":   true,
+	"roles/editor":                             true,
+	"roles/owner":                              true,
+}
+
+// projectRoleDescription describes a project-level role that grants Secret Manager access
+func projectRoleDescription(role string) string {
+	if description := SecretManagerRoles[role]; description != "" {
+		return description
+	}
+	switch role {
+	case "roles/editor":
+		return "Editor (includes Secret Manager access)"
+	case "roles/owner":
+		return "Owner (includes full Secret Manager access)"
 	}
+	return role
+}
 
-	fmt.Printf("Project-Level Secret Manager Permissions (Project: %s)\n\n", projectID)
+// projectAccessBindings returns the bindings of a project policy that grant
+// Secret Manager access, sorted by role, with sorted members
+func projectAccessBindings(policy IAMPolicy) []ProjectAccessBinding {
+	bindings := make([]ProjectAccessBinding, 0)
+	for _, binding := range policy.Bindings {
+		if !projectSecretManagerRoles[binding.Role] || len(binding.Members) == 0 {
+			continue
+		}
+		members := make([]string, len(binding.Members))
+		copy(members, binding.Members)
+		sort.Strings(members)
+		bindings = append(bindings, ProjectAccessBinding{
+			Role:            binding.Role,
+			RoleDescription: projectRoleDescription(binding.Role),
+			Scope:           projectAccessScope,
+			Members:         members,
+			Condition:       binding.Condition,
+		})
+	}
+	sort.SliceStable(bindings, func(i, j int) bool {
+		return bindings[i].Role < bindings[j].Role
+	})
+	return bindings
+}
+
+// encodeProjectAccessReport serializes the report as JSON, or as CSV with one
+// row per member so each grant can be reviewed on its own line
+func encodeProjectAccessReport(report ProjectAccessReport, format string) ([]byte, error) {
+	if format == "json" {
+		jsonOutput, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal JSON output: %w", err)
+		}
+		return append(jsonOutput, '\n'), nil
+	}
 
-	// Get project IAM policy
+	records := [][]string{{"project", "role", "role_description", "scope", "member", "condition_title", "condition_expression", "condition_description"}}
+	for _, binding := range report.Bindings {
+		var title, expression, description string
+		if binding.Condition != nil {
+			title, expression, description = binding.Condition.Title, binding.Condition.Expression, binding.Condition.Description
+		}
+		for _, member := range binding.Members {
+			records = append(records, []string{report.Project, binding.Role, binding.RoleDescription, binding.Scope, member, title, expression, description})
+		}
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(records); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// fetchProjectIAMPolicy retrieves the IAM policy of a project
+func fetchProjectIAMPolicy(projectID string) (*IAMPolicy, error) {
 	gcloudArgs := []string{"projects", "get-iam-policy", projectID, "--format", "json"}
 
-	// Execute gcloud command
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
 	output, err := gcloudCmd.Output()
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
-			return formatGcloudError(string(exitError.Stderr))
+			return nil, formatGcloudError(string(exitError.Stderr))
 		}
-		return fmt.Errorf("failed to execute gcloud command: %w", err)
+		return nil, fmt.Errorf("failed to execute gcloud command: %w", err)
 	}
 
-	// Parse JSON response
 	var policy IAMPolicy
 	if err := json.Unmarshal(output, &policy); err != nil {
-		return fmt.Errorf("failed to parse project IAM policy: %w", err)
+		return nil, fmt.Errorf("failed to parse project IAM policy: %w", err)
 	}
+	return &policy, nil
+}
 
-	// Define roles that provide Secret Manager access
-	secretManagerRoles := map[string]bool{
-		"roles/secretmanager.admin":                true,
-		"roles/secretmanager.secretAccessor":       true,
-		"roles/secretmanager.viewer":               true,
-		"roles/secretmanager.secretVersionManager": true,
-		"roles/secretmanager.secretVersionAdder":   true,
-		"roles/editor":                             true,
-		"roles/owner":                              true,
+// showProjectLevelPermissions shows project-level permissions without requiring
+// a secret. With a format (json or csv) the permissions are exported instead,
+// to stdout or to outputPath.
+func showProjectLevelPermissions(project, format, outputPath string) error {
+	projectID := getProjectID(project)
+	if projectID == "" {
+		return missingProjectIDError()
 	}
 
-	// Filter and display only Secret Manager related roles
-	found := false
-	// Sort bindings by role for consistent output
-	sort.Slice(policy.Bindings, func(i, j int) bool {
-		return policy.Bindings[i].Role < policy.Bindings[j].Role
-	})
+	if format == "" {
+		fmt.Printf("Project-Level Secret Manager Permissions (Project: %s)\n\n", projectID)
+	}
 
-	for _, binding := range policy.Bindings {
-		if secretManagerRoles[binding.Role] && len(binding.Members) > 0 {
-			if !found {
-				found = true
-			}
+	policy, err := secretManager.GetProjectIamPolicy(projectID)
+	if err != nil {
+		return err
+	}
+	bindings := projectAccessBindings(*policy)
 
-			roleDescription := SecretManagerRoles[binding.Role]
-			if roleDescription == "" {
-				if binding.Role == "roles/editor" {
-					roleDescription = "Editor (includes Secret Manager access)"
-				} else if binding.Role == "roles/owner" {
-					roleDescription = "Owner (includes full Secret Manager access)"
-				} else {
-					roleDescription = binding.Role
-				}
-			}
+	if format != "" {
+		data, err := encodeProjectAccessReport(ProjectAccessReport{Project: projectID, Bindings: bindings}, format)
+		if err != nil {
+			return err
+		}
+		if outputPath == "" {
+			_, err := os.Stdout.Write(data)
+			return err
+		}
+		if err := atomicWriteFile(outputPath, data, 0644); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		fmt.Printf("Exported %d project-level bindings to %s\n", len(bindings), outputPath)
+		return nil
+	}
 
-			fmt.Printf("Role: %s\n", roleDescription)
-			fmt.Printf("  Role ID: %s\n", binding.Role)
-			fmt.Printf("  Scope: Project-wide (affects all secrets in project)\n")
+	for _, binding := range bindings {
+		fmt.Printf("Role: %s\n", binding.RoleDescription)
+		fmt.Printf("  Role ID: %s\n", binding.Role)
+		fmt.Printf("  Scope: Project-wide (affects all secrets in project)\n")
 
-			if len(binding.Members) > 0 {
-				fmt.Println("  Members:")
-				// Sort members for consistent output
-				sortedMembers := make([]string, len(binding.Members))
-				copy(sortedMembers, binding.Members)
-				sort.Strings(sortedMembers)
+		fmt.Println("  Members:")
+		for _, member := range binding.Members {
+			fmt.Printf("    - %s\n", formatPrincipal(member))
+		}
 
-				for _, member := range sortedMembers {
-					fmt.Printf("    - %s\n", formatPrincipal(member))
-				}
+		if binding.Condition != nil {
+			fmt.Printf("  Condition: %s\n", binding.Condition.Expression)
+			if binding.Condition.Title != "" {
+				fmt.Printf("    Title: %s\n", binding.Condition.Title)
 			}
-
-			if binding.Condition != nil {
-				fmt.Printf("  Condition: %s\n", binding.Condition.Expression)
-				if binding.Condition.Title != "" {
-					fmt.Printf("    Title: %s\n", binding.Condition.Title)
-				}
-				if binding.Condition.Description != "" {
-					fmt.Printf("    Description: %s\n", binding.Condition.Description)
-				}
+			if binding.Condition.Description != "" {
+				fmt.Printf("    Description: %s\n", binding.Condition.Description)
 			}
-
-			fmt.Println()
 		}
+
+		fmt.Println()
 	}
 
-	if !found {
+	if len(bindings) == 0 {
 		fmt.Println("No project-level Secret Manager permissions found.")
 		fmt.Println("\nThis means:")
 		fmt.Println("  - No users/groups have project-wide Secret Manager access")
@@ -556,16 +1176,33 @@ func init() {
 
 	// Flags for list command
 	accessListCmd.Flags().Bool("include-project", false, "Include project-level permissions that grant access to secrets")
+	accessListCmd.Flags().Bool("include-ancestors", false, "Include Secret Manager roles inherited from the project's folders and organization")
+	accessListCmd.Flags().String("min-role", "", "Only show bindings granting at least this role: viewer, versionAdder, versionManager, accessor, or admin")
+	accessListCmd.Flags().Bool("by-principal", false, "Group the output by principal, listing every role each one holds")
+	accessListCmd.Flags().String("format", "", "Output format: text (default, also table), json, yaml, or markdown")
+
+	// Flags for project command
+	accessProjectCmd.Flags().String("format", "", "Output format: text (default), json, or csv")
+	accessProjectCmd.Flags().StringP("output", "o", "", "Write the --format export to this file instead of stdout")
 
 	// Flags for grant and revoke commands
-	accessGrantCmd.Flags().String("principal", "", "Principal to grant access to (required) - format: user:email@domain.com, group:group@domain.com, etc.")
-	accessGrantCmd.Flags().String("role", "roles/secretmanager.secretAccessor", "Role to grant (default: roles/secretmanager.secretAccessor)")
+	accessGrantCmd.Flags().StringSlice("principal", nil, "Principal to grant access to (required, repeatable) - format: user:email@domain.com, group:group@domain.com, etc.")
+	accessGrantCmd.Flags().String("role", defaultAccessRole, "Role to grant (default: roles/secretmanager.secretAccessor)")
+	accessGrantCmd.Flags().Bool("project-level", false, "Grant the role on the project IAM policy (applies to all secrets)")
+	accessGrantCmd.Flags().BoolP("force", "f", false, "Skip the project ID confirmation for --project-level and the allUsers/allAuthenticatedUsers confirmation")
+	accessGrantCmd.Flags().Bool("dry-run", false, "Print the gcloud command that would run without changing IAM")
+	accessGrantCmd.Flags().String("condition-title", "", "Title of an IAM condition to attach to the binding (requires --condition-expression)")
+	accessGrantCmd.Flags().String("condition-expression", "", "CEL expression of the IAM condition, e.g. request.time < timestamp(\"2025-12-31T00:00:00Z\")")
+	accessGrantCmd.Flags().String("condition-description", "", "Optional description of the IAM condition")
 	if err := accessGrantCmd.MarkFlagRequired("principal"); err != nil {
 		panic(fmt.Sprintf("Failed to mark principal flag as required for grant command: %v", err))
 	}
 
 	accessRevokeCmd.Flags().String("principal", "", "Principal to revoke access from (required) - format: user:email@domain.com, group:group@domain.com, etc.")
-	accessRevokeCmd.Flags().String("role", "roles/secretmanager.secretAccessor", "Role to revoke (default: roles/secretmanager.secretAccessor)")
+	accessRevokeCmd.Flags().String("role", defaultAccessRole, "Role to revoke (default: roles/secretmanager.secretAccessor)")
+	accessRevokeCmd.Flags().Bool("project-level", false, "Revoke the role from the project IAM policy (applies to all secrets)")
+	accessRevokeCmd.Flags().BoolP("force", "f", false, "Skip the project ID confirmation for --project-level")
+	accessRevokeCmd.Flags().Bool("dry-run", false, "Print the gcloud command that would run without changing IAM")
 	if err := accessRevokeCmd.MarkFlagRequired("principal"); err != nil {
 		panic(fmt.Sprintf("Failed to mark principal flag as required for revoke command: %v", err))
 	}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ProjectAncestor is one entry of 'gcloud projects get-ancestors': the
+// project itself, then its folders from nearest to farthest, then the organization
+type ProjectAncestor struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// ancestorPermissions names the permission needed to read each ancestor's
+// IAM policy, for the warning shown when it is missing
+var ancestorPermissions = map[string]string{
+	"folder":       "resourcemanager.folders.getIamPolicy",
+	"organization": "resourcemanager.organizations.getIamPolicy",
+}
+
+// fetchProjectAncestors returns the resource hierarchy above a project
+func fetchProjectAncestors(projectID string) ([]ProjectAncestor, error) {
+	gcloudCmd := exec.Command(gcloudBinary(), "projects", "get-ancestors", projectID, "--format", "json")
+	output, err := gcloudCmd.Output()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return nil, formatGcloudError(string(exitError.Stderr))
+		}
+		return nil, fmt.Errorf("failed to execute gcloud command: %w", err)
+	}
+
+	var ancestors []ProjectAncestor
+	if err := json.Unmarshal(output, &ancestors); err != nil {
+		return nil, fmt.Errorf("failed to parse project ancestors: %w", err)
+	}
+	return ancestors, nil
+}
+
+// fetchAncestorIAMPolicy retrieves the IAM policy of a folder or organization
+func fetchAncestorIAMPolicy(ancestor ProjectAncestor) (*IAMPolicy, error) {
+	var gcloudArgs []string
+	switch ancestor.Type {
+	case "folder":
+		gcloudArgs = []string{"resource-manager", "folders", "get-iam-policy", ancestor.ID, "--format", "json"}
+	case "organization":
+		gcloudArgs = []string{"organizations", "get-iam-policy", ancestor.ID, "--format", "json"}
+	default:
+		return nil, fmt.Errorf("unsupported ancestor type '%s'", ancestor.Type)
+	}
+
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
+	output, err := gcloudCmd.Output()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return nil, formatGcloudError(string(exitError.Stderr))
+		}
+		return nil, fmt.Errorf("failed to execute gcloud command: %w", err)
+	}
+
+	var policy IAMPolicy
+	if err := json.Unmarshal(output, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse %s IAM policy: %w", ancestor.Type, err)
+	}
+	return &policy, nil
+}
+
+// ancestorAccessBindings returns the bindings of a folder or organization
+// policy that grant Secret Manager access to the secrets below it, at least
+// minRank, with the ancestor type as their scope
+func ancestorAccessBindings(policy IAMPolicy, ancestor ProjectAncestor, minRank int) []ProjectAccessBinding {
+	bindings := make([]ProjectAccessBinding, 0)
+	for _, binding := range projectAccessBindings(policy) {
+		if roleRanks[binding.Role] < minRank {
+			continue
+		}
+		binding.Scope = ancestor.Type
+		bindings = append(bindings, binding)
+	}
+	return bindings
+}
+
+// displayAncestorAccess prints the Secret Manager roles granted on the folders
+// and organization above the project for 'access list --include-ancestors'.
+// Ancestors whose policy cannot be read are reported and skipped.
+func displayAncestorAccess(project string, minRank int) {
+	projectID := getProjectID(project)
+	if projectID == "" {
+		fmt.Printf("Warning: %v\n", missingProjectIDError())
+		return
+	}
+
+	ancestors, err := secretManager.GetAncestors(projectID)
+	if err != nil {
+		fmt.Printf("\nWarning: Could not resolve the ancestry of project '%s' (requires resourcemanager.projects.get); folder and organization permissions are not shown\n", projectID)
+		return
+	}
+
+	shown := 0
+	for _, ancestor := range ancestors {
+		if ancestor.Type != "folder" && ancestor.Type != "organization" {
+			continue
+		}
+		shown++
+		label := strings.ToUpper(ancestor.Type[:1]) + ancestor.Type[1:]
+		fmt.Printf("\n--- %s-Level Permissions (%s: %s) ---\n\n", label, label, ancestor.ID)
+
+		policy, err := secretManager.GetAncestorIamPolicy(ancestor)
+		if err != nil {
+			if strings.Contains(strings.ToLower(err.Error()), "permission") {
+				fmt.Printf("Warning: No permission to read the IAM policy of %s %s (requires %s); skipped\n", ancestor.Type, ancestor.ID, ancestorPermissions[ancestor.Type])
+			} else {
+				fmt.Printf("Warning: Could not retrieve the IAM policy of %s %s: %v\n", ancestor.Type, ancestor.ID, err)
+			}
+			continue
+		}
+
+		bindings := ancestorAccessBindings(*policy, ancestor, minRank)
+		if len(bindings) == 0 {
+			fmt.Printf("No %s-level Secret Manager permissions found.\n", ancestor.Type)
+			continue
+		}
+		for _, binding := range bindings {
+			fmt.Printf("Role: %s\n", binding.RoleDescription)
+			fmt.Printf("  Role ID: %s\n", binding.Role)
+			fmt.Printf("  Origin: %s %s (inherited by every project below it)\n", binding.Scope, ancestor.ID)
+			fmt.Println("  Members:")
+			for _, member := range binding.Members {
+				fmt.Printf("    - %s\n", formatPrincipal(member))
+			}
+			if binding.Condition != nil {
+				fmt.Printf("  Condition: %s\n", binding.Condition.Expression)
+			}
+			fmt.Println()
+		}
+	}
+
+	if shown == 0 {
+		fmt.Printf("\nProject '%s' has no folder or organization ancestors.\n", projectID)
+	}
+}
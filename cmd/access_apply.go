@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// accessApplyColumns are the columns of an 'access apply' CSV file; role may
+// be omitted, and defaults to roles/secretmanager.secretAccessor
+var accessApplyColumns = []string{"secret", "principal", "role", "action"}
+
+// accessApplyChange is a validated row of an 'access apply' CSV file
+type accessApplyChange struct {
+	SecretName string // with the configured prefix
+	Principal  string
+	Role       string
+	Action     string // applyActionGrant or applyActionRevoke
+}
+
+// accessApplyStats counts the outcome of 'access apply', like importStats
+type accessApplyStats struct {
+	granted int
+	revoked int
+	failed  int
+}
+
+var accessApplyCmd = &cobra.Command{
+	Use:   "apply <csv-file>",
+	Short: "Grant and revoke access in bulk from a CSV file",
+	Long: `Grant and revoke secret access in bulk from a CSV file with the columns
+secret, principal, role, and action:
+
+  secret,principal,role,action
+  db-password,user:alice@example.com,roles/secretmanager.secretAccessor,grant
+  api-key,group:devs@example.com,,grant
+  db-password,user:bob@example.com,roles/secretmanager.secretAccessor,revoke
+
+action is grant or revoke, and an empty role (or no role column) means
+roles/secretmanager.secretAccessor. The configured prefix is added to secret
+names. Every row is validated before any change is made: the action, the
+principal format (as in 'access grant'), and the role, which must be a role ID
+such as roles/secretmanager.viewer or projects/ID/roles/NAME. Grants to
+allUsers or allAuthenticatedUsers, which make a secret public, are invalid
+unless --force is given. If any row is invalid, every invalid row is reported
+and nothing is applied.
+
+Valid rows then run in order; a row that fails is reported and the remaining
+rows still run. A summary of granted, revoked, and failed rows is printed, and
+the command exits non-zero if any row failed.
+
+Use --dry-run to validate the file and print the intended changes without
+calling gcloud.
+
+Examples:
+  gsecutil access apply access-changes.csv --dry-run
+  gsecutil access apply access-changes.csv --project my-project`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, _ := cmd.Flags().GetString("project")
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		force, _ := cmd.Flags().GetBool("force")
+
+		records, header, err := readCsvFile(args[0])
+		if err != nil {
+			return err
+		}
+		columns, err := accessApplyColumnIndexes(header)
+		if err != nil {
+			return err
+		}
+		return runAccessApply(records, columns, project, dryRun, force)
+	},
+}
+
+func init() {
+	accessCmd.AddCommand(accessApplyCmd)
+	accessApplyCmd.Flags().Bool("dry-run", false, "Validate the file and show the intended changes without calling gcloud")
+	accessApplyCmd.Flags().BoolP("force", "f", false, "Allow grants to allUsers and allAuthenticatedUsers")
+}
+
+// accessApplyColumnIndexes maps the accessApplyColumns to their positions in
+// header, matched case-insensitively. role is optional; the others are required.
+func accessApplyColumnIndexes(header []string) (map[string]int, error) {
+	columns := make(map[string]int)
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if !slices.Contains(accessApplyColumns, column) {
+			return nil, fmt.Errorf("unknown column '%s' in CSV header (expected %s)", column, strings.Join(accessApplyColumns, ", "))
+		}
+		if _, seen := columns[column]; seen {
+			return nil, fmt.Errorf("duplicate column '%s' in CSV header", column)
+		}
+		columns[column] = i
+	}
+	for _, name := range []string{"secret", "principal", "action"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("CSV header is missing the '%s' column", name)
+		}
+	}
+	return columns, nil
+}
+
+// parseAccessApplyRecord validates a CSV row and resolves its secret name
+func parseAccessApplyRecord(columns map[string]int, record []string) (accessApplyChange, error) {
+	value := func(column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	change := accessApplyChange{
+		Principal: value("principal"),
+		Role:      value("role"),
+		Action:    strings.ToLower(value("action")),
+	}
+	secret := value("secret")
+	if secret == "" {
+		return change, fmt.Errorf("secret is empty")
+	}
+	change.SecretName = AddPrefixToSecretName(secret)
+	if change.Action != applyActionGrant && change.Action != applyActionRevoke {
+		return change, fmt.Errorf("invalid action '%s' (use grant or revoke)", value("action"))
+	}
+	if err := validatePrincipalFormat(change.Principal); err != nil {
+		return change, err
+	}
+	if change.Role == "" {
+		change.Role = defaultAccessRole
+	} else if err := validateRoleFormat(change.Role); err != nil {
+		return change, err
+	}
+	return change, nil
+}
+
+// validateAccessApplyRecords validates every CSV row before any change is
+// made, printing each invalid row. Public grants are invalid unless force is set.
+func validateAccessApplyRecords(records [][]string, columns map[string]int, force bool) ([]accessApplyChange, error) {
+	changes := make([]accessApplyChange, 0, len(records))
+	invalid := 0
+	for i, record := range records {
+		change, err := parseAccessApplyRecord(columns, record)
+		if err == nil && change.Action == applyActionGrant {
+			err = checkBulkPublicGrant(change.Principal, force)
+		}
+		if err != nil {
+			fmt.Printf("Error: Row %d: %v\n", i+2, err) // the header is row 1
+			invalid++
+			continue
+		}
+		changes = append(changes, change)
+	}
+	if invalid > 0 {
+		return nil, fmt.Errorf("%d invalid row(s); no access was changed", invalid)
+	}
+	return changes, nil
+}
+
+// runAccessApply validates every CSV row, then applies them in order,
+// continuing after failures, and prints a summary. With dryRun the changes
+// are only printed. Public grants are refused unless force is set.
+func runAccessApply(records [][]string, columns map[string]int, project string, dryRun, force bool) error {
+	changes, err := validateAccessApplyRecords(records, columns, force)
+	if err != nil {
+		return err
+	}
+
+	stats := &accessApplyStats{}
+	for i, change := range changes {
+		row := i + 2 // the header is row 1
+		if dryRun {
+			if change.Action == applyActionGrant {
+				fmt.Printf("Would grant %s to %s on secret '%s'\n", change.Role, change.Principal, change.SecretName)
+				stats.granted++
+			} else {
+				fmt.Printf("Would revoke %s from %s on secret '%s'\n", change.Role, change.Principal, change.SecretName)
+				stats.revoked++
+			}
+			continue
+		}
+
+		if change.Action == applyActionGrant {
+			err = grantSecretAccess(change.SecretName, change.Principal, change.Role, project, nil)
+		} else {
+			err = revokeSecretAccess(change.SecretName, change.Principal, change.Role, project)
+		}
+		switch {
+		case err != nil:
+			fmt.Printf("Error: Row %d (%s): %v\n", row, change.SecretName, err)
+			stats.failed++
+		case change.Action == applyActionGrant:
+			stats.granted++
+		default:
+			stats.revoked++
+		}
+	}
+
+	fmt.Println("\nAccess Apply Summary:")
+	if dryRun {
+		fmt.Printf("  Would grant: %d\n", stats.granted)
+		fmt.Printf("  Would revoke: %d\n", stats.revoked)
+	} else {
+		fmt.Printf("  Granted: %d\n", stats.granted)
+		fmt.Printf("  Revoked: %d\n", stats.revoked)
+	}
+	fmt.Printf("  Failed: %d\n", stats.failed)
+
+	if stats.failed > 0 {
+		return fmt.Errorf("%d row(s) failed", stats.failed)
+	}
+	return nil
+}
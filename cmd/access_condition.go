@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"regexp"
+	"sort"
+	"time"
+)
+
+// conditionExpiryPattern matches the upper time bound of a time-bound grant,
+// as written by the Cloud Console: request.time < timestamp("2025-01-01T00:00:00Z")
+var conditionExpiryPattern = regexp.MustCompile(`request\.time\s*<=?\s*timestamp\(\s*["']([^"']+)["']\s*\)`)
+
+// conditionExpiry returns the time after which a condition no longer grants
+// access, or false when the expression has no request.time upper bound. When
+// there are several bounds the earliest applies.
+func conditionExpiry(expression string) (time.Time, bool) {
+	var expiry time.Time
+	found := false
+	for _, match := range conditionExpiryPattern.FindAllStringSubmatch(expression, -1) {
+		t, err := time.Parse(time.RFC3339, match[1])
+		if err != nil {
+			continue
+		}
+		if !found || t.Before(expiry) {
+			expiry = t
+			found = true
+		}
+	}
+	return expiry, found
+}
+
+// formatConditionExpiry describes a condition's expiry relative to now, e.g.
+// "expires 2025-01-01 00:00 UTC" or "expired 2024-06-30 12:00 UTC"
+func formatConditionExpiry(expiry, now time.Time) string {
+	formatted := expiry.UTC().Format(datetimeFormat) + " UTC"
+	if !expiry.After(now) {
+		return "expired " + formatted
+	}
+	return "expires " + formatted
+}
+
+// SecretAccessBinding is one binding in 'access list --format json|yaml'
+type SecretAccessBinding struct {
+	Role            string     `json:"role" yaml:"role"`
+	RoleDescription string     `json:"roleDescription,omitempty" yaml:"roleDescription,omitempty"`
+	Members         []string   `json:"members" yaml:"members"`
+	Condition       *Condition `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Expires         *time.Time `json:"expires,omitempty" yaml:"expires,omitempty"`
+}
+
+// SecretAccessReport is the structured output of 'access list --format json|yaml'.
+// Project and ProjectBindings are only set with --include-project.
+type SecretAccessReport struct {
+	Secret          string                `json:"secret" yaml:"secret"`
+	Bindings        []SecretAccessBinding `json:"bindings" yaml:"bindings"`
+	Project         string                `json:"project,omitempty" yaml:"project,omitempty"`
+	ProjectBindings []SecretAccessBinding `json:"projectBindings,omitempty" yaml:"projectBindings,omitempty"`
+}
+
+// newSecretAccessReport converts a secret's IAM policy into the structured
+// report, with the full condition of each binding and the expiry of time-bound ones
+func newSecretAccessReport(secretName string, policy IAMPolicy) SecretAccessReport {
+	report := SecretAccessReport{Secret: secretName, Bindings: make([]SecretAccessBinding, 0, len(policy.Bindings))}
+	for _, binding := range policy.Bindings {
+		report.Bindings = append(report.Bindings, newSecretAccessBinding(binding, SecretManagerRoles[binding.Role]))
+	}
+	sortSecretAccessBindings(report.Bindings)
+	return report
+}
+
+// addProjectBindings adds the project-level bindings that give access to the
+// secret, those with a role ranked at least minRank, as listed by
+// --include-project in the text output
+func (report *SecretAccessReport) addProjectBindings(projectID string, policy IAMPolicy, minRank int) {
+	report.Project = projectID
+	report.ProjectBindings = make([]SecretAccessBinding, 0)
+	for _, binding := range policy.Bindings {
+		if rank := roleRanks[binding.Role]; rank > 0 && rank >= minRank && len(binding.Members) > 0 {
+			report.ProjectBindings = append(report.ProjectBindings, newSecretAccessBinding(binding, projectRoleDescription(binding.Role)))
+		}
+	}
+	sortSecretAccessBindings(report.ProjectBindings)
+}
+
+// newSecretAccessBinding converts a policy binding, with normalized members
+// and the expiry of a time-bound condition
+func newSecretAccessBinding(binding Binding, roleDescription string) SecretAccessBinding {
+	entry := SecretAccessBinding{
+		Role:            binding.Role,
+		RoleDescription: roleDescription,
+		Members:         normalizeMembers(binding.Members),
+		Condition:       binding.Condition,
+	}
+	if binding.Condition != nil {
+		if expiry, ok := conditionExpiry(binding.Condition.Expression); ok {
+			entry.Expires = &expiry
+		}
+	}
+	return entry
+}
+
+// sortSecretAccessBindings orders bindings by role
+func sortSecretAccessBindings(bindings []SecretAccessBinding) {
+	sort.SliceStable(bindings, func(i, j int) bool {
+		return bindings[i].Role < bindings[j].Role
+	})
+}
+
+// secretAccessTableColumns returns the header and rows of
+// 'access list --format markdown': one row per member of each binding
+func secretAccessTableColumns(report SecretAccessReport, now time.Time) ([]string, [][]string) {
+	header := []string{"ROLE", "ROLE ID", "MEMBER", "CONDITION", "EXPIRES"}
+	rows := make([][]string, 0)
+	for _, binding := range report.Bindings {
+		description := binding.RoleDescription
+		if description == "" {
+			description = binding.Role
+		}
+		condition, expires := "-", "-"
+		if binding.Condition != nil {
+			condition = binding.Condition.Expression
+			if binding.Condition.Title != "" {
+				condition = binding.Condition.Title + ": " + condition
+			}
+		}
+		if binding.Expires != nil {
+			expires = formatConditionExpiry(*binding.Expires, now)
+		}
+		for _, member := range binding.Members {
+			rows = append(rows, []string{description, binding.Role, formatPrincipal(member), condition, expires})
+		}
+	}
+	return header, rows
+}
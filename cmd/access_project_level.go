@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// projectAccessChange describes one direction of a project-level IAM change
+type projectAccessChange struct {
+	Verb       string // "grant" or "revoke"
+	GcloudVerb string // gcloud projects subcommand
+	Done       string // past tense for the result message, including the preposition
+}
+
+var (
+	projectAccessGrant  = projectAccessChange{Verb: "grant", GcloudVerb: "add-iam-policy-binding", Done: "granted to"}
+	projectAccessRevoke = projectAccessChange{Verb: "revoke", GcloudVerb: "remove-iam-policy-binding", Done: "revoked from"}
+)
+
+// secretOrProjectLevelArgs requires SECRET_NAME for secret-level grant and
+// revoke, and rejects it with --project-level. A secret-level grant also
+// takes --force, to skip the confirmation for public principals.
+func secretOrProjectLevelArgs(cmd *cobra.Command, args []string) error {
+	projectLevel, _ := cmd.Flags().GetBool("project-level")
+	if !projectLevel {
+		if cmd.Flags().Changed("force") && cmd.Name() != "grant" {
+			return fmt.Errorf("--force is only supported with --project-level")
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("--project-level changes the project IAM policy and cannot be combined with SECRET_NAME")
+	}
+	return nil
+}
+
+// validateProjectLevelRole limits project-level changes to the Secret Manager
+// roles, so gsecutil cannot be used to hand out broader roles such as owner
+func validateProjectLevelRole(role string) error {
+	if _, ok := SecretManagerRoles[role]; ok {
+		return nil
+	}
+	roles := make([]string, 0, len(SecretManagerRoles))
+	for name := range SecretManagerRoles {
+		roles = append(roles, name)
+	}
+	sort.Strings(roles)
+	return fmt.Errorf("role '%s' is not a Secret Manager role; project-level changes are limited to: %s", role, strings.Join(roles, ", "))
+}
+
+// changeProjectLevelAccess grants or revokes a Secret Manager role on the
+// project IAM policy. It prints a summary of the change and, unless force is
+// set, requires retyping the project ID because the binding applies to every
+// secret in the project. A grant is bound by condition when it is not nil.
+// With dryRun the gcloud command is printed instead, without confirmation.
+func changeProjectLevelAccess(change projectAccessChange, project, principal, role string, condition *Condition, force, dryRun bool, reader *bufio.Reader) error {
+	if err := validatePrincipalFormat(principal); err != nil {
+		return err
+	}
+	if err := validateProjectLevelRole(role); err != nil {
+		return err
+	}
+	projectID := getProjectID(project)
+	if projectID == "" {
+		return missingProjectIDError()
+	}
+
+	fmt.Printf("Project-level %s:\n", change.Verb)
+	fmt.Printf("  Project: %s\n", projectID)
+	fmt.Printf("  Principal: %s\n", principal)
+	fmt.Printf("  Role: %s (%s)\n", role, SecretManagerRoles[role])
+	if condition != nil {
+		fmt.Printf("  Condition: %s (%s)\n", condition.Title, condition.Expression)
+	}
+	fmt.Printf("This applies to every secret in project '%s', including secrets created later.\n", projectID)
+
+	if dryRun {
+		gcloudArgs := projectBindingArgs(change, projectID, principal, role, condition)
+		fmt.Printf("Dry run: would run\n  %s\n", formatGcloudCommandLine(gcloudArgs))
+		return nil
+	}
+
+	if !force {
+		confirmed, err := confirmProjectID(reader, projectID)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("project ID did not match; project-level %s cancelled", change.Verb)
+		}
+	}
+
+	var err error
+	if change == projectAccessGrant {
+		err = secretManager.AddProjectIamBinding(projectID, principal, role, condition)
+	} else {
+		err = secretManager.RemoveProjectIamBinding(projectID, principal, role)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Project '%s': access %s %s (%s)\n", projectID, change.Done, principal, role)
+	return nil
+}
+
+// projectBindingArgs builds the gcloud arguments that add or remove role on
+// a project. --condition=None targets the unconditional binding without
+// prompting when the project policy contains conditional bindings.
+func projectBindingArgs(change projectAccessChange, projectID, principal, role string, condition *Condition) []string {
+	conditionValue := "None"
+	if condition != nil {
+		conditionValue = conditionFlagValue(condition)
+	}
+	return []string{
+		"projects", change.GcloudVerb, projectID,
+		"--member", principal,
+		"--role", role,
+		"--condition", conditionValue,
+	}
+}
+
+// confirmProjectID asks the user to retype the project ID and reports whether
+// the typed value matches it exactly
+func confirmProjectID(reader *bufio.Reader, projectID string) (bool, error) {
+	fmt.Printf("Type the project ID to confirm: ")
+	response, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read confirmation input: %w", err)
+	}
+	return strings.TrimSpace(response) == projectID, nil
+}
+
+// isPublicPrincipal reports whether a principal is allUsers or
+// allAuthenticatedUsers, which make a resource readable beyond the organization
+func isPublicPrincipal(principal string) bool {
+	switch normalizeMember(principal) {
+	case "allUsers", "allAuthenticatedUsers":
+		return true
+	}
+	return false
+}
+
+// checkBulkPublicGrant refuses a grant to allUsers or allAuthenticatedUsers
+// in a bulk command, where there is no per-grant prompt, unless force is set
+func checkBulkPublicGrant(principal string, force bool) error {
+	if isPublicPrincipal(principal) && !force {
+		return fmt.Errorf("refusing to grant access to %s without --force", normalizeMember(principal))
+	}
+	return nil
+}
+
+// confirmPublicGrant warns that granting to a public principal exposes target
+// and asks for confirmation. Without a terminal nobody can confirm, so the
+// grant is refused; --force skips this check.
+func confirmPublicGrant(principal, target string, interactive bool, reader *bufio.Reader) error {
+	principal = normalizeMember(principal)
+	audience := "anyone on the internet"
+	if principal == "allAuthenticatedUsers" {
+		audience = "anyone with a Google account"
+	}
+	fmt.Fprintf(os.Stderr, "WARNING: granting access to %s makes %s accessible to %s, not just your organization.\n", principal, target, audience)
+	if !interactive {
+		return fmt.Errorf("refusing to grant access to %s without --force when not running in a terminal", principal)
+	}
+
+	fmt.Printf("Grant access to %s anyway? (y/N): ", principal)
+	response, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("failed to read confirmation input: %w", err)
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		return fmt.Errorf("grant to %s cancelled", principal)
+	}
+	return nil
+}
+
+// runAccessChange handles access grant and revoke at the secret or project level
+func runAccessChange(cmd *cobra.Command, args []string, change projectAccessChange) error {
+	project, _ := cmd.Flags().GetString("project")
+	project, err := resolveProject(project)
+	if err != nil {
+		return err
+	}
+	role, _ := cmd.Flags().GetString("role")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+	reader := bufio.NewReader(os.Stdin)
+
+	// Only grant has the --condition-* flags and a repeatable --principal
+	var principals []string
+	var condition *Condition
+	if change == projectAccessGrant {
+		principals, _ = cmd.Flags().GetStringSlice("principal")
+		if len(principals) == 0 {
+			return fmt.Errorf("--principal requires a value")
+		}
+		title, _ := cmd.Flags().GetString("condition-title")
+		expression, _ := cmd.Flags().GetString("condition-expression")
+		description, _ := cmd.Flags().GetString("condition-description")
+		if condition, err = grantConditionFromFlags(title, expression, description); err != nil {
+			return err
+		}
+	} else {
+		principal, _ := cmd.Flags().GetString("principal")
+		principals = []string{principal}
+	}
+
+	projectLevel, _ := cmd.Flags().GetBool("project-level")
+	if change == projectAccessGrant && !force && !dryRun {
+		target := "every secret in the project"
+		if !projectLevel {
+			target = fmt.Sprintf("secret '%s'", AddPrefixToSecretName(args[0]))
+		}
+		interactive := term.IsTerminal(int(os.Stdin.Fd()))
+		for _, principal := range principals {
+			if isPublicPrincipal(principal) {
+				if err := confirmPublicGrant(principal, target, interactive, reader); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if projectLevel {
+		// Each project-level change is confirmed on its own
+		if len(principals) > 1 {
+			return fmt.Errorf("--project-level %ss one principal at a time; repeat the command for each", change.Verb)
+		}
+		return changeProjectLevelAccess(change, project, principals[0], role, condition, force, dryRun, reader)
+	}
+
+	userInputName := args[0]                           // What the user typed
+	secretName := AddPrefixToSecretName(userInputName) // Add prefix if configured
+	if change == projectAccessGrant {
+		return grantSecretAccessToPrincipals(secretName, principals, role, project, condition, dryRun)
+	}
+	if dryRun {
+		return previewAccessChange(fmt.Sprintf("revoke %s on secret '%s' from %s", role, secretName, principals[0]),
+			principals[0], revokeAccessArgs(secretName, principals[0], role, project))
+	}
+	return revokeSecretAccess(secretName, principals[0], role, project)
+}
@@ -0,0 +1,691 @@
+package cmd
+
+import (
+	"bufio"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// TestValidatePrincipalFormat tests accepted and rejected principal formats
+func TestValidatePrincipalFormat(t *testing.T) {
+	tests := []struct {
+		principal   string
+		expectError bool
+	}{
+		{"user:alice@example.com", false},
+		{"serviceAccount:app@p.iam.gserviceaccount.com", false},
+		{"allUsers", false},
+		{"principal://iam.googleapis.com/locations/global/workforcePools/corp/subject/alice@example.com", false},
+		{"principalSet://iam.googleapis.com/locations/global/workforcePools/corp/group/admins", false},
+		{"principal://iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/github/subject/repo:org/app:ref:refs/heads/main", false},
+		{"principalSet://iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/github/attribute.repository/org/app", false},
+		{"principalSet://iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/github/*", false},
+		{"principal://", true},
+		{"principalSet://iam.googleapis.com", true},
+		{"alice@example.com", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.principal, func(t *testing.T) {
+			err := validatePrincipalFormat(tt.principal)
+			if (err != nil) != tt.expectError {
+				t.Errorf("validatePrincipalFormat(%q) error = %v, expectError %v", tt.principal, err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestFormatPrincipal tests display of standard and federated principals
+func TestFormatPrincipal(t *testing.T) {
+	tests := []struct {
+		principal string
+		expected  string
+	}{
+		{"user:alice@example.com", "User: alice@example.com"},
+		{"allUsers", "allUsers"},
+		{
+			"principal://iam.googleapis.com/locations/global/workforcePools/corp/subject/alice@example.com",
+			"Workforce Identity: alice@example.com (pool corp)",
+		},
+		{
+			"principalSet://iam.googleapis.com/locations/global/workforcePools/corp/group/admins",
+			"Workforce Identity Set: group admins (pool corp)",
+		},
+		{
+			"principal://iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/p.svc.id.goog/subject/ns/default/sa/app",
+			"Workload Identity: ns/default/sa/app (pool p.svc.id.goog)",
+		},
+		{
+			"principalSet://iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/github/attribute.repository/org/app",
+			"Workload Identity Set: repository=org/app (pool github)",
+		},
+		{
+			"principalSet://iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/github/*",
+			"Workload Identity Set: all identities (pool github)",
+		},
+		{"principal://goog/subject/alice@example.com", "Principal: goog/subject/alice@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.principal, func(t *testing.T) {
+			if got := formatPrincipal(tt.principal); got != tt.expected {
+				t.Errorf("formatPrincipal(%q) = %q, expected %q", tt.principal, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestNormalizeMember tests trimming and canonical type prefixes
+func TestNormalizeMember(t *testing.T) {
+	tests := []struct {
+		member   string
+		expected string
+	}{
+		{"user:alice@example.com", "user:alice@example.com"},
+		{"User:alice@example.com", "user:alice@example.com"},
+		{"  group: ops@example.com ", "group:ops@example.com"},
+		{"SERVICEACCOUNT:app@p.iam.gserviceaccount.com", "serviceAccount:app@p.iam.gserviceaccount.com"},
+		{"allusers", "allUsers"},
+		{"PrincipalSet://iam.googleapis.com/locations/global/workforcePools/corp/*", "principalSet://iam.googleapis.com/locations/global/workforcePools/corp/*"},
+		{"custom:thing", "custom:thing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.member, func(t *testing.T) {
+			if result := normalizeMember(tt.member); result != tt.expected {
+				t.Errorf("normalizeMember(%q) = %q, expected %q", tt.member, result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestPrincipalRoles tests cross-role grouping of normalized members
+func TestPrincipalRoles(t *testing.T) {
+	policy := IAMPolicy{Bindings: []Binding{
+		{Role: "roles/secretmanager.viewer", Members: []string{"User:alice@example.com", "user:bob@example.com"}},
+		{Role: "roles/secretmanager.secretAccessor", Members: []string{"user:alice@example.com", " user:alice@example.com"}},
+		{Role: "roles/secretmanager.secretAccessor", Members: []string{"user:alice@example.com"},
+			Condition: &Condition{Expression: "true"}},
+	}}
+
+	roles := principalRoles(policy)
+	if len(roles) != 2 {
+		t.Fatalf("Expected 2 principals, got %v", roles)
+	}
+	if got := strings.Join(roles["user:alice@example.com"], ","); got != "roles/secretmanager.secretAccessor,roles/secretmanager.viewer" {
+		t.Errorf("alice roles = %s", got)
+	}
+	if got := strings.Join(roles["user:bob@example.com"], ","); got != "roles/secretmanager.viewer" {
+		t.Errorf("bob roles = %s", got)
+	}
+}
+
+// TestNewSecretAccessSummary tests that bindings and members are sorted
+func TestNewSecretAccessSummary(t *testing.T) {
+	policy := IAMPolicy{Bindings: []Binding{
+		{Role: "roles/secretmanager.viewer", Members: []string{"user:bob@example.com", "group:ops@example.com"}},
+		{Role: "roles/secretmanager.secretAccessor", Members: []string{"user:alice@example.com"},
+			Condition: &Condition{Expression: "request.time < timestamp(\"2030-01-01T00:00:00Z\")"}},
+	}}
+
+	summary := newSecretAccessSummary("db", policy)
+	if len(summary.Bindings) != 2 || summary.Bindings[0].Role != "roles/secretmanager.secretAccessor" {
+		t.Fatalf("Bindings not sorted by role: %+v", summary.Bindings)
+	}
+	if summary.Bindings[0].Condition == "" {
+		t.Error("Expected condition expression to be kept")
+	}
+	if members := summary.Bindings[1].Members; members[0] != "group:ops@example.com" {
+		t.Errorf("Members not sorted: %v", members)
+	}
+	if policy.Bindings[0].Members[0] != "user:bob@example.com" {
+		t.Error("Policy members should not be modified")
+	}
+
+	if empty := newSecretAccessSummary("api", IAMPolicy{}); empty.Bindings == nil {
+		t.Error("Expected empty bindings slice, got nil")
+	}
+}
+
+// TestBuildAccessMatrix tests principal rows and per-secret role cells
+func TestBuildAccessMatrix(t *testing.T) {
+	summaries := []SecretAccessSummary{
+		{Name: "api", Bindings: []AccessBinding{
+			{Role: "roles/secretmanager.secretAccessor", Members: []string{"user:bob@example.com"}},
+			{Role: "roles/secretmanager.viewer", Members: []string{"user:bob@example.com", "user:alice@example.com"}},
+		}},
+		{Name: "db", Bindings: []AccessBinding{
+			{Role: "roles/owner", Members: []string{"user:alice@example.com"}},
+		}},
+		{Name: "broken", Error: "permission denied"},
+	}
+
+	principals, cells := buildAccessMatrix(summaries)
+	if strings.Join(principals, ",") != "user:alice@example.com,user:bob@example.com" {
+		t.Fatalf("Unexpected principals: %v", principals)
+	}
+	if got := strings.Join(cells["user:bob@example.com"]["api"], ","); got != "secretAccessor,viewer" {
+		t.Errorf("bob on api = %s, expected secretAccessor,viewer", got)
+	}
+	if got := strings.Join(cells["user:alice@example.com"]["db"], ","); got != "roles/owner" {
+		t.Errorf("alice on db = %s, expected roles/owner", got)
+	}
+	if len(cells["user:bob@example.com"]["db"]) != 0 {
+		t.Error("bob should have no roles on db")
+	}
+}
+
+// TestFilterBindingsByMinRank tests capability filtering of bindings
+func TestFilterBindingsByMinRank(t *testing.T) {
+	bindings := []Binding{
+		{Role: "roles/secretmanager.viewer", Members: []string{"user:v@example.com"}},
+		{Role: "roles/secretmanager.secretAccessor", Members: []string{"user:a@example.com"}},
+		{Role: "roles/secretmanager.admin", Members: []string{"user:admin@example.com"}},
+		{Role: "roles/owner", Members: []string{"user:owner@example.com"}},
+		{Role: "projects/p/roles/custom", Members: []string{"user:c@example.com"}},
+	}
+
+	tests := []struct {
+		minRole        string
+		expectedRoles  string
+		expectedHidden int
+		expectError    bool
+	}{
+		{minRole: "accessor", expectedRoles: "roles/secretmanager.secretAccessor,roles/secretmanager.admin,roles/owner", expectedHidden: 2},
+		{minRole: "roles/secretmanager.admin", expectedRoles: "roles/secretmanager.admin,roles/owner", expectedHidden: 3},
+		{minRole: "viewer", expectedRoles: "roles/secretmanager.viewer,roles/secretmanager.secretAccessor,roles/secretmanager.admin,roles/owner", expectedHidden: 1},
+		{minRole: "reader", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.minRole, func(t *testing.T) {
+			minRank, err := parseMinRole(tt.minRole)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			kept, hidden := filterBindingsByMinRank(bindings, minRank)
+			var roles []string
+			for _, binding := range kept {
+				roles = append(roles, binding.Role)
+			}
+			if got := strings.Join(roles, ","); got != tt.expectedRoles {
+				t.Errorf("Kept roles = %s, expected %s", got, tt.expectedRoles)
+			}
+			if hidden != tt.expectedHidden {
+				t.Errorf("Hidden = %d, expected %d", hidden, tt.expectedHidden)
+			}
+		})
+	}
+}
+
+// TestValidateProjectLevelRole tests that project-level changes only accept Secret Manager roles
+func TestValidateProjectLevelRole(t *testing.T) {
+	for role := range SecretManagerRoles {
+		if err := validateProjectLevelRole(role); err != nil {
+			t.Errorf("validateProjectLevelRole(%s) failed: %v", role, err)
+		}
+	}
+	for _, role := range []string{"roles/owner", "roles/editor", "roles/secretmanager.unknown", ""} {
+		if err := validateProjectLevelRole(role); err == nil {
+			t.Errorf("Expected validateProjectLevelRole(%q) to fail", role)
+		}
+	}
+}
+
+// TestConfirmProjectID tests the retype-the-project-ID confirmation for project-level changes
+func TestConfirmProjectID(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"my-project\n", true},
+		{"  my-project  \n", true},
+		{"my-project", true},
+		{"y\n", false},
+		{"my-project-2\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		var confirmed bool
+		var err error
+		captureStdout(func() {
+			confirmed, err = confirmProjectID(bufio.NewReader(strings.NewReader(tt.input)), "my-project")
+		})
+		if err != nil {
+			t.Errorf("confirmProjectID(%q) failed: %v", tt.input, err)
+		}
+		if confirmed != tt.expected {
+			t.Errorf("confirmProjectID(%q) = %v, expected %v", tt.input, confirmed, tt.expected)
+		}
+	}
+}
+
+// TestSecretOrProjectLevelArgs tests argument rules for secret- and project-level grant and revoke
+func TestSecretOrProjectLevelArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		flags       []string
+		args        []string
+		expectError bool
+	}{
+		{name: "secret level", args: []string{"db"}},
+		{name: "secret level without name", expectError: true},
+		{name: "project level", flags: []string{"--project-level"}},
+		{name: "project level with name", flags: []string{"--project-level"}, args: []string{"db"}, expectError: true},
+		{name: "force without project level", flags: []string{"--force"}, args: []string{"db"}, expectError: true},
+		{name: "project level with force", flags: []string{"--project-level", "--force"}},
+	}
+	// grant takes --force at the secret level too, for public principals
+	if err := secretOrProjectLevelArgs(accessGrantCmd, []string{"db"}); err != nil {
+		t.Errorf("secretOrProjectLevelArgs(grant) error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("project-level", false, "")
+			cmd.Flags().Bool("force", false, "")
+			if err := cmd.Flags().Parse(tt.flags); err != nil {
+				t.Fatalf("Parse() failed: %v", err)
+			}
+			err := secretOrProjectLevelArgs(cmd, tt.args)
+			if (err != nil) != tt.expectError {
+				t.Errorf("secretOrProjectLevelArgs() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestProjectAccessBindings tests selection of the project-level bindings that grant Secret Manager access
+func TestProjectAccessBindings(t *testing.T) {
+	policy := IAMPolicy{Bindings: []Binding{
+		{Role: "roles/owner", Members: []string{"user:owner@example.com"}},
+		{Role: "roles/compute.admin", Members: []string{"user:ops@example.com"}},
+		{Role: "roles/secretmanager.secretAccessor", Members: []string{"serviceAccount:b@p.iam.gserviceaccount.com", "group:a@example.com"},
+			Condition: &Condition{Title: "prod only", Expression: "resource.name.startsWith('projects/p/secrets/prod-')"}},
+		{Role: "roles/secretmanager.viewer", Members: []string{}},
+	}}
+
+	bindings := projectAccessBindings(policy)
+	if len(bindings) != 2 {
+		t.Fatalf("Expected 2 bindings, got %+v", bindings)
+	}
+	if bindings[0].Role != "roles/owner" || bindings[0].RoleDescription != "Owner (includes full Secret Manager access)" || bindings[0].Scope != projectAccessScope {
+		t.Errorf("Unexpected first binding: %+v", bindings[0])
+	}
+	if bindings[1].Members[0] != "group:a@example.com" || bindings[1].Condition == nil {
+		t.Errorf("Expected sorted members and the condition, got %+v", bindings[1])
+	}
+
+	data, err := encodeProjectAccessReport(ProjectAccessReport{Project: "p", Bindings: bindings}, "csv")
+	if err != nil {
+		t.Fatalf("encodeProjectAccessReport(csv) failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Expected a header and one row per member, got %q", lines)
+	}
+	if lines[0] != "project,role,role_description,scope,member,condition_title,condition_expression,condition_description" {
+		t.Errorf("Unexpected CSV header: %s", lines[0])
+	}
+	if !strings.Contains(lines[2], "group:a@example.com,prod only,") {
+		t.Errorf("Expected the condition on the member row, got %s", lines[2])
+	}
+
+	data, err = encodeProjectAccessReport(ProjectAccessReport{Project: "p", Bindings: projectAccessBindings(IAMPolicy{})}, "json")
+	if err != nil {
+		t.Fatalf("encodeProjectAccessReport(json) failed: %v", err)
+	}
+	if !strings.Contains(string(data), `"bindings": []`) {
+		t.Errorf("Expected an empty bindings array, got %s", data)
+	}
+}
+
+// TestAncestorAccessBindings tests selecting inherited folder and organization bindings
+func TestAncestorAccessBindings(t *testing.T) {
+	policy := IAMPolicy{Bindings: []Binding{
+		{Role: "roles/resourcemanager.folderAdmin", Members: []string{"user:admin@example.com"}},
+		{Role: "roles/secretmanager.secretAccessor", Members: []string{"group:apps@example.com"}},
+		{Role: "roles/secretmanager.viewer", Members: []string{"group:auditors@example.com"}},
+	}}
+	folder := ProjectAncestor{ID: "123", Type: "folder"}
+
+	bindings := ancestorAccessBindings(policy, folder, 0)
+	if len(bindings) != 2 {
+		t.Fatalf("Expected the 2 Secret Manager bindings, got %+v", bindings)
+	}
+	for _, binding := range bindings {
+		if binding.Scope != "folder" {
+			t.Errorf("Expected scope folder, got %+v", binding)
+		}
+	}
+
+	bindings = ancestorAccessBindings(policy, ProjectAncestor{ID: "456", Type: "organization"}, roleRanks["roles/secretmanager.secretAccessor"])
+	if len(bindings) != 1 || bindings[0].Role != "roles/secretmanager.secretAccessor" || bindings[0].Scope != "organization" {
+		t.Errorf("Expected only the accessor binding with --min-role accessor, got %+v", bindings)
+	}
+}
+
+// TestConditionExpiry tests extracting the upper time bound of a condition
+func TestConditionExpiry(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   string
+	}{
+		{"Console time-bound grant", `request.time < timestamp("2025-01-01T00:00:00Z")`, "2025-01-01T00:00:00Z"},
+		{"Less or equal with single quotes", `request.time <= timestamp('2025-03-15T12:30:00+02:00')`, "2025-03-15T10:30:00Z"},
+		{"Combined with a resource condition", `resource.name.startsWith("projects/p/secrets/prod-") && request.time < timestamp("2026-06-01T00:00:00Z")`, "2026-06-01T00:00:00Z"},
+		{"Earliest of several bounds", `request.time < timestamp("2027-01-01T00:00:00Z") || request.time < timestamp("2026-01-01T00:00:00Z")`, "2026-01-01T00:00:00Z"},
+		{"Start time only", `request.time > timestamp("2025-01-01T00:00:00Z")`, ""},
+		{"No time bound", `resource.name.endsWith("-dev")`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expiry, ok := conditionExpiry(tt.expression)
+			if tt.expected == "" {
+				if ok {
+					t.Errorf("Expected no expiry, got %v", expiry)
+				}
+				return
+			}
+			if !ok || expiry.UTC().Format(time.RFC3339) != tt.expected {
+				t.Errorf("conditionExpiry() = %v, %v, expected %s", expiry, ok, tt.expected)
+			}
+		})
+	}
+
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	if got := formatConditionExpiry(now.AddDate(0, 1, 0), now); got != "expires 2025-07-01 00:00 UTC" {
+		t.Errorf("formatConditionExpiry() = %q", got)
+	}
+	if got := formatConditionExpiry(now.AddDate(0, -1, 0), now); got != "expired 2025-05-01 00:00 UTC" {
+		t.Errorf("formatConditionExpiry() = %q", got)
+	}
+}
+
+// TestNewSecretAccessReport tests the structured access list output
+func TestNewSecretAccessReport(t *testing.T) {
+	condition := &Condition{
+		Title:       "temporary",
+		Description: "Incident 42",
+		Expression:  `request.time < timestamp("2025-01-01T00:00:00Z")`,
+	}
+	policy := IAMPolicy{Bindings: []Binding{
+		{Role: "roles/secretmanager.viewer", Members: []string{" User:bob@example.com"}},
+		{Role: "roles/secretmanager.secretAccessor", Members: []string{"user:alice@example.com"}, Condition: condition},
+	}}
+
+	report := newSecretAccessReport("db-password", policy)
+	if report.Secret != "db-password" || len(report.Bindings) != 2 {
+		t.Fatalf("newSecretAccessReport() = %+v", report)
+	}
+	accessor, viewer := report.Bindings[0], report.Bindings[1]
+	if accessor.Role != "roles/secretmanager.secretAccessor" || accessor.Condition != condition {
+		t.Errorf("Expected the accessor binding first with its full condition, got %+v", accessor)
+	}
+	if accessor.Expires == nil || !accessor.Expires.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected expiry 2025-01-01, got %v", accessor.Expires)
+	}
+	if viewer.Condition != nil || viewer.Expires != nil || viewer.Members[0] != "user:bob@example.com" {
+		t.Errorf("Unexpected viewer binding: %+v", viewer)
+	}
+}
+
+// TestSecretAccessTableColumns tests the rows of 'access list --format markdown'
+func TestSecretAccessTableColumns(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	report := newSecretAccessReport("db", IAMPolicy{Bindings: []Binding{
+		{Role: "roles/secretmanager.secretAccessor", Members: []string{"user:alice@example.com", "group:ops@example.com"}},
+		{Role: "roles/custom.reader", Members: []string{"user:bob@example.com"},
+			Condition: &Condition{Title: "temp", Expression: `request.time < timestamp("2025-07-01T00:00:00Z")`}},
+	}})
+
+	header, rows := secretAccessTableColumns(report, now)
+	if len(header) != 5 || len(rows) != 3 {
+		t.Fatalf("Expected 5 columns and one row per member, got %v %v", header, rows)
+	}
+	if rows[0][1] != "roles/custom.reader" || rows[0][0] != "roles/custom.reader" {
+		t.Errorf("Expected the role ID as the description for unknown roles, got %v", rows[0])
+	}
+	if rows[0][3] != `temp: request.time < timestamp("2025-07-01T00:00:00Z")` || rows[0][4] != "expires 2025-07-01 00:00 UTC" {
+		t.Errorf("Unexpected condition columns: %v", rows[0])
+	}
+	if rows[1][3] != "-" || rows[1][4] != "-" {
+		t.Errorf("Expected - for unconditional bindings, got %v", rows[1])
+	}
+}
+
+// TestGrantConditionFromFlags tests building an IAM condition from the grant
+// flags and formatting it for gcloud's --condition
+func TestGrantConditionFromFlags(t *testing.T) {
+	tests := []struct {
+		name        string
+		title       string
+		expression  string
+		description string
+		expected    string
+		expectError bool
+	}{
+		{name: "No condition"},
+		{
+			name:       "Title and expression",
+			title:      "Until 2026",
+			expression: `request.time < timestamp("2026-01-01T00:00:00Z")`,
+			expected:   `title=Until 2026,expression=request.time < timestamp("2026-01-01T00:00:00Z")`,
+		},
+		{
+			name:        "With description",
+			title:       "temp",
+			expression:  "request.time < timestamp('2026-01-01T00:00:00Z')",
+			description: "Contractor access",
+			expected:    "title=temp,expression=request.time < timestamp('2026-01-01T00:00:00Z'),description=Contractor access",
+		},
+		{
+			name:       "Comma in expression switches the separator",
+			title:      "prefix",
+			expression: `resource.name in ["projects/1/secrets/a", "projects/1/secrets/b"]`,
+			expected:   `^;^title=prefix;expression=resource.name in ["projects/1/secrets/a", "projects/1/secrets/b"]`,
+		},
+		{
+			name:       "Comma and semicolon",
+			title:      "a, b; c",
+			expression: "true",
+			expected:   "^|^title=a, b; c|expression=true",
+		},
+		{name: "Title without expression", title: "temp", expectError: true},
+		{name: "Expression without title", expression: "true", expectError: true},
+		{name: "Description only", description: "why", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := grantConditionFromFlags(tt.title, tt.expression, tt.description)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.expected == "" {
+				if condition != nil {
+					t.Errorf("Expected no condition, got %+v", condition)
+				}
+				return
+			}
+			if result := conditionFlagValue(condition); result != tt.expected {
+				t.Errorf("conditionFlagValue() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseAccessApplyRecord tests validation of 'access apply' CSV rows
+func TestParseAccessApplyRecord(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Prefix: "team-"}
+
+	columns, err := accessApplyColumnIndexes([]string{"Secret", "principal", "role", "Action"})
+	if err != nil {
+		t.Fatalf("accessApplyColumnIndexes() failed: %v", err)
+	}
+	tests := []struct {
+		name        string
+		record      []string
+		expected    accessApplyChange
+		expectError bool
+	}{
+		{
+			name:     "Grant with role",
+			record:   []string{"db", "user:alice@example.com", "roles/secretmanager.viewer", "grant"},
+			expected: accessApplyChange{SecretName: "team-db", Principal: "user:alice@example.com", Role: "roles/secretmanager.viewer", Action: "grant"},
+		},
+		{
+			name:     "Default role and uppercase action",
+			record:   []string{"team-api", "group:devs@example.com", "", "REVOKE"},
+			expected: accessApplyChange{SecretName: "team-api", Principal: "group:devs@example.com", Role: defaultAccessRole, Action: "revoke"},
+		},
+		{name: "Empty secret", record: []string{"", "user:alice@example.com", "", "grant"}, expectError: true},
+		{name: "Invalid principal", record: []string{"db", "alice@example.com", "", "grant"}, expectError: true},
+		{name: "Invalid action", record: []string{"db", "user:alice@example.com", "", "add"}, expectError: true},
+		{name: "Role without prefix", record: []string{"db", "user:alice@example.com", "secretmanager.viewer", "grant"}, expectError: true},
+		{name: "Malformed custom role", record: []string{"db", "user:alice@example.com", "projects/p/secretReader", "grant"}, expectError: true},
+		{
+			name:     "Custom role",
+			record:   []string{"db", "user:alice@example.com", "projects/my-project/roles/secretReader", "grant"},
+			expected: accessApplyChange{SecretName: "team-db", Principal: "user:alice@example.com", Role: "projects/my-project/roles/secretReader", Action: "grant"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseAccessApplyRecord(columns, tt.record)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("parseAccessApplyRecord() = %+v, expected %+v", result, tt.expected)
+			}
+		})
+	}
+
+	for _, header := range [][]string{{"secret", "principal"}, {"secret", "principal", "action", "note"}, {"secret", "secret", "principal", "action"}} {
+		if _, err := accessApplyColumnIndexes(header); err == nil {
+			t.Errorf("Expected an error for header %v", header)
+		}
+	}
+}
+
+// TestPrincipalAccessGrants tests finding one principal's grants in secret
+// and project policies
+func TestPrincipalAccessGrants(t *testing.T) {
+	summaries := []SecretAccessSummary{
+		{Name: "db", Bindings: []AccessBinding{
+			{Role: "roles/secretmanager.viewer", Members: []string{"user:Alice@example.com"}},
+			{Role: "roles/secretmanager.secretAccessor", Members: []string{"group:devs@example.com", "user:alice@example.com"}, Condition: "request.time < timestamp('2026-01-01T00:00:00Z')"},
+		}},
+		{Name: "api", Bindings: []AccessBinding{{Role: "roles/secretmanager.secretAccessor", Members: []string{"user:alice@example.com"}}}},
+		{Name: "cache", Bindings: []AccessBinding{{Role: "roles/secretmanager.secretAccessor", Members: []string{"user:bob@example.com"}}}},
+	}
+	projectBindings := []ProjectAccessBinding{
+		{Role: "roles/secretmanager.admin", Members: []string{"user:bob@example.com"}},
+		{Role: "roles/secretmanager.viewer", Members: []string{"user:alice@example.com"}, Condition: &Condition{Expression: "true"}},
+	}
+
+	expected := []PrincipalAccessGrant{
+		{Secret: accessAllSecrets, Role: "roles/secretmanager.viewer", Scope: projectAccessScope, Condition: "true"},
+		{Secret: "api", Role: "roles/secretmanager.secretAccessor", Scope: secretAccessScope},
+		{Secret: "db", Role: "roles/secretmanager.secretAccessor", Scope: secretAccessScope, Condition: "request.time < timestamp('2026-01-01T00:00:00Z')"},
+		{Secret: "db", Role: "roles/secretmanager.viewer", Scope: secretAccessScope},
+	}
+	result := principalAccessGrants("User:alice@example.com", summaries, projectBindings)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("principalAccessGrants() = %+v, expected %+v", result, expected)
+	}
+
+	if result := principalAccessGrants("user:carol@example.com", summaries, projectBindings); len(result) != 0 {
+		t.Errorf("Expected no grants, got %+v", result)
+	}
+}
+
+// TestPolicyAccessGrants tests comparing two policies per role and member
+func TestPolicyAccessGrants(t *testing.T) {
+	policyA := IAMPolicy{Bindings: []Binding{
+		{Role: "roles/secretmanager.secretAccessor", Members: []string{"user:alice@example.com", "User:bob@example.com "}},
+		{Role: "roles/secretmanager.viewer", Members: []string{"group:ops@example.com"}},
+		{Role: "roles/secretmanager.secretAccessor", Members: []string{"user:carol@example.com"}, Condition: &Condition{Expression: "true"}},
+	}}
+	policyB := IAMPolicy{Bindings: []Binding{
+		{Role: "roles/secretmanager.secretAccessor", Members: []string{"user:bob@example.com", "user:alice@example.com", "user:carol@example.com"}},
+		{Role: "roles/secretmanager.viewer", Members: []string{"group:ops@example.com"}},
+	}}
+
+	grantsA, grantsB := policyAccessGrants(policyA), policyAccessGrants(policyB)
+	onlyA := accessGrantsMissingFrom(grantsA, grantsB)
+	onlyB := accessGrantsMissingFrom(grantsB, grantsA)
+	expectedA := []accessGrant{{Role: "roles/secretmanager.secretAccessor", Member: "user:carol@example.com", Condition: "true"}}
+	expectedB := []accessGrant{{Role: "roles/secretmanager.secretAccessor", Member: "user:carol@example.com"}}
+	if !reflect.DeepEqual(onlyA, expectedA) || !reflect.DeepEqual(onlyB, expectedB) {
+		t.Errorf("Expected only the conditional grant to differ, got %+v and %+v", onlyA, onlyB)
+	}
+
+	if missing := accessGrantsMissingFrom(grantsA, grantsA); len(missing) != 0 {
+		t.Errorf("Expected identical policies to match, got %+v", missing)
+	}
+}
+
+// TestConfirmPublicGrant tests the confirmation required to grant access to
+// allUsers or allAuthenticatedUsers
+func TestConfirmPublicGrant(t *testing.T) {
+	for _, principal := range []string{"allUsers", "allauthenticatedusers"} {
+		if !isPublicPrincipal(principal) {
+			t.Errorf("isPublicPrincipal(%q) = false, expected true", principal)
+		}
+	}
+	if isPublicPrincipal("user:alice@example.com") {
+		t.Error("isPublicPrincipal() = true for a user")
+	}
+
+	tests := []struct {
+		name        string
+		interactive bool
+		input       string
+		expectError bool
+	}{
+		{name: "confirmed", interactive: true, input: "yes\n"},
+		{name: "declined", interactive: true, input: "n\n", expectError: true},
+		{name: "no answer", interactive: true, input: "", expectError: true},
+		{name: "not a terminal", interactive: false, input: "yes\n", expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := confirmPublicGrant("allUsers", "secret 'db'", tt.interactive, bufio.NewReader(strings.NewReader(tt.input)))
+			if (err != nil) != tt.expectError {
+				t.Errorf("confirmPublicGrant() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// SecretAccessSummary is the secret-level access of one secret in 'access tree'
+type SecretAccessSummary struct {
+	Name     string          `json:"name" yaml:"name"`
+	Bindings []AccessBinding `json:"bindings" yaml:"bindings"`
+	Error    string          `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// AccessBinding is a role and the members granted it on a secret
+type AccessBinding struct {
+	Role      string   `json:"role" yaml:"role"`
+	Members   []string `json:"members" yaml:"members"`
+	Condition string   `json:"condition,omitempty" yaml:"condition,omitempty"`
+}
+
+var accessTreeCmd = &cobra.Command{
+	Use:   "tree",
+	Short: "Show who can access every secret in the project",
+	Long: `Show the secret-level IAM bindings of every secret in the project (within
+the configured prefix), for a project-wide review of who can access what.
+
+By default the result is printed as a tree of secret, role, and members.
+With --matrix it is printed as a table with one row per principal and one
+column per secret, each cell listing the roles the principal holds on that
+secret. Policies are fetched concurrently.
+
+Only secret-level bindings are shown; use 'access project' for project-level
+permissions, which apply to every secret.
+
+Examples:
+  gsecutil access tree
+  gsecutil access tree --matrix
+  gsecutil access tree --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, _ := cmd.Flags().GetString("project")
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
+		format, _ := cmd.Flags().GetString("format")
+		matrix, _ := cmd.Flags().GetBool("matrix")
+
+		if format != "" && format != "json" && format != "yaml" {
+			return fmt.Errorf("unsupported format '%s' (use json or yaml)", format)
+		}
+		return showAccessTree(project, format, matrix)
+	},
+}
+
+func init() {
+	accessCmd.AddCommand(accessTreeCmd)
+	accessTreeCmd.Flags().String("format", "", "Output format: json or yaml (default: tree)")
+	accessTreeCmd.Flags().Bool("matrix", false, "Print a principals x secrets table instead of a tree")
+}
+
+// showAccessTree fetches the IAM policy of every secret and prints the access map
+func showAccessTree(project, format string, matrix bool) error {
+	secrets, err := secretManager.List(project, "", 0)
+	if err != nil {
+		return err
+	}
+
+	var filtered []SecretInfo
+	for _, secret := range secrets {
+		if FilterSecretsByPrefix(extractSecretName(secret.Name)) {
+			filtered = append(filtered, secret)
+		}
+	}
+	sortSecrets(filtered)
+
+	summaries := fetchSecretAccessSummaries(filtered, project)
+	for _, summary := range summaries {
+		if summary.Error != "" {
+			fmt.Fprintf(os.Stderr, "Warning: Could not get IAM policy for secret '%s': %s\n", summary.Name, summary.Error)
+		}
+	}
+
+	if format == "json" || format == "yaml" {
+		return printStructuredOutput(summaries, format)
+	}
+
+	if len(summaries) == 0 {
+		fmt.Println("No secrets found.")
+		return nil
+	}
+
+	if matrix {
+		displayAccessMatrix(summaries)
+	} else {
+		displayAccessTree(summaries)
+	}
+	return nil
+}
+
+// fetchSecretAccessSummaries fetches the IAM policy of each secret concurrently.
+// Failures are recorded in the summary's Error instead of aborting the review.
+func fetchSecretAccessSummaries(secrets []SecretInfo, project string) []SecretAccessSummary {
+	const maxConcurrency = 10
+	prefix := GetPrefix()
+
+	summaries := make([]SecretAccessSummary, len(secrets))
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i := range secrets {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			secretName := extractSecretName(secrets[idx].Name)
+			bareName := strings.TrimPrefix(secretName, prefix)
+
+			policy, err := secretManager.GetIamPolicy(secretName, project)
+			if err != nil {
+				summaries[idx] = SecretAccessSummary{Name: bareName, Bindings: []AccessBinding{}, Error: err.Error()}
+				return
+			}
+			summaries[idx] = newSecretAccessSummary(bareName, *policy)
+		}(i)
+	}
+	wg.Wait()
+	return summaries
+}
+
+// newSecretAccessSummary converts a policy into bindings sorted by role, with
+// normalized and sorted members
+func newSecretAccessSummary(name string, policy IAMPolicy) SecretAccessSummary {
+	summary := SecretAccessSummary{Name: name, Bindings: []AccessBinding{}}
+	for _, binding := range policy.Bindings {
+		accessBinding := AccessBinding{Role: binding.Role, Members: normalizeMembers(binding.Members)}
+		if binding.Condition != nil {
+			accessBinding.Condition = binding.Condition.Expression
+		}
+		summary.Bindings = append(summary.Bindings, accessBinding)
+	}
+	sort.SliceStable(summary.Bindings, func(i, j int) bool {
+		return summary.Bindings[i].Role < summary.Bindings[j].Role
+	})
+	return summary
+}
+
+// displayAccessTree prints each secret with its roles and members indented below it
+func displayAccessTree(summaries []SecretAccessSummary) {
+	for i, summary := range summaries {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Println(summary.Name)
+
+		if summary.Error != "" {
+			fmt.Println("  (could not read IAM policy)")
+			continue
+		}
+		if len(summary.Bindings) == 0 {
+			fmt.Println("  (no secret-level bindings)")
+			continue
+		}
+		for _, binding := range summary.Bindings {
+			role := binding.Role
+			if binding.Condition != "" {
+				role += fmt.Sprintf(" (condition: %s)", binding.Condition)
+			}
+			fmt.Printf("  %s\n", role)
+			for _, member := range binding.Members {
+				fmt.Printf("    - %s\n", formatPrincipal(member))
+			}
+		}
+	}
+}
+
+// buildAccessMatrix returns the sorted principals and, for each principal, the
+// roles held per secret name
+func buildAccessMatrix(summaries []SecretAccessSummary) ([]string, map[string]map[string][]string) {
+	cells := make(map[string]map[string][]string)
+	for _, summary := range summaries {
+		for _, binding := range summary.Bindings {
+			for _, member := range binding.Members {
+				if cells[member] == nil {
+					cells[member] = make(map[string][]string)
+				}
+				cells[member][summary.Name] = append(cells[member][summary.Name], shortRoleName(binding.Role))
+			}
+		}
+	}
+
+	principals := make([]string, 0, len(cells))
+	for principal := range cells {
+		principals = append(principals, principal)
+	}
+	sort.Strings(principals)
+	return principals, cells
+}
+
+// shortRoleName drops the roles/secretmanager. prefix for compact role lists
+func shortRoleName(role string) string {
+	return strings.TrimPrefix(role, "roles/secretmanager.")
+}
+
+// displayAccessMatrix prints a principals x secrets table of roles
+func displayAccessMatrix(summaries []SecretAccessSummary) {
+	principals, cells := buildAccessMatrix(summaries)
+	if len(principals) == 0 {
+		fmt.Println("No secret-level bindings found.")
+		return
+	}
+
+	principalWidth := 9 // "PRINCIPAL"
+	for _, principal := range principals {
+		if w := displayWidth(principal); w > principalWidth {
+			principalWidth = w
+		}
+	}
+	columnWidths := make([]int, len(summaries))
+	for i, summary := range summaries {
+		columnWidths[i] = displayWidth(summary.Name)
+		for _, principal := range principals {
+			if w := displayWidth(strings.Join(cells[principal][summary.Name], ",")); w > columnWidths[i] {
+				columnWidths[i] = w
+			}
+		}
+	}
+
+	header := padRight("PRINCIPAL", principalWidth)
+	sep := strings.Repeat("-", principalWidth)
+	for i, summary := range summaries {
+		header += "  " + padRight(summary.Name, columnWidths[i])
+		sep += "  " + strings.Repeat("-", columnWidths[i])
+	}
+	fmt.Println(strings.TrimRight(header, " "))
+	fmt.Println(sep)
+
+	for _, principal := range principals {
+		row := padRight(principal, principalWidth)
+		for i, summary := range summaries {
+			cell := strings.Join(cells[principal][summary.Name], ",")
+			if cell == "" {
+				cell = "-"
+			}
+			row += "  " + padRight(cell, columnWidths[i])
+		}
+		fmt.Println(strings.TrimRight(row, " "))
+	}
+}
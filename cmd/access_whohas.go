@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// accessAllSecrets is the secret name reported for project-level grants,
+// which apply to every secret in the project
+const accessAllSecrets = "(all secrets)"
+
+// secretAccessScope is the scope reported for secret-level bindings
+const secretAccessScope = "secret"
+
+// PrincipalAccessGrant is one grant held by the principal in 'access whohas'
+type PrincipalAccessGrant struct {
+	Secret    string `json:"secret" yaml:"secret"`
+	Role      string `json:"role" yaml:"role"`
+	Scope     string `json:"scope" yaml:"scope"`
+	Condition string `json:"condition,omitempty" yaml:"condition,omitempty"`
+}
+
+var accessWhohasCmd = &cobra.Command{
+	Use:   "whohas --principal <principal>",
+	Short: "Show every secret a principal can access",
+	Long: `Show the secrets a single principal can access, for offboarding and access
+reviews. The IAM policy of every secret in the project (within the configured
+prefix) is checked for secret-level bindings, and the project IAM policy for
+Secret Manager roles, which apply to every secret and are listed once as
+"(all secrets)" with the project scope.
+
+Only direct grants to the principal are found: membership in a group that
+holds a role is not expanded. Policies are fetched concurrently. If a policy
+cannot be read, a warning is printed and the command exits non-zero, since
+the result may be incomplete.
+
+Examples:
+  gsecutil access whohas --principal user:alice@example.com
+  gsecutil access whohas --principal serviceAccount:app@my-project.iam.gserviceaccount.com --format json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, _ := cmd.Flags().GetString("project")
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
+		principal, _ := cmd.Flags().GetString("principal")
+		format, _ := cmd.Flags().GetString("format")
+
+		if format != "" && format != "json" && format != "yaml" {
+			return fmt.Errorf("unsupported format '%s' (use json or yaml)", format)
+		}
+		if err := validatePrincipalFormat(principal); err != nil {
+			return err
+		}
+		return showPrincipalAccess(project, principal, format)
+	},
+}
+
+func init() {
+	accessCmd.AddCommand(accessWhohasCmd)
+	accessWhohasCmd.Flags().String("principal", "", "Principal to look up (required) - format: user:email@domain.com, group:group@domain.com, etc.")
+	accessWhohasCmd.Flags().String("format", "", "Output format: json or yaml (default: table)")
+	if err := accessWhohasCmd.MarkFlagRequired("principal"); err != nil {
+		panic(fmt.Sprintf("Failed to mark principal flag as required for whohas command: %v", err))
+	}
+}
+
+// showPrincipalAccess collects the secret-level and project-level grants of
+// principal and prints them
+func showPrincipalAccess(project, principal, format string) error {
+	secrets, err := secretManager.List(project, "", 0)
+	if err != nil {
+		return err
+	}
+	var filtered []SecretInfo
+	for _, secret := range secrets {
+		if FilterSecretsByPrefix(extractSecretName(secret.Name)) {
+			filtered = append(filtered, secret)
+		}
+	}
+	sortSecrets(filtered)
+
+	unchecked := 0
+	summaries := fetchSecretAccessSummaries(filtered, project)
+	for _, summary := range summaries {
+		if summary.Error != "" {
+			fmt.Fprintf(os.Stderr, "Warning: Could not get IAM policy for secret '%s': %s\n", summary.Name, summary.Error)
+			unchecked++
+		}
+	}
+
+	var projectBindings []ProjectAccessBinding
+	projectID := getProjectID(project)
+	if policy, err := secretManager.GetProjectIamPolicy(projectID); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Could not get the IAM policy of project '%s': %v\n", projectID, err)
+		unchecked++
+	} else {
+		projectBindings = projectAccessBindings(*policy)
+	}
+
+	grants := principalAccessGrants(principal, summaries, projectBindings)
+	if format == "json" || format == "yaml" {
+		if err := printStructuredOutput(grants, format); err != nil {
+			return err
+		}
+	} else if len(grants) == 0 {
+		fmt.Printf("%s has no direct access to secrets in project '%s'.\n", formatPrincipal(normalizeMember(principal)), projectID)
+	} else {
+		rows := make([][]string, 0, len(grants))
+		for _, grant := range grants {
+			rows = append(rows, []string{grant.Secret, grant.Role, grant.Scope, grant.Condition})
+		}
+		fmt.Printf("Access held by %s (Project: %s)\n\n", formatPrincipal(normalizeMember(principal)), projectID)
+		printTextTable([]string{"SECRET", "ROLE", "SCOPE", "CONDITION"}, rows)
+	}
+
+	if unchecked > 0 {
+		return fmt.Errorf("could not read %d IAM policies; the result may be incomplete", unchecked)
+	}
+	return nil
+}
+
+// principalAccessGrants returns the grants of principal in the secret
+// summaries and project bindings: project-wide grants first, then secret-level
+// grants by secret and role. Members are compared normalized and
+// case-insensitively.
+func principalAccessGrants(principal string, summaries []SecretAccessSummary, projectBindings []ProjectAccessBinding) []PrincipalAccessGrant {
+	principal = normalizeMember(principal)
+	isPrincipal := func(members []string) bool {
+		for _, member := range members {
+			if strings.EqualFold(normalizeMember(member), principal) {
+				return true
+			}
+		}
+		return false
+	}
+
+	grants := make([]PrincipalAccessGrant, 0)
+	for _, binding := range projectBindings {
+		if !isPrincipal(binding.Members) {
+			continue
+		}
+		grant := PrincipalAccessGrant{Secret: accessAllSecrets, Role: binding.Role, Scope: projectAccessScope}
+		if binding.Condition != nil {
+			grant.Condition = binding.Condition.Expression
+		}
+		grants = append(grants, grant)
+	}
+
+	var secretGrants []PrincipalAccessGrant
+	for _, summary := range summaries {
+		for _, binding := range summary.Bindings {
+			if isPrincipal(binding.Members) {
+				secretGrants = append(secretGrants, PrincipalAccessGrant{Secret: summary.Name, Role: binding.Role, Scope: secretAccessScope, Condition: binding.Condition})
+			}
+		}
+	}
+	sort.SliceStable(secretGrants, func(i, j int) bool {
+		if secretGrants[i].Secret != secretGrants[j].Secret {
+			return secretGrants[i].Secret < secretGrants[j].Secret
+		}
+		return secretGrants[i].Role < secretGrants[j].Role
+	})
+	return append(grants, secretGrants...)
+}
@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// Actions supported by apply
+const (
+	applyActionCreate = "create"
+	applyActionUpdate = "update"
+	applyActionGrant  = "grant"
+	applyActionRevoke = "revoke"
+)
+
+// ApplyOperation is a single operation read by 'apply --stdin-json'
+type ApplyOperation struct {
+	Action    string            `json:"action"`
+	Name      string            `json:"name"`
+	Value     *string           `json:"value,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Principal string            `json:"principal,omitempty"`
+	Role      string            `json:"role,omitempty"`
+}
+
+// applyStep is a validated operation with its resolved secret name
+type applyStep struct {
+	Operation  ApplyOperation
+	SecretName string
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply --stdin-json",
+	Short: "Apply a batch of operations read from a JSON stream",
+	Long: `Apply a batch of create, update, grant, and revoke operations read as a
+JSON array from stdin. This is a programmatic interface for other tools;
+use 'import' for spreadsheet-style CSV files.
+
+Each operation is an object with these fields:
+  action     create, update, grant, or revoke (required)
+  name       secret name (required; the configured prefix is added if missing)
+  value      secret value (required for create and update)
+  labels     labels for create, as an object of key/value pairs
+  principal  member for grant and revoke (required), e.g. user:alice@example.com
+  role       role for grant and revoke (default: roles/secretmanager.secretAccessor)
+
+Every operation is validated before anything runs: unknown fields, missing
+values, invalid principals, creating a secret that already exists, and updating
+one that does not exist are all reported together, and nothing is applied.
+Grants to allUsers or allAuthenticatedUsers, which make a secret public, are
+reported the same way unless --force is given.
+Operations then run in order and stop at the first failure; the summary shows
+which operations were applied and which did not run.
+
+Config default labels are merged into create labels, like the create command.
+Use --dry-run to validate and print the plan without changing anything.
+With --dry-run --format json (or yaml) the plan is printed as a document listing
+each operation and its change, for review or an approval step before the real
+run. The IAM policies of the secrets targeted by grant and revoke are fetched
+first, so grants already in place and revokes of missing bindings are marked
+as no-ops.`,
+	Example: `  echo '[{"action": "create", "name": "db-password", "value": "s3cret"}]' | gsecutil apply --stdin-json
+  gsecutil apply --stdin-json --dry-run < operations.json
+  gsecutil apply --stdin-json --dry-run --format json < operations.json > plan.json
+  generate-ops | gsecutil apply --stdin-json --project my-project`,
+	Args: cobra.NoArgs,
+	RunE: runApply,
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+	applyCmd.Flags().Bool("stdin-json", false, "Read a JSON array of operations from stdin")
+	applyCmd.Flags().Bool("dry-run", false, "Validate operations and show what would be done without making changes")
+	applyCmd.Flags().Bool("allow-empty-value", false, "Allow create and update operations with an empty value")
+	applyCmd.Flags().String("format", "", "Output format for --dry-run: text (default), json, or yaml")
+	applyCmd.Flags().BoolP("force", "f", false, "Allow grants to allUsers and allAuthenticatedUsers")
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	project, _ := cmd.Flags().GetString("project")
+	project, err := resolveProject(project)
+	if err != nil {
+		return err
+	}
+	stdinJSON, _ := cmd.Flags().GetBool("stdin-json")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	allowEmptyValue, _ := cmd.Flags().GetBool("allow-empty-value")
+	format, _ := cmd.Flags().GetString("format")
+	force, _ := cmd.Flags().GetBool("force")
+
+	if !stdinJSON {
+		return fmt.Errorf("no input given: use --stdin-json to read operations from stdin")
+	}
+	if format != "" && !dryRun {
+		return fmt.Errorf("--format is only supported with --dry-run")
+	}
+	if format == "text" {
+		format = ""
+	}
+	if format != "" && format != "json" && format != "yaml" {
+		return fmt.Errorf("unsupported format '%s' (use text, json, or yaml)", format)
+	}
+
+	operations, err := parseApplyOperations(os.Stdin)
+	if err != nil {
+		return err
+	}
+	if len(operations) == 0 {
+		if format != "" {
+			return printStructuredOutput(ApplyPlan{Project: project, Operations: []ApplyPlanStep{}}, format)
+		}
+		fmt.Println("No operations to apply")
+		return nil
+	}
+
+	existingSecrets, err := secretManager.ListNames(project, GetPrefix())
+	if err != nil {
+		return fmt.Errorf("failed to get existing secrets: %w", err)
+	}
+
+	steps, problems := validateApplyOperations(operations, existingSecrets, allowEmptyValue, force)
+	if len(problems) > 0 {
+		fmt.Fprintln(os.Stderr, "Invalid operations:")
+		for _, problem := range problems {
+			fmt.Fprintf(os.Stderr, "  - %s\n", problem)
+		}
+		return fmt.Errorf("%d invalid operation(s); nothing was applied", len(problems))
+	}
+
+	if dryRun && format != "" {
+		plan, err := buildApplyPlan(steps, project, func(secretName string) (*IAMPolicy, error) {
+			return secretManager.GetIamPolicy(secretName, project)
+		})
+		if err != nil {
+			return err
+		}
+		return printStructuredOutput(plan, format)
+	}
+
+	if dryRun {
+		for i, step := range steps {
+			fmt.Printf("[DRY-RUN] %d: %s\n", i+1, describeApplyStep(step))
+		}
+		fmt.Println()
+		fmt.Println("Apply Summary:")
+		fmt.Printf("  Would apply: %d\n", len(steps))
+		return nil
+	}
+
+	applied := 0
+	var failure error
+	for i, step := range steps {
+		if err := executeApplyStep(step, project); err != nil {
+			fmt.Printf("Error in operation %d (%s): %v\n", i+1, describeApplyStep(step), err)
+			failure = err
+			break
+		}
+		applied++
+	}
+
+	fmt.Println()
+	fmt.Println("Apply Summary:")
+	fmt.Printf("  Applied: %d\n", applied)
+	if failure != nil {
+		fmt.Printf("  Failed: 1\n")
+		fmt.Printf("  Not run: %d\n", len(steps)-applied-1)
+		return fmt.Errorf("apply stopped at operation %d of %d", applied+1, len(steps))
+	}
+	return nil
+}
+
+// parseApplyOperations decodes a JSON array of operations, rejecting unknown fields
+func parseApplyOperations(r io.Reader) ([]ApplyOperation, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var operations []ApplyOperation
+	if err := decoder.Decode(&operations); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON operations: %w", err)
+	}
+	return operations, nil
+}
+
+// validateApplyOperations checks every operation before any is executed. existing
+// holds the secrets present before the batch; secrets created earlier in the batch
+// count as existing for later operations. Grants to public principals are
+// problems unless allowPublic is set.
+func validateApplyOperations(operations []ApplyOperation, existing map[string]bool, allowEmptyValue, allowPublic bool) ([]applyStep, []string) {
+	exists := make(map[string]bool, len(existing))
+	for name := range existing {
+		exists[name] = true
+	}
+
+	var steps []applyStep
+	var problems []string
+	for i, op := range operations {
+		problem := func(format string, a ...interface{}) {
+			problems = append(problems, fmt.Sprintf("operation %d: %s", i+1, fmt.Sprintf(format, a...)))
+		}
+
+		if op.Name == "" {
+			problem("name is required")
+			continue
+		}
+		secretName := AddPrefixToSecretName(op.Name)
+		if err := validateSecretName(secretName); err != nil {
+			problem("%v", err)
+			continue
+		}
+
+		switch op.Action {
+		case applyActionCreate, applyActionUpdate:
+			if op.Principal != "" || op.Role != "" {
+				problem("principal and role are only valid for grant and revoke")
+				continue
+			}
+			if op.Value == nil {
+				problem("value is required for %s", op.Action)
+				continue
+			}
+			if err := checkEmptySecretValue(*op.Value, allowEmptyValue); err != nil {
+				problem("%s '%s': %v", op.Action, secretName, err)
+				continue
+			}
+			if err := checkSecretValueSize(*op.Value, GetMaxSecretSize(0)); err != nil {
+				problem("%s '%s': %v", op.Action, secretName, err)
+				continue
+			}
+			if op.Action == applyActionCreate && exists[secretName] {
+				problem("secret '%s' already exists", secretName)
+				continue
+			}
+			if op.Action == applyActionUpdate && !exists[secretName] {
+				problem("secret '%s' does not exist", secretName)
+				continue
+			}
+			if op.Action == applyActionUpdate && len(op.Labels) > 0 {
+				problem("labels are only valid for create")
+				continue
+			}
+			exists[secretName] = true
+		case applyActionGrant, applyActionRevoke:
+			if op.Value != nil || len(op.Labels) > 0 {
+				problem("value and labels are not valid for %s", op.Action)
+				continue
+			}
+			if op.Principal == "" {
+				problem("principal is required for %s", op.Action)
+				continue
+			}
+			if err := validatePrincipalFormat(op.Principal); err != nil {
+				problem("invalid principal format: %s", op.Principal)
+				continue
+			}
+			if op.Action == applyActionGrant {
+				if err := checkBulkPublicGrant(op.Principal, allowPublic); err != nil {
+					problem("%v", err)
+					continue
+				}
+			}
+			if !exists[secretName] {
+				problem("secret '%s' does not exist", secretName)
+				continue
+			}
+			if op.Role == "" {
+				op.Role = defaultAccessRole
+			}
+		case "":
+			problem("action is required")
+			continue
+		default:
+			problem("unknown action '%s' (use create, update, grant, or revoke)", op.Action)
+			continue
+		}
+
+		steps = append(steps, applyStep{Operation: op, SecretName: secretName})
+	}
+
+	return steps, problems
+}
+
+// describeApplyStep summarizes a step for dry-run and error output. Values are never printed.
+func describeApplyStep(step applyStep) string {
+	op := step.Operation
+	switch op.Action {
+	case applyActionGrant:
+		return fmt.Sprintf("grant %s on '%s' to %s", op.Role, step.SecretName, op.Principal)
+	case applyActionRevoke:
+		return fmt.Sprintf("revoke %s on '%s' from %s", op.Role, step.SecretName, op.Principal)
+	default:
+		return fmt.Sprintf("%s secret '%s'", op.Action, step.SecretName)
+	}
+}
+
+// executeApplyStep runs a validated step using the same helpers as the
+// individual commands
+func executeApplyStep(step applyStep, project string) error {
+	op := step.Operation
+	switch op.Action {
+	case applyActionCreate:
+		labels := make(map[string]string)
+		for key, value := range GetConfig().Defaults.Labels {
+			labels[key] = value
+		}
+		for key, value := range op.Labels {
+			labels[key] = value
+		}
+		if err := createSecretFromImport(step.SecretName, *op.Value, labels, project); err != nil {
+			return err
+		}
+		fmt.Printf("Created secret: %s\n", step.SecretName)
+	case applyActionUpdate:
+		if err := updateSecretFromImport(step.SecretName, *op.Value, project); err != nil {
+			return err
+		}
+		fmt.Printf("Updated secret: %s\n", step.SecretName)
+	case applyActionGrant:
+		return grantSecretAccess(step.SecretName, op.Principal, op.Role, project, nil)
+	case applyActionRevoke:
+		return revokeSecretAccess(step.SecretName, op.Principal, op.Role, project)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import "fmt"
+
+// Changes reported in an apply plan
+const (
+	applyChangeCreateSecret  = "create-secret"
+	applyChangeAddVersion    = "add-version"
+	applyChangeAddBinding    = "add-binding"
+	applyChangeRemoveBinding = "remove-binding"
+)
+
+// ApplyPlan is the structured plan printed by 'apply --dry-run --format json|yaml'
+type ApplyPlan struct {
+	Project    string          `json:"project,omitempty" yaml:"project,omitempty"`
+	Operations []ApplyPlanStep `json:"operations" yaml:"operations"`
+	Changes    int             `json:"changes" yaml:"changes"`
+	NoOps      int             `json:"noOps" yaml:"noOps"`
+}
+
+// ApplyPlanStep is one planned operation. Values are never included.
+type ApplyPlanStep struct {
+	Index     int    `json:"index" yaml:"index"`
+	Action    string `json:"action" yaml:"action"`
+	Secret    string `json:"secret" yaml:"secret"`
+	Principal string `json:"principal,omitempty" yaml:"principal,omitempty"`
+	Role      string `json:"role,omitempty" yaml:"role,omitempty"`
+	Change    string `json:"change" yaml:"change"`
+	NoOp      bool   `json:"noOp" yaml:"noOp"`
+}
+
+// buildApplyPlan turns validated steps into a plan. The IAM policy of each
+// existing secret targeted by a grant or revoke is fetched once, and the
+// bindings are tracked through the batch, so a grant of a role the principal
+// already holds (or a revoke of one it does not) is marked as a no-op. Secrets
+// created in the batch start with an empty policy.
+func buildApplyPlan(steps []applyStep, project string, fetchPolicy func(secretName string) (*IAMPolicy, error)) (ApplyPlan, error) {
+	plan := ApplyPlan{Project: project, Operations: make([]ApplyPlanStep, 0, len(steps))}
+
+	// members[secret][role] holds the unconditional members of each role
+	members := make(map[string]map[string]map[string]bool)
+	bindingsOf := func(secretName string) (map[string]map[string]bool, error) {
+		if roles, ok := members[secretName]; ok {
+			return roles, nil
+		}
+		policy, err := fetchPolicy(secretName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch IAM policy for '%s': %w", secretName, err)
+		}
+		roles := make(map[string]map[string]bool)
+		for _, binding := range policy.Bindings {
+			// add-iam-policy-binding without a condition only touches the unconditional binding
+			if binding.Condition != nil {
+				continue
+			}
+			if roles[binding.Role] == nil {
+				roles[binding.Role] = make(map[string]bool)
+			}
+			for _, member := range binding.Members {
+				roles[binding.Role][normalizeMember(member)] = true
+			}
+		}
+		members[secretName] = roles
+		return roles, nil
+	}
+
+	for i, step := range steps {
+		op := step.Operation
+		planned := ApplyPlanStep{Index: i + 1, Action: op.Action, Secret: step.SecretName}
+
+		switch op.Action {
+		case applyActionCreate:
+			planned.Change = applyChangeCreateSecret
+			members[step.SecretName] = make(map[string]map[string]bool)
+		case applyActionUpdate:
+			planned.Change = applyChangeAddVersion
+		case applyActionGrant, applyActionRevoke:
+			planned.Principal = op.Principal
+			planned.Role = op.Role
+
+			roles, err := bindingsOf(step.SecretName)
+			if err != nil {
+				return ApplyPlan{}, err
+			}
+			if roles[op.Role] == nil {
+				roles[op.Role] = make(map[string]bool)
+			}
+			member := normalizeMember(op.Principal)
+			held := roles[op.Role][member]
+			if op.Action == applyActionGrant {
+				planned.Change = applyChangeAddBinding
+				planned.NoOp = held
+				roles[op.Role][member] = true
+			} else {
+				planned.Change = applyChangeRemoveBinding
+				planned.NoOp = !held
+				delete(roles[op.Role], member)
+			}
+		}
+
+		if planned.NoOp {
+			plan.NoOps++
+		} else {
+			plan.Changes++
+		}
+		plan.Operations = append(plan.Operations, planned)
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestBuildApplyPlan tests change detection against pre-fetched IAM policies
+func TestBuildApplyPlan(t *testing.T) {
+	value := "s3cret"
+	steps := []applyStep{
+		{Operation: ApplyOperation{Action: "create", Name: "new", Value: &value}, SecretName: "new"},
+		{Operation: ApplyOperation{Action: "grant", Name: "new", Principal: "user:alice@example.com", Role: defaultAccessRole}, SecretName: "new"},
+		{Operation: ApplyOperation{Action: "update", Name: "db", Value: &value}, SecretName: "db"},
+		{Operation: ApplyOperation{Action: "grant", Name: "db", Principal: "User:alice@example.com", Role: defaultAccessRole}, SecretName: "db"},
+		{Operation: ApplyOperation{Action: "grant", Name: "db", Principal: "user:bob@example.com", Role: defaultAccessRole}, SecretName: "db"},
+		{Operation: ApplyOperation{Action: "revoke", Name: "db", Principal: "group:ops@example.com", Role: defaultAccessRole}, SecretName: "db"},
+		{Operation: ApplyOperation{Action: "revoke", Name: "db", Principal: "user:bob@example.com", Role: defaultAccessRole}, SecretName: "db"},
+	}
+
+	fetched := map[string]int{}
+	fetchPolicy := func(secretName string) (*IAMPolicy, error) {
+		fetched[secretName]++
+		return &IAMPolicy{Bindings: []Binding{
+			{Role: defaultAccessRole, Members: []string{"user:alice@example.com"}},
+			{Role: defaultAccessRole, Members: []string{"group:ops@example.com"}, Condition: &Condition{Title: "temporary"}},
+		}}, nil
+	}
+
+	plan, err := buildApplyPlan(steps, "my-project", fetchPolicy)
+	if err != nil {
+		t.Fatalf("buildApplyPlan() failed: %v", err)
+	}
+
+	expected := []struct {
+		change string
+		noOp   bool
+	}{
+		{applyChangeCreateSecret, false},
+		{applyChangeAddBinding, false},
+		{applyChangeAddVersion, false},
+		{applyChangeAddBinding, true},     // already granted, member case normalized
+		{applyChangeAddBinding, false},    // new member
+		{applyChangeRemoveBinding, true},  // only held through a conditional binding
+		{applyChangeRemoveBinding, false}, // granted earlier in the batch
+	}
+	if len(plan.Operations) != len(expected) {
+		t.Fatalf("Expected %d operations, got %d", len(expected), len(plan.Operations))
+	}
+	for i, want := range expected {
+		got := plan.Operations[i]
+		if got.Index != i+1 || got.Change != want.change || got.NoOp != want.noOp {
+			t.Errorf("Operation %d: got %+v, want change %s no-op %v", i+1, got, want.change, want.noOp)
+		}
+	}
+	if plan.Changes != 5 || plan.NoOps != 2 {
+		t.Errorf("Expected 5 changes and 2 no-ops, got %d and %d", plan.Changes, plan.NoOps)
+	}
+	if plan.Operations[3].Principal != "User:alice@example.com" || plan.Operations[3].Role != defaultAccessRole {
+		t.Errorf("Expected principal and role to be reported as given, got %+v", plan.Operations[3])
+	}
+	if fetched["db"] != 1 || fetched["new"] != 0 {
+		t.Errorf("Expected one policy fetch for the existing secret only, got %v", fetched)
+	}
+
+	data, err := json.Marshal(plan)
+	if err != nil {
+		t.Fatalf("Failed to marshal plan: %v", err)
+	}
+	if !strings.Contains(string(data), `"noOps":2`) || !strings.Contains(string(data), `"noOp":true`) {
+		t.Errorf("Expected camelCase noOps and noOp keys, got %s", data)
+	}
+
+	if _, err := buildApplyPlan(steps[3:4], "", func(string) (*IAMPolicy, error) {
+		return nil, errors.New("permission denied")
+	}); err == nil {
+		t.Error("Expected error when the IAM policy cannot be fetched")
+	}
+}
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseApplyOperations tests decoding of the JSON operation stream
+func TestParseApplyOperations(t *testing.T) {
+	operations, err := parseApplyOperations(strings.NewReader(`[
+		{"action": "create", "name": "db", "value": "s3cret", "labels": {"env": "prod"}},
+		{"action": "grant", "name": "db", "principal": "user:alice@example.com"}
+	]`))
+	if err != nil {
+		t.Fatalf("parseApplyOperations() failed: %v", err)
+	}
+	if len(operations) != 2 || *operations[0].Value != "s3cret" || operations[1].Principal != "user:alice@example.com" {
+		t.Errorf("Unexpected operations: %+v", operations)
+	}
+
+	if _, err := parseApplyOperations(strings.NewReader(`[{"action": "create", "name": "db", "valu": "typo"}]`)); err == nil {
+		t.Error("Expected error for unknown field")
+	}
+	if _, err := parseApplyOperations(strings.NewReader(`{"action": "create"}`)); err == nil {
+		t.Error("Expected error for non-array input")
+	}
+}
+
+// TestValidateApplyOperations tests that all operations are checked before execution
+func TestValidateApplyOperations(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Prefix: "team-"}
+
+	value := "v"
+	empty := ""
+	existing := map[string]bool{"team-existing": true}
+
+	tests := []struct {
+		name          string
+		operations    []ApplyOperation
+		expectSteps   int
+		expectProblem string
+	}{
+		{
+			name: "create then grant on the new secret",
+			operations: []ApplyOperation{
+				{Action: "create", Name: "new", Value: &value},
+				{Action: "grant", Name: "new", Principal: "user:alice@example.com"},
+				{Action: "update", Name: "team-new", Value: &value},
+			},
+			expectSteps: 3,
+		},
+		{
+			name:          "missing action",
+			operations:    []ApplyOperation{{Name: "existing"}},
+			expectProblem: "action is required",
+		},
+		{
+			name:          "unknown action",
+			operations:    []ApplyOperation{{Action: "delete", Name: "existing"}},
+			expectProblem: "unknown action",
+		},
+		{
+			name:          "missing name",
+			operations:    []ApplyOperation{{Action: "create", Value: &value}},
+			expectProblem: "name is required",
+		},
+		{
+			name:          "create without value",
+			operations:    []ApplyOperation{{Action: "create", Name: "new"}},
+			expectProblem: "value is required",
+		},
+		{
+			name:          "create with empty value",
+			operations:    []ApplyOperation{{Action: "create", Name: "new", Value: &empty}},
+			expectProblem: "value is empty",
+		},
+		{
+			name:          "create existing secret",
+			operations:    []ApplyOperation{{Action: "create", Name: "existing", Value: &value}},
+			expectProblem: "already exists",
+		},
+		{
+			name:          "update missing secret",
+			operations:    []ApplyOperation{{Action: "update", Name: "missing", Value: &value}},
+			expectProblem: "does not exist",
+		},
+		{
+			name:          "grant with invalid principal",
+			operations:    []ApplyOperation{{Action: "grant", Name: "existing", Principal: "alice"}},
+			expectProblem: "invalid principal",
+		},
+		{
+			name:          "grant to allUsers",
+			operations:    []ApplyOperation{{Action: "grant", Name: "existing", Principal: "allUsers"}},
+			expectProblem: "refusing to grant access to allUsers without --force",
+		},
+		{
+			name:        "revoke from allAuthenticatedUsers",
+			operations:  []ApplyOperation{{Action: "revoke", Name: "existing", Principal: "allAuthenticatedUsers"}},
+			expectSteps: 1,
+		},
+		{
+			name:          "grant with value",
+			operations:    []ApplyOperation{{Action: "grant", Name: "existing", Principal: "user:a@example.com", Value: &value}},
+			expectProblem: "not valid for grant",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, problems := validateApplyOperations(tt.operations, existing, false, false)
+			if tt.expectProblem == "" {
+				if len(problems) > 0 {
+					t.Fatalf("Unexpected problems: %v", problems)
+				}
+				if len(steps) != tt.expectSteps {
+					t.Errorf("Got %d steps, expected %d", len(steps), tt.expectSteps)
+				}
+				return
+			}
+			if len(problems) != 1 || !strings.Contains(problems[0], tt.expectProblem) {
+				t.Errorf("problems = %v, expected one containing %q", problems, tt.expectProblem)
+			}
+		})
+	}
+}
+
+// TestValidateApplyOperationsDefaults tests name resolution and the default role
+func TestValidateApplyOperationsDefaults(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Prefix: "team-"}
+
+	steps, problems := validateApplyOperations([]ApplyOperation{
+		{Action: "revoke", Name: "db", Principal: "group:ops@example.com"},
+	}, map[string]bool{"team-db": true}, false, false)
+	if len(problems) > 0 {
+		t.Fatalf("Unexpected problems: %v", problems)
+	}
+	if steps[0].SecretName != "team-db" || steps[0].Operation.Role != defaultAccessRole {
+		t.Errorf("Unexpected step: %+v", steps[0])
+	}
+	if got := describeApplyStep(steps[0]); got != "revoke roles/secretmanager.secretAccessor on 'team-db' from group:ops@example.com" {
+		t.Errorf("describeApplyStep() = %q", got)
+	}
+}
@@ -1,14 +1,21 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // AuditLogEntry represents an audit log entry from Google Cloud Logging
@@ -39,8 +46,9 @@ type AuditLogEntry struct {
 }
 
 var auditlogCmd = &cobra.Command{
-	Use:   "auditlog [SECRET_NAME]",
-	Short: "Show audit log for secret access",
+	Use:     "auditlog [SECRET_NAME]",
+	Aliases: []string{"audit"},
+	Short:   "Show audit log for secret access",
 	Long: `Show audit log entries for secrets, including who accessed them,
 when they accessed them, and what operations were performed.
 
@@ -58,7 +66,76 @@ Examples:
   gsecutil auditlog my-secret          # Show logs for secrets containing "my-secret"
   gsecutil auditlog --principal john   # Show logs for user containing "john"
   gsecutil auditlog --operation ACCESS,CREATE    # Show only ACCESS and CREATE operations
-  gsecutil auditlog db --principal admin --operation UPDATE    # Specific filters combined`,
+  gsecutil auditlog db --principal admin --operation UPDATE    # Specific filters combined
+  gsecutil auditlog my-secret --order asc   # Read a session chronologically
+  gsecutil auditlog --describe-ops          # Explain each operation in plain language
+  gsecutil auditlog my-secret --summary --days 30  # Who accessed the secret, and how often
+  gsecutil auditlog my-secret --format markdown  # Table to paste into a ticket
+  gsecutil auditlog --fields timestamp,user,operation --csv  # Only the chosen columns
+  gsecutil auditlog --csv --output audit.csv    # Append new entries to an archive CSV
+  gsecutil auditlog --start 2025-06-01 --end 2025-06-02  # Entries from two whole days (UTC)
+  gsecutil auditlog --days 30 --limit 1000 --cache-file audit.json  # Fetch once and cache
+  gsecutil auditlog --from-cache --cache-file audit.json --operation ACCESS  # Re-filter offline
+  gsecutil auditlog --input exported.json --principal alice  # Analyze logs exported by a sink
+  gsecutil auditlog --exclude-principal ci-bot@my-proj.iam --exclude-operation LIST  # Hide routine noise
+  gsecutil auditlog --operation ACCESS --flag-unexpected  # Mark accesses by principals the config does not expect
+
+Time window:
+--days looks back from now. --start and --end select an explicit window
+instead, and take an RFC 3339 timestamp (2025-06-01T09:30:00Z) or a date
+(2025-06-01, UTC); a date --end includes that whole day. --end defaults to
+now, and --days is ignored when --start is given. The window also narrows
+--input and --from-cache entries, but cannot be used when fetching into
+--cache-file, since the cache records a --days window.
+
+Exclusions:
+--exclude-principal and --exclude-operation drop matching entries after the
+other filters are applied. Both take comma-separated values; principals match
+partially and case-insensitively, like --principal. --exclude-user and
+--exclude-operations are accepted as alternative spellings, as are --user and
+--operations for --principal and --operation. 'gsecutil audit' is an alias of
+this command.
+
+Caching:
+--cache-file stores the entries fetched from gcloud, along with the secret,
+principal, days, and limit used, in a JSON file. --from-cache reads that file
+instead of querying gcloud, so the same window can be sliced by different
+--operation, --principal, or secret filters offline. A warning is printed when
+the requested filters are broader than the cached query (for example a longer
+--days window), since the cache cannot contain those entries. With --from-cache,
+--days and --limit narrow the cached entries only when given explicitly.
+
+Exported logs:
+--input reads log entries from a file instead of querying gcloud, for audit
+logs routed to a Cloud Storage bucket by a log sink or saved with
+'gcloud logging read --format json'. The file may be a JSON array or JSON
+lines (one entry per line); use - to read stdin. The same filters and output
+formats apply. --days (counted back from now) and --limit narrow the entries
+only when given explicitly.
+
+Columns:
+CSV output (--format csv, or --csv) has a header row of timestamp, operation,
+method, user, and resource, quoting values that contain commas.
+
+--fields chooses the columns and their order from timestamp, operation,
+description, method, user, and resource. It applies to the table, --format
+markdown, and CSV output (whose header holds the field names and keeps full
+timestamps and resource names), but not to --output archives or JSON.
+
+Summary:
+--summary aggregates the filtered entries by principal instead of listing
+them: one row per principal with its total, the count of each operation, and
+its most recent entry, most active principals first. With --format json the
+aggregated records are printed instead of raw entries. Counts cover the
+entries retrieved, so raise --limit for long windows.
+
+Unexpected access:
+--flag-unexpected adds a FLAG column that marks ACCESS entries as UNEXPECTED
+when the principal is not listed in the expected_accessors attribute of the
+secret's configuration entry. The attribute holds principals as a YAML list or
+a comma-separated string; member types such as "user:" or "serviceAccount:"
+are ignored, and matching is case-insensitive. Secrets without the attribute
+are never flagged. It applies to the table, --format markdown, and CSV output.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get command arguments and flags
@@ -74,50 +151,272 @@ Examples:
 		}
 
 		project, _ := cmd.Flags().GetString("project")
-		project = GetProject(project) // Use configuration-based project resolution
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
 		days, _ := cmd.Flags().GetInt("days")
 		limit, _ := cmd.Flags().GetInt("limit")
 		format, _ := cmd.Flags().GetString("format")
 		principalFilter, _ := cmd.Flags().GetString("principal")
 		operationFilter, _ := cmd.Flags().GetString("operation")
+		csvOutput, _ := cmd.Flags().GetBool("csv")
+		outputPath, _ := cmd.Flags().GetString("output")
+		cacheFile, _ := cmd.Flags().GetString("cache-file")
+		fromCache, _ := cmd.Flags().GetBool("from-cache")
+		order, _ := cmd.Flags().GetString("order")
+		describeOps, _ := cmd.Flags().GetBool("describe-ops")
+		inputFile, _ := cmd.Flags().GetString("input")
+		excludePrincipal, _ := cmd.Flags().GetString("exclude-principal")
+		excludeOperation, _ := cmd.Flags().GetString("exclude-operation")
+		flagUnexpected, _ := cmd.Flags().GetBool("flag-unexpected")
+		startValue, _ := cmd.Flags().GetString("start")
+		endValue, _ := cmd.Flags().GetString("end")
+		summary, _ := cmd.Flags().GetBool("summary")
+
+		if order != auditLogOrderAsc && order != auditLogOrderDesc {
+			return fmt.Errorf("invalid --order '%s' (use asc or desc)", order)
+		}
+		format, err = auditLogOutputFormat(format, csvOutput)
+		if err != nil {
+			return err
+		}
+		if outputPath != "" && format != "csv" {
+			return fmt.Errorf("--output requires --csv")
+		}
+		if inputFile != "" && (fromCache || cacheFile != "") {
+			return fmt.Errorf("--input cannot be combined with --cache-file or --from-cache")
+		}
+		if fromCache && cacheFile == "" {
+			return fmt.Errorf("--from-cache requires --cache-file")
+		}
+		if fromCache || inputFile != "" {
+			// Without explicit values, use the whole window and every entry read
+			if !cmd.Flags().Changed("days") {
+				days = 0
+			}
+			if !cmd.Flags().Changed("limit") {
+				limit = 0
+			}
+		}
+		timeRange, err := parseAuditLogTimeRange(startValue, endValue, time.Now())
+		if err != nil {
+			return err
+		}
+		if timeRange.isSet() {
+			if cacheFile != "" && !fromCache {
+				return fmt.Errorf("--start cannot be combined with --cache-file when fetching, since the cache records a --days window")
+			}
+			if cmd.Flags().Changed("days") {
+				fmt.Fprintln(os.Stderr, "Warning: --days is ignored when --start is given")
+			}
+			days = 0
+		}
 
-		return runAuditLogQuery(project, secretName, principalFilter, operationFilter, days, limit, format)
+		exclusions := auditLogExclusions{
+			Principals: parsePrincipalExclusions(excludePrincipal),
+			Operations: parseOperationFilter(excludeOperation),
+		}
+		var fields []string
+		if cmd.Flags().Changed("fields") {
+			fieldsValue, _ := cmd.Flags().GetString("fields")
+			var err error
+			if fields, err = parseAuditLogFields(fieldsValue); err != nil {
+				return err
+			}
+			if describeOps {
+				return fmt.Errorf("--fields cannot be combined with --describe-ops (add description to --fields instead)")
+			}
+			if outputPath != "" {
+				return fmt.Errorf("--fields cannot be combined with --output, since the archive CSV keeps every column")
+			}
+			if format == "json" {
+				return fmt.Errorf("--fields supports table, csv, and markdown output, not json")
+			}
+		}
+		if flagUnexpected {
+			if outputPath != "" {
+				return fmt.Errorf("--flag-unexpected cannot be combined with --output, since the archive CSV keeps a fixed layout")
+			}
+			if format == "json" {
+				return fmt.Errorf("--flag-unexpected supports table, csv, and markdown output, not json")
+			}
+			if !hasExpectedAccessors() {
+				fmt.Fprintf(os.Stderr, "Warning: no configuration entry sets %s; no entries will be flagged\n", expectedAccessorsAttribute)
+			}
+			if len(fields) == 0 {
+				fields = defaultAuditLogFields(describeOps)
+			}
+			fields = append(fields, auditLogFlagField)
+		}
+		if summary {
+			switch {
+			case outputPath != "":
+				return fmt.Errorf("--summary cannot be combined with --output, since the archive CSV keeps raw entries")
+			case len(fields) > 0:
+				return fmt.Errorf("--summary cannot be combined with --fields or --flag-unexpected")
+			case describeOps:
+				return fmt.Errorf("--summary cannot be combined with --describe-ops")
+			}
+		}
+		return runAuditLogQuery(project, secretName, principalFilter, operationFilter, exclusions, days, timeRange, limit, format, outputPath, cacheFile, fromCache, inputFile, order, describeOps, fields, summary)
 	},
 }
 
-// runAuditLogQuery executes the audit log query with filtering
-func runAuditLogQuery(project, secretName, principalFilter, operationFilter string, days, limit int, format string) error {
+// auditLogOutputFormat validates --format and folds --csv into it, so that
+// --csv and --format csv behave the same. An empty format is the table.
+func auditLogOutputFormat(format string, csvOutput bool) (string, error) {
+	switch format {
+	case "", "table", "json", "csv", markdownFormat:
+	default:
+		return "", fmt.Errorf("invalid --format '%s' (use table, json, csv, or markdown)", format)
+	}
+	if csvOutput {
+		if format != "" && format != "csv" {
+			return "", fmt.Errorf("--csv cannot be combined with --format %s", format)
+		}
+		format = "csv"
+	}
+	if format == "table" {
+		format = ""
+	}
+	return format, nil
+}
+
+// runAuditLogQuery executes the audit log query with filtering. With fromCache,
+// entries are read from cacheFile instead of gcloud, and with inputFile from an
+// exported log file; otherwise fetched entries are also saved to cacheFile
+// when it is set. exclusions drop entries after the other filters, and are
+// never part of the gcloud query or the cache. days <= 0 applies no time window,
+// and a set timeRange (from --start and --end) replaces it. Results are sorted by timestamp
+// in the given order before any output; describeOps adds a DESCRIPTION column
+// to the table, and fields (from --fields) replaces the columns when set. With
+// summary, the entries are aggregated per principal instead of listed.
+func runAuditLogQuery(project, secretName, principalFilter, operationFilter string, exclusions auditLogExclusions, days int, timeRange auditLogTimeRange, limit int, format, outputPath, cacheFile string, fromCache bool, inputFile, order string, describeOps bool, fields []string, summary bool) error {
 	// Parse operation filter
 	operations := parseOperationFilter(operationFilter)
 
-	// Build the filter for Secret Manager audit logs
-	filter := buildLogFilter(secretName, principalFilter, days)
+	var logEntries []AuditLogEntry
+	if fromCache {
+		cache, err := readAuditLogCache(cacheFile)
+		if err != nil {
+			return err
+		}
+		for _, warning := range auditLogCacheWarnings(cache, project, secretName, principalFilter, days) {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+		}
+		logEntries = cachedEntriesWithin(cache, days)
+		if timeRange.isSet() {
+			if covered := cache.FetchedAt.AddDate(0, 0, -cache.Days); cache.Days > 0 && timeRange.Start.Before(covered) {
+				fmt.Fprintf(os.Stderr, "Warning: cache only covers the %d days before %s, not from %s\n",
+					cache.Days, cache.FetchedAt.UTC().Format(datetimeFormat), timeRange.Start.UTC().Format(datetimeFormat))
+			}
+			logEntries = timeRange.entries(logEntries)
+		} else if days <= 0 {
+			days = cache.Days
+		}
+	} else if inputFile != "" {
+		var err error
+		logEntries, err = readAuditLogInput(inputFile)
+		if err != nil {
+			return err
+		}
+		if timeRange.isSet() {
+			logEntries = timeRange.entries(logEntries)
+		} else if days > 0 {
+			logEntries = entriesSince(logEntries, time.Now().AddDate(0, 0, -days))
+		}
+	} else {
+		// Build the filter for Secret Manager audit logs
+		filter := buildLogFilter(secretName, principalFilter, days, timeRange)
 
-	// Execute gcloud logging command
-	logEntries, err := executeLogQuery(project, filter, limit)
-	if err != nil {
-		return err
+		// Execute gcloud logging command
+		var err error
+		logEntries, err = executeLogQuery(project, filter, limit)
+		if err != nil {
+			return err
+		}
+
+		if cacheFile != "" {
+			cache := AuditLogCache{
+				FetchedAt: time.Now().UTC(),
+				Project:   project,
+				Secret:    secretName,
+				Principal: principalFilter,
+				Days:      days,
+				Limit:     limit,
+				Entries:   logEntries,
+			}
+			if err := writeAuditLogCache(cacheFile, cache); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Cached %d audit log entries to %s\n", len(logEntries), cacheFile)
+		}
 	}
 
 	// Filter entries if needed (for partial matching that gcloud filter can't handle well)
-	filteredEntries := filterLogEntries(logEntries, secretName, principalFilter, operations)
+	filteredEntries := filterLogEntries(logEntries, secretName, principalFilter, operations, exclusions)
+	if (fromCache || inputFile != "") && limit > 0 && len(filteredEntries) > limit {
+		// Keep the newest entries, as the gcloud query does, whatever the file order
+		sortLogEntries(filteredEntries, auditLogOrderDesc)
+		filteredEntries = filteredEntries[:limit]
+	}
+	sortLogEntries(filteredEntries, order)
+
+	// Append mode reports its own counts, even when nothing new was found
+	if outputPath != "" {
+		appended, err := appendAuditLogCsv(outputPath, filteredEntries)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Appended %d new audit log entries to %s (%d fetched, %d already present)\n",
+			appended, outputPath, len(filteredEntries), len(filteredEntries)-appended)
+		return nil
+	}
 
 	if len(filteredEntries) == 0 {
 		printNoResultsMessage(secretName, principalFilter, operationFilter, days)
 		return nil
 	}
 
+	if summary {
+		return displayLogSummary(filteredEntries, secretName, principalFilter, operationFilter, days, format)
+	}
+
 	// Display results
-	return displayLogEntries(filteredEntries, secretName, principalFilter, operationFilter, days, format)
+	return displayLogEntries(filteredEntries, secretName, principalFilter, operationFilter, days, format, describeOps, fields)
+}
+
+// Audit log output orders
+const (
+	auditLogOrderAsc  = "asc"
+	auditLogOrderDesc = "desc"
+)
+
+// sortLogEntries sorts entries by timestamp, oldest first for asc and newest
+// first for desc. Entries with equal timestamps keep their relative order.
+func sortLogEntries(entries []AuditLogEntry, order string) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if order == auditLogOrderAsc {
+			return entries[i].Timestamp.Before(entries[j].Timestamp)
+		}
+		return entries[i].Timestamp.After(entries[j].Timestamp)
+	})
 }
 
-// buildLogFilter constructs the gcloud logging filter query
-func buildLogFilter(secretName, principalFilter string, days int) string {
+// buildLogFilter constructs the gcloud logging filter query. A set timeRange
+// bounds the timestamps on both sides; otherwise they reach back days days.
+func buildLogFilter(secretName, principalFilter string, days int, timeRange auditLogTimeRange) string {
 	// Base filter for Secret Manager service
 	filter := `protoPayload.serviceName="secretmanager.googleapis.com"`
 
 	// Add time constraint
-	filter += fmt.Sprintf(` AND timestamp>="%s"`, time.Now().AddDate(0, 0, -days).Format(time.RFC3339))
+	if timeRange.isSet() {
+		filter += fmt.Sprintf(` AND timestamp>="%s" AND timestamp<="%s"`,
+			timeRange.Start.UTC().Format(time.RFC3339Nano), timeRange.End.UTC().Format(time.RFC3339Nano))
+	} else {
+		filter += fmt.Sprintf(` AND timestamp>="%s"`, time.Now().AddDate(0, 0, -days).Format(time.RFC3339))
+	}
 
 	// Add secret name filter if provided
 	if secretName != "" {
@@ -151,7 +450,7 @@ func executeLogQuery(project, filter string, limit int) ([]AuditLogEntry, error)
 	}
 
 	// Execute gcloud command
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
 	output, err := gcloudCmd.Output()
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
@@ -216,8 +515,47 @@ func isValidOperation(operation string) bool {
 	return false
 }
 
-// filterLogEntries performs post-processing filtering for partial matches and secret relevance
-func filterLogEntries(entries []AuditLogEntry, secretName, principalFilter string, operations []string) []AuditLogEntry {
+// auditLogExclusions are the negative filters applied after the positive ones
+type auditLogExclusions struct {
+	Principals []string // partial, case-insensitive principal matches
+	Operations []string // operation names, as returned by getOperationName
+}
+
+// excludes reports whether an entry matches any exclusion
+func (e auditLogExclusions) excludes(entry AuditLogEntry) bool {
+	if len(e.Principals) > 0 {
+		principal := strings.ToLower(entry.ProtoPayload.AuthenticationInfo.PrincipalEmail)
+		for _, excluded := range e.Principals {
+			if principal != "" && strings.Contains(principal, strings.ToLower(excluded)) {
+				return true
+			}
+		}
+	}
+	if len(e.Operations) > 0 {
+		operationName := getOperationName(entry.ProtoPayload.MethodName)
+		for _, excluded := range e.Operations {
+			if operationName == excluded {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parsePrincipalExclusions splits a comma-separated --exclude-principal value
+func parsePrincipalExclusions(value string) []string {
+	var principals []string
+	for _, part := range strings.Split(value, ",") {
+		if principal := strings.TrimSpace(part); principal != "" {
+			principals = append(principals, principal)
+		}
+	}
+	return principals
+}
+
+// filterLogEntries performs post-processing filtering for partial matches and
+// secret relevance, then drops the entries matching the exclusions
+func filterLogEntries(entries []AuditLogEntry, secretName, principalFilter string, operations []string, exclusions auditLogExclusions) []AuditLogEntry {
 	var filtered []AuditLogEntry
 
 	for _, entry := range entries {
@@ -272,6 +610,10 @@ func filterLogEntries(entries []AuditLogEntry, secretName, principalFilter strin
 			}
 		}
 
+		if exclusions.excludes(entry) {
+			continue
+		}
+
 		filtered = append(filtered, entry)
 	}
 
@@ -337,16 +679,21 @@ func printNoResultsMessage(secretName, principalFilter, operationFilter string,
 		filters = append(filters, fmt.Sprintf("operations '%s'", operationFilter))
 	}
 
+	window := ""
+	if days > 0 {
+		window = fmt.Sprintf(" in the last %d days", days)
+	}
 	if len(filters) > 0 {
-		fmt.Printf("No audit log entries found for %s in the last %d days.\n", strings.Join(filters, " and "), days)
+		fmt.Printf("No audit log entries found for %s%s.\n", strings.Join(filters, " and "), window)
 	} else {
-		fmt.Printf("No Secret Manager audit log entries found in the last %d days.\n", days)
+		fmt.Printf("No Secret Manager audit log entries found%s.\n", window)
 	}
 	fmt.Println("Note: Audit logs may take some time to appear, and require Cloud Audit Logs to be enabled.")
 }
 
-// displayLogEntries formats and displays the log entries
-func displayLogEntries(entries []AuditLogEntry, secretName, principalFilter, operationFilter string, days int, format string) error {
+// displayLogEntries formats and displays the log entries. fields, when set,
+// chooses the columns of the table, Markdown, and CSV output.
+func displayLogEntries(entries []AuditLogEntry, secretName, principalFilter, operationFilter string, days int, format string, describeOps bool, fields []string) error {
 	// Display results based on format
 	if format == "json" {
 		jsonOutput, err := json.MarshalIndent(entries, "", "  ")
@@ -357,41 +704,346 @@ func displayLogEntries(entries []AuditLogEntry, secretName, principalFilter, ope
 		return nil
 	}
 
+	if format == "csv" && len(fields) > 0 {
+		rows := make([][]string, 0, len(entries))
+		for _, entry := range entries {
+			rows = append(rows, auditLogCsvFields(entry, fields))
+		}
+		return printCsvTable(fields, rows)
+	}
+	if format == "csv" {
+		writer := csv.NewWriter(os.Stdout)
+		if err := writeAuditLogCsv(writer, entries, auditLogCsvHeader, true); err != nil {
+			return fmt.Errorf("failed to write CSV output: %w", err)
+		}
+		return nil
+	}
+
+	chosenFields := len(fields) > 0
+	if !chosenFields {
+		fields = defaultAuditLogFields(describeOps)
+	}
+	header, rows := auditLogTableColumns(entries, fields)
+	if format == markdownFormat {
+		printMarkdownTable(header, rows)
+		return nil
+	}
+
+	// Columns chosen with --fields are aligned to their content
+	if chosenFields {
+		printAuditLogTitle(secretName, principalFilter, operationFilter, days)
+		printTextTable(header, rows)
+		fmt.Printf("\nTotal entries: %d\n", len(entries))
+		if column := slices.Index(fields, auditLogFlagField); column >= 0 {
+			flagged := 0
+			for _, row := range rows {
+				if row[column] != "" {
+					flagged++
+				}
+			}
+			fmt.Printf("Flagged as unexpected: %d\n", flagged)
+		}
+		return nil
+	}
+
 	// Default table format
-	printTableHeader(secretName, principalFilter, operationFilter, days)
+	printTableHeader(secretName, principalFilter, operationFilter, days, describeOps)
 
-	for _, entry := range entries {
-		timestamp := entry.Timestamp.Format("2006-01-02 15:04:05")
-		operation := getOperationName(entry.ProtoPayload.MethodName)
-		user := entry.ProtoPayload.AuthenticationInfo.PrincipalEmail
-		if user == "" {
-			user = "system"
+	for _, row := range rows {
+		if describeOps {
+			fmt.Printf("%-20s %-30s %-34s %-40s %s\n", row[0], row[1], row[2], row[3], row[4])
+		} else {
+			fmt.Printf("%-20s %-30s %-40s %s\n", row[0], row[1], row[2], row[3])
 		}
+	}
+
+	fmt.Printf("\nTotal entries: %d\n", len(entries))
+	return nil
+}
 
-		// Extract resource name
-		resourceName := entry.ProtoPayload.ResourceName
-		if resourceName == "" {
-			resourceName = entry.ProtoPayload.Request.Name
+// auditLogFields are the --fields names of the audit log columns
+var auditLogFields = []string{"timestamp", "operation", "description", "method", "user", "resource"}
+
+// defaultAuditLogFields returns the columns of the table without --fields
+func defaultAuditLogFields(describeOps bool) []string {
+	if describeOps {
+		return []string{"timestamp", "operation", "description", "user", "resource"}
+	}
+	return []string{"timestamp", "operation", "user", "resource"}
+}
+
+// parseAuditLogFields parses --fields: a comma-separated list of audit log
+// columns, in display order
+func parseAuditLogFields(value string) ([]string, error) {
+	var fields []string
+	seen := make(map[string]bool)
+	for _, field := range strings.Split(value, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+		if !slices.Contains(auditLogFields, field) {
+			return nil, fmt.Errorf("unknown field '%s' (valid fields: %s)", field, strings.Join(auditLogFields, ", "))
 		}
-		if resourceName == "" {
-			resourceName = entry.ProtoPayload.Response.Name
+		if seen[field] {
+			return nil, fmt.Errorf("duplicate field '%s' in --fields", field)
 		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("--fields requires at least one field")
+	}
+	return fields, nil
+}
 
+// auditLogFieldValue returns the table value of one column of an entry
+func auditLogFieldValue(entry AuditLogEntry, field string) string {
+	switch field {
+	case "timestamp":
+		return entry.Timestamp.Format("2006-01-02 15:04:05")
+	case "operation":
+		return getOperationName(entry.ProtoPayload.MethodName)
+	case "description":
+		return getOperationDescription(getOperationName(entry.ProtoPayload.MethodName))
+	case "method":
+		return entry.ProtoPayload.MethodName
+	case "user":
+		if user := entry.ProtoPayload.AuthenticationInfo.PrincipalEmail; user != "" {
+			return user
+		}
+		return "system"
+	case "resource":
 		// Shorten resource name by replacing the heading part before the 3rd '/' with '...'
+		resourceName := getEntryResourceName(entry)
 		parts := strings.Split(resourceName, "/")
 		if len(parts) > 3 {
 			resourceName = "..." + "/" + strings.Join(parts[3:], "/")
 		}
+		return resourceName
+	case auditLogFlagField:
+		if isUnexpectedAccess(entry) {
+			return unexpectedAccessFlag
+		}
+	}
+	return ""
+}
 
-		fmt.Printf("%-20s %-30s %-40s %s\n", timestamp, operation, user, resourceName)
+// auditLogCsvFields returns the CSV values of the chosen columns of an entry,
+// matching the full-precision values of the default CSV output
+func auditLogCsvFields(entry AuditLogEntry, fields []string) []string {
+	record := auditLogCsvRecord(entry)
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		if column := slices.Index(auditLogCsvHeader, field); column >= 0 {
+			values[i] = record[column]
+		} else {
+			values[i] = auditLogFieldValue(entry, field)
+		}
 	}
+	return values
+}
 
-	fmt.Printf("\nTotal entries: %d\n", len(entries))
-	return nil
+// auditLogTableColumns returns the header and rows of the audit log table
+// with the given columns. Both the text and the Markdown table use it.
+func auditLogTableColumns(entries []AuditLogEntry, fields []string) ([]string, [][]string) {
+	header := make([]string, len(fields))
+	for i, field := range fields {
+		header[i] = strings.ToUpper(field)
+	}
+
+	rows := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		row := make([]string, len(fields))
+		for i, field := range fields {
+			row[i] = auditLogFieldValue(entry, field)
+		}
+		rows = append(rows, row)
+	}
+	return header, rows
+}
+
+// getEntryResourceName returns the first non-empty resource name recorded on an entry
+func getEntryResourceName(entry AuditLogEntry) string {
+	resourceName := entry.ProtoPayload.ResourceName
+	if resourceName == "" {
+		resourceName = entry.ProtoPayload.Request.Name
+	}
+	if resourceName == "" {
+		resourceName = entry.ProtoPayload.Response.Name
+	}
+	return resourceName
+}
+
+// auditLogCsvHeader is the column layout used for CSV audit log output
+var auditLogCsvHeader = []string{"timestamp", "operation", "method", "user", "resource"}
+
+// auditLogCsvRecord converts an entry into a CSV row matching auditLogCsvHeader.
+// Timestamps keep full precision so they remain usable as part of the dedup key.
+func auditLogCsvRecord(entry AuditLogEntry) []string {
+	return []string{
+		entry.Timestamp.UTC().Format(time.RFC3339Nano),
+		getOperationName(entry.ProtoPayload.MethodName),
+		entry.ProtoPayload.MethodName,
+		entry.ProtoPayload.AuthenticationInfo.PrincipalEmail,
+		getEntryResourceName(entry),
+	}
+}
+
+// auditLogDedupKey builds the stable key used to detect rows already present in an archive
+func auditLogDedupKey(timestamp, method, resource, principal string) string {
+	return strings.Join([]string{timestamp, method, resource, principal}, "\x00")
+}
+
+// auditLogCsvRow converts an entry into a CSV row with the given columns, so
+// rows appended to an archive match its header. Columns that are not in
+// auditLogCsvHeader are left empty.
+func auditLogCsvRow(entry AuditLogEntry, columns []string) []string {
+	record := auditLogCsvRecord(entry)
+	if slices.Equal(columns, auditLogCsvHeader) {
+		return record
+	}
+	row := make([]string, len(columns))
+	for i, column := range columns {
+		if j := slices.Index(auditLogCsvHeader, column); j >= 0 {
+			row[i] = record[j]
+		}
+	}
+	return row
+}
+
+// writeAuditLogCsv writes entries as CSV rows with the given columns,
+// optionally preceded by them as the header
+func writeAuditLogCsv(writer *csv.Writer, entries []AuditLogEntry, columns []string, withHeader bool) error {
+	if withHeader {
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+	}
+	for _, entry := range entries {
+		if err := writer.Write(auditLogCsvRow(entry, columns)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// readAuditLogCsvKeys collects the dedup keys of all rows in an existing
+// archive CSV, and returns its header with the column names lowercased, or
+// nil when the file is empty
+func readAuditLogCsvKeys(r io.Reader) (map[string]bool, []string, error) {
+	keys := make(map[string]bool)
+
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return keys, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, col := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(col))
+		columns[header[i]] = i
+	}
+	for _, required := range []string{"timestamp", "method", "resource", "user"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("existing CSV is missing required column '%s'", required)
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read CSV row: %w", err)
+		}
+		keys[auditLogDedupKey(record[columns["timestamp"]], record[columns["method"]], record[columns["resource"]], record[columns["user"]])] = true
+	}
+
+	return keys, header, nil
+}
+
+// filterNewAuditLogEntries drops entries whose dedup key is already known,
+// including duplicates within the same batch
+func filterNewAuditLogEntries(entries []AuditLogEntry, keys map[string]bool) []AuditLogEntry {
+	var newEntries []AuditLogEntry
+	for _, entry := range entries {
+		record := auditLogCsvRecord(entry)
+		key := auditLogDedupKey(record[0], record[2], record[4], record[3])
+		if keys[key] {
+			continue
+		}
+		keys[key] = true
+		newEntries = append(newEntries, entry)
+	}
+	return newEntries
+}
+
+// appendAuditLogCsv appends entries not already present in the CSV at path,
+// creating the file with a header if it does not exist. New rows follow the
+// column order of an existing header. Returns the number of rows appended.
+// The existing rows and the new ones are rewritten together atomically, so an
+// interrupted append never leaves a partial row behind.
+func appendAuditLogCsv(path string, entries []AuditLogEntry) (int, error) {
+	keys := make(map[string]bool)
+	columns := auditLogCsvHeader
+	withHeader := true
+
+	existing, err := os.ReadFile(path)
+	if err == nil {
+		var header []string
+		keys, header, err = readAuditLogCsvKeys(bytes.NewReader(existing))
+		if err != nil {
+			return 0, fmt.Errorf("failed to read existing audit log CSV %s: %w", path, err)
+		}
+		if header != nil {
+			columns = header
+		}
+		withHeader = len(existing) == 0
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return 0, fmt.Errorf("failed to open audit log CSV %s: %w", path, err)
+	}
+
+	newEntries := filterNewAuditLogEntries(entries, keys)
+	if len(newEntries) == 0 && !withHeader {
+		return 0, nil
+	}
+
+	buf := bytes.NewBuffer(existing)
+	if len(existing) > 0 && !bytes.HasSuffix(existing, []byte("\n")) {
+		buf.WriteByte('\n')
+	}
+	if err := writeAuditLogCsv(csv.NewWriter(buf), newEntries, columns, withHeader); err != nil {
+		return 0, fmt.Errorf("failed to write audit log CSV %s: %w", path, err)
+	}
+	if err := atomicWriteFile(path, buf.Bytes(), 0644); err != nil {
+		return 0, fmt.Errorf("failed to write audit log CSV %s: %w", path, err)
+	}
+
+	return len(newEntries), nil
 }
 
 // printTableHeader prints the appropriate table header based on filters
-func printTableHeader(secretName, principalFilter, operationFilter string, days int) {
+func printTableHeader(secretName, principalFilter, operationFilter string, days int, describeOps bool) {
+	printAuditLogTitle(secretName, principalFilter, operationFilter, days)
+
+	if describeOps {
+		fmt.Printf("%-20s %-30s %-34s %-40s %-30s\n", "TIMESTAMP", "OPERATION", "DESCRIPTION", "USER", "RESOURCE")
+		fmt.Println(strings.Repeat("-", 155))
+		return
+	}
+	fmt.Printf("%-20s %-30s %-40s %-30s\n", "TIMESTAMP", "OPERATION", "USER", "RESOURCE")
+	fmt.Println(strings.Repeat("-", 120))
+}
+
+// printAuditLogTitle prints the line above the table describing the filters
+func printAuditLogTitle(secretName, principalFilter, operationFilter string, days int) {
 	filters := []string{}
 	if secretName != "" {
 		filters = append(filters, fmt.Sprintf("secret '%s'", secretName))
@@ -403,14 +1055,38 @@ func printTableHeader(secretName, principalFilter, operationFilter string, days
 		filters = append(filters, fmt.Sprintf("operations '%s'", operationFilter))
 	}
 
+	window := ""
+	if days > 0 {
+		window = fmt.Sprintf(" (last %d days)", days)
+	}
 	if len(filters) > 0 {
-		fmt.Printf("Secret Manager audit logs matching %s (last %d days):\n\n", strings.Join(filters, " and "), days)
+		fmt.Printf("Secret Manager audit logs matching %s%s:\n\n", strings.Join(filters, " and "), window)
 	} else {
-		fmt.Printf("Secret Manager audit logs (last %d days):\n\n", days)
+		fmt.Printf("Secret Manager audit logs%s:\n\n", window)
 	}
+}
 
-	fmt.Printf("%-20s %-30s %-40s %-30s\n", "TIMESTAMP", "OPERATION", "USER", "RESOURCE")
-	fmt.Println(strings.Repeat("-", 120))
+// OperationDescriptions maps operation names to plain-language descriptions
+var OperationDescriptions = map[string]string{
+	"ACCESS":          "Read the secret value",
+	"CREATE":          "Created the secret",
+	"UPDATE":          "Added a new secret version",
+	"DELETE":          "Deleted the secret",
+	"GET_METADATA":    "Viewed secret or version metadata",
+	"LIST":            "Listed secrets or versions",
+	"UPDATE_METADATA": "Changed labels or settings",
+	"DESTROY_VERSION": "Permanently destroyed a version",
+	"DISABLE_VERSION": "Disabled a version",
+	"ENABLE_VERSION":  "Re-enabled a disabled version",
+}
+
+// getOperationDescription returns the description of an operation name, or "-"
+// for operations without one
+func getOperationDescription(operation string) string {
+	if description := OperationDescriptions[operation]; description != "" {
+		return description
+	}
+	return "-"
 }
 
 // getOperationName converts gcloud method names to human-readable operation names
@@ -449,8 +1125,40 @@ func getOperationName(methodName string) string {
 func init() {
 	rootCmd.AddCommand(auditlogCmd)
 	auditlogCmd.Flags().IntP("days", "d", 7, "Number of days to look back for audit logs")
+	auditlogCmd.Flags().String("start", "", "Show entries from this time: RFC 3339 timestamp or YYYY-MM-DD (replaces --days)")
+	auditlogCmd.Flags().String("end", "", "Show entries up to this time: RFC 3339 timestamp or YYYY-MM-DD, inclusive (default now; requires --start)")
 	auditlogCmd.Flags().IntP("limit", "l", 50, "Maximum number of log entries to retrieve")
-	auditlogCmd.Flags().String("format", "", "Output format: table (default) or json")
+	auditlogCmd.Flags().String("format", "", "Output format: table (default), json, csv, or markdown")
+	auditlogCmd.Flags().String("fields", "", "Comma-separated columns to show, in order: timestamp, operation, description, method, user, resource")
 	auditlogCmd.Flags().String("principal", "", "Filter by principal/user (supports partial matching)")
 	auditlogCmd.Flags().StringP("operation", "o", "", "Filter by operations (comma-separated): ACCESS,CREATE,UPDATE,DELETE,GET_METADATA,LIST,UPDATE_METADATA,DESTROY_VERSION,DISABLE_VERSION,ENABLE_VERSION")
+	auditlogCmd.Flags().Bool("csv", false, "Output results as CSV")
+	auditlogCmd.Flags().String("output", "", "Append CSV results to this file, skipping entries already present (requires --csv)")
+	auditlogCmd.Flags().String("cache-file", "", "Save fetched entries to this JSON file (or read them with --from-cache)")
+	auditlogCmd.Flags().Bool("from-cache", false, "Read entries from --cache-file instead of querying gcloud")
+	auditlogCmd.Flags().String("input", "", "Read exported log entries (JSON array or JSON lines) from this file instead of querying gcloud (- for stdin)")
+	auditlogCmd.Flags().Bool("describe-ops", false, "Add a DESCRIPTION column explaining each operation in plain language (table output)")
+	auditlogCmd.Flags().Bool("summary", false, "Print per-principal counts of each operation and the latest entry instead of the entries")
+	auditlogCmd.Flags().String("order", auditLogOrderDesc, "Sort entries by timestamp: desc (newest first) or asc (oldest first)")
+	auditlogCmd.Flags().String("exclude-principal", "", "Hide entries by these principals (comma-separated, partial matching)")
+	auditlogCmd.Flags().String("exclude-operation", "", "Hide these operations (comma-separated), e.g. LIST,GET_METADATA")
+	auditlogCmd.Flags().Bool("flag-unexpected", false, "Mark ACCESS entries by principals missing from the secret's expected_accessors config attribute")
+	auditlogCmd.Flags().SetNormalizeFunc(normalizeAuditLogFlagName)
+}
+
+// normalizeAuditLogFlagName accepts --user, --operations, --exclude-user, and
+// --exclude-operations as spellings of --principal, --operation,
+// --exclude-principal, and --exclude-operation
+func normalizeAuditLogFlagName(f *pflag.FlagSet, name string) pflag.NormalizedName {
+	switch name {
+	case "user":
+		name = "principal"
+	case "operations":
+		name = "operation"
+	case "exclude-user":
+		name = "exclude-principal"
+	case "exclude-operations":
+		name = "exclude-operation"
+	}
+	return pflag.NormalizedName(name)
 }
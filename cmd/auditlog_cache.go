@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// AuditLogCache is the file written by 'auditlog --cache-file'. It stores the
+// entries returned by gcloud (before client-side filtering) together with the
+// query that produced them, so --from-cache can warn about incompatible filters.
+type AuditLogCache struct {
+	FetchedAt time.Time       `json:"fetchedAt"`
+	Project   string          `json:"project,omitempty"`
+	Secret    string          `json:"secret,omitempty"`
+	Principal string          `json:"principal,omitempty"`
+	Days      int             `json:"days"`
+	Limit     int             `json:"limit,omitempty"`
+	Entries   []AuditLogEntry `json:"entries"`
+}
+
+// writeAuditLogCache saves fetched entries and their query to path
+func writeAuditLogCache(path string, cache AuditLogCache) error {
+	if cache.Entries == nil {
+		cache.Entries = []AuditLogEntry{}
+	}
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit log cache: %w", err)
+	}
+	if err := atomicWriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write audit log cache %s: %w", path, err)
+	}
+	return nil
+}
+
+// readAuditLogCache loads a cache written by writeAuditLogCache
+func readAuditLogCache(path string) (*AuditLogCache, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log cache %s: %w", path, err)
+	}
+	var cache AuditLogCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return nil, fmt.Errorf("failed to parse audit log cache %s: %w", path, err)
+	}
+	return &cache, nil
+}
+
+// auditLogCacheWarnings reports ways the requested query is broader than the
+// cached one, in which case the cache cannot contain every matching entry.
+// Narrower filters are fine: they are applied to the cached entries. days is 0
+// when the cached window should be used as-is.
+func auditLogCacheWarnings(cache *AuditLogCache, project, secretName, principalFilter string, days int) []string {
+	var warnings []string
+
+	if project != "" && cache.Project != "" && project != cache.Project {
+		warnings = append(warnings, fmt.Sprintf("cache was fetched from project '%s', not '%s'", cache.Project, project))
+	}
+
+	// Cached entries only match the cached filters; the new filter must match a subset of them
+	if cache.Secret != "" && !strings.Contains(strings.ToLower(secretName), strings.ToLower(cache.Secret)) {
+		warnings = append(warnings, fmt.Sprintf("cache only contains secrets matching '%s'", cache.Secret))
+	}
+	if cache.Principal != "" && !strings.Contains(strings.ToLower(principalFilter), strings.ToLower(cache.Principal)) {
+		warnings = append(warnings, fmt.Sprintf("cache only contains principals matching '%s'", cache.Principal))
+	}
+
+	if days > cache.Days {
+		warnings = append(warnings, fmt.Sprintf("cache only covers the %d days before %s, not %d", cache.Days, cache.FetchedAt.UTC().Format(datetimeFormat), days))
+	}
+	if cache.Limit > 0 && len(cache.Entries) >= cache.Limit {
+		warnings = append(warnings, fmt.Sprintf("cache was truncated at --limit %d; older entries may be missing", cache.Limit))
+	}
+
+	return warnings
+}
+
+// cachedEntriesWithin returns the cached entries from the last days days,
+// measured from when the cache was fetched. days <= 0 returns every entry.
+func cachedEntriesWithin(cache *AuditLogCache, days int) []AuditLogEntry {
+	if days <= 0 || days >= cache.Days {
+		return cache.Entries
+	}
+
+	return entriesSince(cache.Entries, cache.FetchedAt.AddDate(0, 0, -days))
+}
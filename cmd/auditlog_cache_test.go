@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestAuditLogCacheRoundTrip tests that cached entries and query metadata survive a write and read
+func TestAuditLogCacheRoundTrip(t *testing.T) {
+	fetchedAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	access := "google.cloud.secretmanager.v1.SecretManagerService.AccessSecretVersion"
+	cache := AuditLogCache{
+		FetchedAt: fetchedAt,
+		Project:   "test",
+		Secret:    "db",
+		Days:      30,
+		Limit:     100,
+		Entries: []AuditLogEntry{
+			newTestAuditLogEntry(fetchedAt.Add(-time.Hour), access, "projects/test/secrets/db/versions/1", "alice@example.com"),
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "audit.json")
+	if err := writeAuditLogCache(path, cache); err != nil {
+		t.Fatalf("writeAuditLogCache() failed: %v", err)
+	}
+	loaded, err := readAuditLogCache(path)
+	if err != nil {
+		t.Fatalf("readAuditLogCache() failed: %v", err)
+	}
+
+	if !loaded.FetchedAt.Equal(fetchedAt) || loaded.Project != "test" || loaded.Secret != "db" || loaded.Days != 30 || loaded.Limit != 100 {
+		t.Errorf("Query metadata not preserved: %+v", loaded)
+	}
+	if len(loaded.Entries) != 1 || loaded.Entries[0].ProtoPayload.MethodName != access ||
+		loaded.Entries[0].ProtoPayload.AuthenticationInfo.PrincipalEmail != "alice@example.com" {
+		t.Errorf("Entries not preserved: %+v", loaded.Entries)
+	}
+
+	if _, err := readAuditLogCache(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for missing cache file")
+	}
+}
+
+// TestAuditLogCacheWarnings tests detection of queries broader than the cached one
+func TestAuditLogCacheWarnings(t *testing.T) {
+	cache := &AuditLogCache{
+		FetchedAt: time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC),
+		Project:   "test",
+		Secret:    "db",
+		Principal: "example.com",
+		Days:      7,
+		Limit:     2,
+		Entries:   []AuditLogEntry{{}},
+	}
+
+	tests := []struct {
+		name      string
+		project   string
+		secret    string
+		principal string
+		days      int
+		expected  []string
+	}{
+		{name: "same query", project: "test", secret: "db", principal: "example.com", days: 7},
+		{name: "narrower filters", project: "test", secret: "db-password", principal: "alice@example.com", days: 3},
+		{name: "cached window", secret: "db", principal: "example.com", days: 0},
+		{name: "other project", project: "other", secret: "db", principal: "example.com", expected: []string{"project 'test'"}},
+		{name: "no secret filter", principal: "example.com", expected: []string{"secrets matching 'db'"}},
+		{name: "different principal", secret: "db", principal: "bob", expected: []string{"principals matching 'example.com'"}},
+		{name: "longer window", secret: "db", principal: "example.com", days: 30, expected: []string{"7 days"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := auditLogCacheWarnings(cache, tt.project, tt.secret, tt.principal, tt.days)
+			if len(warnings) != len(tt.expected) {
+				t.Fatalf("warnings = %v, expected %d", warnings, len(tt.expected))
+			}
+			for i, want := range tt.expected {
+				if !strings.Contains(warnings[i], want) {
+					t.Errorf("warnings[%d] = %q, expected to contain %q", i, warnings[i], want)
+				}
+			}
+		})
+	}
+
+	// A cache that filled its limit may be missing older entries
+	cache.Entries = []AuditLogEntry{{}, {}}
+	warnings := auditLogCacheWarnings(cache, "test", "db", "example.com", 0)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "--limit 2") {
+		t.Errorf("warnings = %v, expected a truncation warning", warnings)
+	}
+}
+
+// TestCachedEntriesWithin tests narrowing cached entries to a shorter window
+func TestCachedEntriesWithin(t *testing.T) {
+	fetchedAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	cache := &AuditLogCache{
+		FetchedAt: fetchedAt,
+		Days:      7,
+		Entries: []AuditLogEntry{
+			newTestAuditLogEntry(fetchedAt.AddDate(0, 0, -1), "m", "r", "p"),
+			newTestAuditLogEntry(fetchedAt.AddDate(0, 0, -5), "m", "r", "p"),
+		},
+	}
+
+	if got := cachedEntriesWithin(cache, 0); len(got) != 2 {
+		t.Errorf("days=0 returned %d entries, expected 2", len(got))
+	}
+	if got := cachedEntriesWithin(cache, 3); len(got) != 1 {
+		t.Errorf("days=3 returned %d entries, expected 1", len(got))
+	}
+	if got := cachedEntriesWithin(cache, 30); len(got) != 2 {
+		t.Errorf("days=30 returned %d entries, expected 2", len(got))
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// expectedAccessorsAttribute is the config attribute listing the principals
+// expected to access a secret, checked by 'auditlog --flag-unexpected'
+const expectedAccessorsAttribute = "expected_accessors"
+
+// auditLogFlagField is the column added by --flag-unexpected; it is not a
+// --fields name since it needs the expected accessors from the config
+const auditLogFlagField = "flag"
+
+// unexpectedAccessFlag is the FLAG value of an unexpected access
+const unexpectedAccessFlag = "UNEXPECTED"
+
+// expectedAccessors returns the principals listed in the expected_accessors
+// attribute of a credential, lowercased and without a member type such as
+// "user:", and whether the attribute is set. It may be a YAML list or a
+// comma-separated string.
+func expectedAccessors(cred *CredentialInfo) (map[string]bool, bool) {
+	if cred == nil {
+		return nil, false
+	}
+	value, ok := cred.Attributes[expectedAccessorsAttribute]
+	if !ok {
+		return nil, false
+	}
+
+	var principals []string
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			principals = append(principals, fmt.Sprintf("%v", item))
+		}
+	default:
+		principals = strings.Split(fmt.Sprintf("%v", v), ",")
+	}
+
+	accessors := make(map[string]bool, len(principals))
+	for _, principal := range principals {
+		if principal = normalizeAccessor(principal); principal != "" {
+			accessors[principal] = true
+		}
+	}
+	return accessors, true
+}
+
+// normalizeAccessor lowercases a principal and drops its IAM member type, so
+// "serviceAccount:ci@p.iam.gserviceaccount.com" matches the audit log email
+func normalizeAccessor(principal string) string {
+	principal = strings.ToLower(strings.TrimSpace(principal))
+	if _, email, ok := strings.Cut(principal, ":"); ok {
+		principal = email
+	}
+	return principal
+}
+
+// isUnexpectedAccess reports whether an entry is an ACCESS by a principal not
+// in the expected_accessors of its secret's config entry. Secrets without the
+// attribute have no expectation, so their accesses are never flagged.
+func isUnexpectedAccess(entry AuditLogEntry) bool {
+	if getOperationName(entry.ProtoPayload.MethodName) != "ACCESS" {
+		return false
+	}
+	secretName := extractSecretName(getEntryResourceName(entry))
+	name := strings.TrimPrefix(secretName, GetPrefix()) // config stores bare names
+	accessors, ok := expectedAccessors(GetCredentialInfo(name))
+	if !ok {
+		return false
+	}
+	return !accessors[normalizeAccessor(entry.ProtoPayload.AuthenticationInfo.PrincipalEmail)]
+}
+
+// hasExpectedAccessors reports whether any config entry sets expected_accessors
+func hasExpectedAccessors() bool {
+	for _, cred := range GetConfig().Credentials {
+		if _, ok := cred.Attributes[expectedAccessorsAttribute]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// TestIsUnexpectedAccess tests flagging ACCESS entries against the
+// expected_accessors config attribute
+func TestIsUnexpectedAccess(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{
+		Prefix: "team-",
+		Credentials: []CredentialInfo{
+			{Name: "db", Attributes: map[string]interface{}{
+				expectedAccessorsAttribute: []interface{}{"serviceAccount:app@proj.iam.gserviceaccount.com", "user:Alice@example.com"},
+			}},
+			{Name: "api", Attributes: map[string]interface{}{expectedAccessorsAttribute: "bob@example.com, ci@example.com"}},
+			{Name: "cache"},
+		},
+	}
+
+	timestamp := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	access := "google.cloud.secretmanager.v1.SecretManagerService.AccessSecretVersion"
+	tests := []struct {
+		name      string
+		method    string
+		resource  string
+		principal string
+		expected  bool
+	}{
+		{name: "expected service account", method: access, resource: "projects/1/secrets/team-db/versions/3", principal: "app@proj.iam.gserviceaccount.com", expected: false},
+		{name: "expected user, case-insensitive", method: access, resource: "projects/1/secrets/team-db/versions/3", principal: "alice@example.com", expected: false},
+		{name: "unexpected user", method: access, resource: "projects/1/secrets/team-db/versions/3", principal: "mallory@example.com", expected: true},
+		{name: "comma-separated list", method: access, resource: "projects/1/secrets/team-api/versions/1", principal: "ci@example.com", expected: false},
+		{name: "comma-separated list, unexpected", method: access, resource: "projects/1/secrets/team-api/versions/1", principal: "alice@example.com", expected: true},
+		{name: "no expectation in config", method: access, resource: "projects/1/secrets/team-cache/versions/1", principal: "mallory@example.com", expected: false},
+		{name: "secret without config entry", method: access, resource: "projects/1/secrets/team-other/versions/1", principal: "mallory@example.com", expected: false},
+		{name: "not an access", method: "google.cloud.secretmanager.v1.SecretManagerService.GetSecret", resource: "projects/1/secrets/team-db", principal: "mallory@example.com", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := newTestAuditLogEntry(timestamp, tt.method, tt.resource, tt.principal)
+			if result := isUnexpectedAccess(entry); result != tt.expected {
+				t.Errorf("isUnexpectedAccess() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+
+	header, rows := auditLogTableColumns([]AuditLogEntry{
+		newTestAuditLogEntry(timestamp, access, "projects/1/secrets/team-db/versions/3", "mallory@example.com"),
+	}, append(defaultAuditLogFields(false), auditLogFlagField))
+	if last := len(header) - 1; header[last] != "FLAG" || rows[0][last] != unexpectedAccessFlag {
+		t.Errorf("Expected a FLAG column marking the entry, got %v %v", header, rows)
+	}
+}
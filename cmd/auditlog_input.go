@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// readAuditLogInput loads exported log entries for 'auditlog --input' from
+// path, or from stdin when path is "-"
+func readAuditLogInput(path string) ([]AuditLogEntry, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log input %s: %w", path, err)
+	}
+
+	entries, err := parseAuditLogEntries(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse audit log input %s: %w", path, err)
+	}
+	return entries, nil
+}
+
+// parseAuditLogEntries decodes exported log entries, either as a JSON array
+// (the output of 'gcloud logging read --format json') or as JSON lines with
+// one entry per line (the files a Cloud Storage log sink writes)
+func parseAuditLogEntries(data []byte) ([]AuditLogEntry, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+
+	if trimmed[0] == '[' {
+		var entries []AuditLogEntry
+		if err := json.Unmarshal(trimmed, &entries); err != nil {
+			return nil, err
+		}
+		return entries, nil
+	}
+
+	var entries []AuditLogEntry
+	scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+	// Log entries with large request or response payloads exceed the default line limit
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry AuditLogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// entriesSince returns the entries logged at or after cutoff
+func entriesSince(entries []AuditLogEntry, cutoff time.Time) []AuditLogEntry {
+	var kept []AuditLogEntry
+	for _, entry := range entries {
+		if !entry.Timestamp.Before(cutoff) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParseAuditLogEntries tests decoding exported entries as a JSON array or JSON lines
+func TestParseAuditLogEntries(t *testing.T) {
+	access := `{"timestamp": "2025-01-15T12:00:00Z", "protoPayload": {"methodName": "google.cloud.secretmanager.v1.SecretManagerService.AccessSecretVersion", "authenticationInfo": {"principalEmail": "alice@example.com"}}}`
+	create := `{"timestamp": "2025-01-15T13:00:00Z", "protoPayload": {"methodName": "google.cloud.secretmanager.v1.SecretManagerService.CreateSecret"}}`
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    int
+		expectError string
+	}{
+		{name: "JSON array", input: "[" + access + "," + create + "]", expected: 2},
+		{name: "JSON lines with blank lines", input: access + "\n\n" + create + "\n", expected: 2},
+		{name: "empty input", input: "  \n", expected: 0},
+		{name: "invalid line reports its number", input: access + "\nnot json\n", expectError: "line 2"},
+		{name: "invalid array", input: "[" + access, expectError: "unexpected end"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := parseAuditLogEntries([]byte(tt.input))
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("parseAuditLogEntries() error = %v, expected it to contain %q", err, tt.expectError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAuditLogEntries() error = %v", err)
+			}
+			if len(entries) != tt.expected {
+				t.Fatalf("parseAuditLogEntries() returned %d entries, expected %d", len(entries), tt.expected)
+			}
+			if tt.expected > 0 && entries[0].ProtoPayload.AuthenticationInfo.PrincipalEmail != "alice@example.com" {
+				t.Errorf("Entry fields not decoded: %+v", entries[0])
+			}
+		})
+	}
+
+	path := filepath.Join(t.TempDir(), "export.json")
+	if err := os.WriteFile(path, []byte(access+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write input file: %v", err)
+	}
+	if entries, err := readAuditLogInput(path); err != nil || len(entries) != 1 {
+		t.Errorf("readAuditLogInput() = %d entries, %v", len(entries), err)
+	}
+	if _, err := readAuditLogInput(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected error for missing input file")
+	}
+}
+
+// TestAuditLogInputLimitKeepsNewest tests that --limit keeps the newest
+// entries of an oldest-first export before --order is applied
+func TestAuditLogInputLimitKeepsNewest(t *testing.T) {
+	var lines []string
+	for _, day := range []string{"01", "02", "03"} {
+		lines = append(lines, `{"timestamp": "2025-01-`+day+`T12:00:00Z", "protoPayload": {"methodName": "google.cloud.secretmanager.v1.SecretManagerService.AccessSecretVersion"}}`)
+	}
+	path := filepath.Join(t.TempDir(), "export.jsonl")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write input file: %v", err)
+	}
+
+	output, err := executeCommand(t, "auditlog", "--input", path, "--project", "test-project", "--limit", "2", "--order", "asc", "--format", "json")
+	if err != nil {
+		t.Fatalf("auditlog --input failed: %v", err)
+	}
+	first, second := strings.Index(output, "2025-01-02"), strings.Index(output, "2025-01-03")
+	if strings.Contains(output, "2025-01-01") || first < 0 || second < first {
+		t.Errorf("Expected the two newest entries oldest first, got %q", output)
+	}
+}
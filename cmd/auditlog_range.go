@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// auditLogTimeRange is the window set by --start and --end. When set, it
+// replaces the --days lookback.
+type auditLogTimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// isSet reports whether --start was given
+func (r auditLogTimeRange) isSet() bool {
+	return !r.Start.IsZero()
+}
+
+// entries returns the entries logged between Start and End, inclusive
+func (r auditLogTimeRange) entries(entries []AuditLogEntry) []AuditLogEntry {
+	var kept []AuditLogEntry
+	for _, entry := range entries {
+		if !entry.Timestamp.Before(r.Start) && !entry.Timestamp.After(r.End) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+// parseAuditLogTimeRange parses the --start and --end values. Both take an
+// RFC 3339 timestamp or a YYYY-MM-DD date in UTC; a date --start begins at
+// midnight and a date --end covers the whole day. --end defaults to now, and
+// an empty start returns an unset range.
+func parseAuditLogTimeRange(start, end string, now time.Time) (auditLogTimeRange, error) {
+	start, end = strings.TrimSpace(start), strings.TrimSpace(end)
+	if start == "" {
+		if end != "" {
+			return auditLogTimeRange{}, fmt.Errorf("--end requires --start")
+		}
+		return auditLogTimeRange{}, nil
+	}
+
+	startTime, _, err := parseAuditLogTime("start", start)
+	if err != nil {
+		return auditLogTimeRange{}, err
+	}
+	endTime := now
+	if end != "" {
+		var dateOnly bool
+		if endTime, dateOnly, err = parseAuditLogTime("end", end); err != nil {
+			return auditLogTimeRange{}, err
+		}
+		if dateOnly {
+			endTime = endTime.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+	if !startTime.Before(endTime) {
+		return auditLogTimeRange{}, fmt.Errorf("--start (%s) must be before --end (%s)",
+			startTime.UTC().Format(time.RFC3339), endTime.UTC().Format(time.RFC3339))
+	}
+	return auditLogTimeRange{Start: startTime, End: endTime}, nil
+}
+
+// parseAuditLogTime parses an RFC 3339 timestamp or a YYYY-MM-DD date for
+// the named flag, and reports whether the value was a date
+func parseAuditLogTime(flag, value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid --%s '%s': use an RFC 3339 timestamp (2025-06-01T00:00:00Z) or a date (2025-06-01)", flag, value)
+}
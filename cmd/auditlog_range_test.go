@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseAuditLogTimeRange tests parsing of the --start and --end window
+func TestParseAuditLogTimeRange(t *testing.T) {
+	now := time.Date(2025, 6, 10, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		start         string
+		end           string
+		expectedStart time.Time
+		expectedEnd   time.Time
+		expectError   bool
+	}{
+		{name: "Unset"},
+		{
+			name:          "Dates cover whole days",
+			start:         "2025-06-01",
+			end:           "2025-06-02",
+			expectedStart: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, 6, 2, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:          "Same date for start and end",
+			start:         "2025-06-01",
+			end:           "2025-06-01",
+			expectedStart: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, 6, 1, 23, 59, 59, 999999999, time.UTC),
+		},
+		{
+			name:          "RFC 3339 timestamps",
+			start:         "2025-06-01T09:30:00Z",
+			end:           "2025-06-01T13:00:00+02:00",
+			expectedStart: time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "End defaults to now",
+			start:         "2025-06-01T09:30:00Z",
+			expectedStart: time.Date(2025, 6, 1, 9, 30, 0, 0, time.UTC),
+			expectedEnd:   now,
+		},
+		{name: "End without start", end: "2025-06-02", expectError: true},
+		{name: "Start after end", start: "2025-06-03", end: "2025-06-02", expectError: true},
+		{name: "Start in the future", start: "2025-07-01", expectError: true},
+		{name: "Invalid start", start: "last tuesday", expectError: true},
+		{name: "Invalid end", start: "2025-06-01", end: "06/02/2025", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseAuditLogTimeRange(tt.start, tt.end, now)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !result.Start.Equal(tt.expectedStart) || !result.End.Equal(tt.expectedEnd) {
+				t.Errorf("parseAuditLogTimeRange() = %v to %v, expected %v to %v", result.Start, result.End, tt.expectedStart, tt.expectedEnd)
+			}
+		})
+	}
+
+	timeRange := auditLogTimeRange{Start: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)}
+	entries := timeRange.entries([]AuditLogEntry{
+		{Timestamp: time.Date(2025, 5, 31, 23, 59, 0, 0, time.UTC)},
+		{Timestamp: timeRange.Start},
+		{Timestamp: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)},
+		{Timestamp: timeRange.End},
+		{Timestamp: time.Date(2025, 6, 2, 0, 1, 0, 0, time.UTC)},
+	})
+	if len(entries) != 3 {
+		t.Errorf("Expected 3 entries within the range (bounds inclusive), got %d", len(entries))
+	}
+}
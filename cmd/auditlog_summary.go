@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuditLogPrincipalSummary counts one principal's audit log entries by
+// operation, for 'auditlog --summary'
+type AuditLogPrincipalSummary struct {
+	Principal  string         `json:"principal"`
+	Total      int            `json:"total"`
+	Operations map[string]int `json:"operations"`
+	LastSeen   time.Time      `json:"lastSeen"`
+}
+
+// summarizeLogEntries aggregates entries by principal and operation, labeled
+// with getOperationName. Principals are ordered by total, most active first,
+// then by name; entries without a principal count as "system".
+func summarizeLogEntries(entries []AuditLogEntry) []AuditLogPrincipalSummary {
+	byPrincipal := make(map[string]*AuditLogPrincipalSummary)
+	for _, entry := range entries {
+		principal := auditLogFieldValue(entry, "user")
+		summary, ok := byPrincipal[principal]
+		if !ok {
+			summary = &AuditLogPrincipalSummary{Principal: principal, Operations: make(map[string]int)}
+			byPrincipal[principal] = summary
+		}
+		summary.Total++
+		summary.Operations[getOperationName(entry.ProtoPayload.MethodName)]++
+		if entry.Timestamp.After(summary.LastSeen) {
+			summary.LastSeen = entry.Timestamp
+		}
+	}
+
+	summaries := make([]AuditLogPrincipalSummary, 0, len(byPrincipal))
+	for _, summary := range byPrincipal {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Total != summaries[j].Total {
+			return summaries[i].Total > summaries[j].Total
+		}
+		return summaries[i].Principal < summaries[j].Principal
+	})
+	return summaries
+}
+
+// formatOperationCounts renders counts as "ACCESS=12, UPDATE=1", the most
+// frequent operation first
+func formatOperationCounts(operations map[string]int) string {
+	names := make([]string, 0, len(operations))
+	for name := range operations {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if operations[names[i]] != operations[names[j]] {
+			return operations[names[i]] > operations[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	counts := make([]string, len(names))
+	for i, name := range names {
+		counts[i] = fmt.Sprintf("%s=%d", name, operations[name])
+	}
+	return strings.Join(counts, ", ")
+}
+
+// displayLogSummary prints the per-principal summary of entries in the given
+// format: the aggregated structure for json, otherwise a table of counts
+func displayLogSummary(entries []AuditLogEntry, secretName, principalFilter, operationFilter string, days int, format string) error {
+	summaries := summarizeLogEntries(entries)
+	if format == "json" {
+		jsonOutput, err := json.MarshalIndent(summaries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON output: %w", err)
+		}
+		fmt.Println(string(jsonOutput))
+		return nil
+	}
+
+	header := []string{"USER", "TOTAL", "OPERATIONS", "LAST SEEN"}
+	rows := make([][]string, 0, len(summaries))
+	for _, summary := range summaries {
+		lastSeen := summary.LastSeen.Format("2006-01-02 15:04:05")
+		if format == "csv" {
+			lastSeen = summary.LastSeen.UTC().Format(time.RFC3339Nano)
+		}
+		rows = append(rows, []string{summary.Principal, strconv.Itoa(summary.Total), formatOperationCounts(summary.Operations), lastSeen})
+	}
+
+	switch format {
+	case "csv":
+		return printCsvTable([]string{"user", "total", "operations", "last_seen"}, rows)
+	case markdownFormat:
+		printMarkdownTable(header, rows)
+		return nil
+	}
+	printAuditLogTitle(secretName, principalFilter, operationFilter, days)
+	printTextTable(header, rows)
+	fmt.Printf("\nTotal entries: %d from %d principals\n", len(entries), len(summaries))
+	return nil
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestSummarizeLogEntries tests aggregating entries by principal and operation
+func TestSummarizeLogEntries(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 10, 0, 0, 0, time.UTC) }
+	access := "google.cloud.secretmanager.v1.SecretManagerService.AccessSecretVersion"
+	update := "google.cloud.secretmanager.v1.SecretManagerService.AddSecretVersion"
+	resource := "projects/1/secrets/db/versions/1"
+	entries := []AuditLogEntry{
+		newTestAuditLogEntry(day(3), access, resource, "bob@example.com"),
+		newTestAuditLogEntry(day(5), access, resource, "alice@example.com"),
+		newTestAuditLogEntry(day(1), update, resource, "alice@example.com"),
+		newTestAuditLogEntry(day(4), access, resource, "alice@example.com"),
+		newTestAuditLogEntry(day(2), access, resource, "carol@example.com"),
+		newTestAuditLogEntry(day(6), access, resource, ""),
+	}
+
+	expected := []AuditLogPrincipalSummary{
+		{Principal: "alice@example.com", Total: 3, Operations: map[string]int{"ACCESS": 2, getOperationName(update): 1}, LastSeen: day(5)},
+		{Principal: "bob@example.com", Total: 1, Operations: map[string]int{"ACCESS": 1}, LastSeen: day(3)},
+		{Principal: "carol@example.com", Total: 1, Operations: map[string]int{"ACCESS": 1}, LastSeen: day(2)},
+		{Principal: "system", Total: 1, Operations: map[string]int{"ACCESS": 1}, LastSeen: day(6)},
+	}
+	if result := summarizeLogEntries(entries); !reflect.DeepEqual(result, expected) {
+		t.Errorf("summarizeLogEntries() = %+v, expected %+v", result, expected)
+	}
+
+	if result := formatOperationCounts(map[string]int{"UPDATE": 1, "ACCESS": 12, "CREATE": 1}); result != "ACCESS=12, CREATE=1, UPDATE=1" {
+		t.Errorf("formatOperationCounts() = %q", result)
+	}
+}
@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -451,7 +455,7 @@ func TestFilterLogEntries(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := filterLogEntries(entries, tt.secretName, tt.userFilter, tt.operations)
+			result := filterLogEntries(entries, tt.secretName, tt.userFilter, tt.operations, auditLogExclusions{})
 			if len(result) != tt.expectedCount {
 				t.Errorf("filterLogEntries() returned %d entries, expected %d", len(result), tt.expectedCount)
 			}
@@ -466,6 +470,7 @@ func TestBuildLogFilter(t *testing.T) {
 		secretName  string
 		userFilter  string
 		days        int
+		timeRange   auditLogTimeRange
 		contains    []string
 		notContains []string
 	}{
@@ -501,11 +506,20 @@ func TestBuildLogFilter(t *testing.T) {
 				`protoPayload.authenticationInfo.principalEmail:"user@example.com"`,
 			},
 		},
+		{
+			name: "Explicit time range",
+			days: 7,
+			timeRange: auditLogTimeRange{
+				Start: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2025, 6, 2, 12, 30, 0, 0, time.UTC),
+			},
+			contains: []string{`AND timestamp>="2025-06-01T00:00:00Z" AND timestamp<="2025-06-02T12:30:00Z"`},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := buildLogFilter(tt.secretName, tt.userFilter, tt.days)
+			result := buildLogFilter(tt.secretName, tt.userFilter, tt.days, tt.timeRange)
 
 			// Check that required strings are present
 			for _, required := range tt.contains {
@@ -528,3 +542,364 @@ func TestBuildLogFilter(t *testing.T) {
 		})
 	}
 }
+
+// newTestAuditLogEntry builds an AuditLogEntry with the fields used by CSV output
+func newTestAuditLogEntry(timestamp time.Time, method, resource, principal string) AuditLogEntry {
+	var entry AuditLogEntry
+	entry.Timestamp = timestamp
+	entry.ProtoPayload.MethodName = method
+	entry.ProtoPayload.ResourceName = resource
+	entry.ProtoPayload.AuthenticationInfo.PrincipalEmail = principal
+	return entry
+}
+
+// TestAppendAuditLogCsv tests that repeated appends only add new entries
+func TestAppendAuditLogCsv(t *testing.T) {
+	base := time.Date(2025, 1, 15, 10, 0, 0, 123000000, time.UTC)
+	access := "google.cloud.secretmanager.v1.SecretManagerService.AccessSecretVersion"
+	resource := "projects/test/secrets/my-secret/versions/1"
+
+	first := []AuditLogEntry{
+		newTestAuditLogEntry(base, access, resource, "alice@example.com"),
+		newTestAuditLogEntry(base.Add(time.Minute), access, resource, "bob@example.com"),
+	}
+	second := []AuditLogEntry{
+		first[1],
+		newTestAuditLogEntry(base.Add(2*time.Minute), access, resource, "alice@example.com"),
+		newTestAuditLogEntry(base.Add(2*time.Minute), access, resource, "alice@example.com"),
+	}
+
+	path := filepath.Join(t.TempDir(), "audit.csv")
+
+	appended, err := appendAuditLogCsv(path, first)
+	if err != nil {
+		t.Fatalf("first append failed: %v", err)
+	}
+	if appended != 2 {
+		t.Errorf("first append = %d, expected 2", appended)
+	}
+
+	appended, err = appendAuditLogCsv(path, second)
+	if err != nil {
+		t.Fatalf("second append failed: %v", err)
+	}
+	if appended != 1 {
+		t.Errorf("second append = %d, expected 1", appended)
+	}
+
+	appended, err = appendAuditLogCsv(path, second)
+	if err != nil {
+		t.Fatalf("third append failed: %v", err)
+	}
+	if appended != 0 {
+		t.Errorf("third append = %d, expected 0", appended)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read CSV: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("CSV has %d lines, expected 4 (header + 3 rows):\n%s", len(lines), data)
+	}
+	if lines[0] != strings.Join(auditLogCsvHeader, ",") {
+		t.Errorf("header = %q, expected %q", lines[0], strings.Join(auditLogCsvHeader, ","))
+	}
+	if !strings.HasPrefix(lines[1], "2025-01-15T10:00:00.123Z,ACCESS,") {
+		t.Errorf("first row = %q, expected full-precision timestamp and operation", lines[1])
+	}
+}
+
+// TestAppendAuditLogCsvReorderedColumns tests that rows appended to an archive
+// with its own column order follow that order
+func TestAppendAuditLogCsvReorderedColumns(t *testing.T) {
+	access := "google.cloud.secretmanager.v1.SecretManagerService.AccessSecretVersion"
+	entry := newTestAuditLogEntry(time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC), access, "projects/test/secrets/db", "alice@example.com")
+
+	path := filepath.Join(t.TempDir(), "audit.csv")
+	existing := "User,Resource,Timestamp,Method,note\nbob@example.com,projects/test/secrets/db,2025-01-14T10:00:00Z," + access + ",manual\n"
+	if err := os.WriteFile(path, []byte(existing), 0644); err != nil {
+		t.Fatalf("Failed to write archive: %v", err)
+	}
+
+	if appended, err := appendAuditLogCsv(path, []AuditLogEntry{entry}); err != nil || appended != 1 {
+		t.Fatalf("appendAuditLogCsv() = %d, %v", appended, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read archive: %v", err)
+	}
+	expected := existing + "alice@example.com,projects/test/secrets/db,2025-01-15T10:00:00Z," + access + ",\n"
+	if string(data) != expected {
+		t.Errorf("Archive = %q, expected %q", data, expected)
+	}
+
+	// The appended row is recognized as already present
+	if appended, err := appendAuditLogCsv(path, []AuditLogEntry{entry}); err != nil || appended != 0 {
+		t.Errorf("Second appendAuditLogCsv() = %d, %v, expected 0", appended, err)
+	}
+}
+
+// TestReadAuditLogCsvKeys tests reading dedup keys from existing archives
+func TestReadAuditLogCsvKeys(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectedLen int
+		expectError bool
+	}{
+		{
+			name:        "Empty file",
+			content:     "",
+			expectedLen: 0,
+		},
+		{
+			name:        "Header only",
+			content:     "timestamp,operation,method,user,resource\n",
+			expectedLen: 0,
+		},
+		{
+			name:        "Reordered columns",
+			content:     "user,resource,timestamp,method\na@example.com,projects/p/secrets/s,2025-01-01T00:00:00Z,m\nb@example.com,projects/p/secrets/s,2025-01-01T00:00:00Z,m\n",
+			expectedLen: 2,
+		},
+		{
+			name:        "Missing column",
+			content:     "timestamp,operation,user,resource\n2025-01-01T00:00:00Z,ACCESS,a@example.com,projects/p/secrets/s\n",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, _, err := readAuditLogCsvKeys(strings.NewReader(tt.content))
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(keys) != tt.expectedLen {
+				t.Errorf("got %d keys, expected %d", len(keys), tt.expectedLen)
+			}
+		})
+	}
+}
+
+// TestSortLogEntries tests chronological and reverse ordering
+func TestSortLogEntries(t *testing.T) {
+	base := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	newEntries := func() []AuditLogEntry {
+		return []AuditLogEntry{
+			newTestAuditLogEntry(base.Add(time.Hour), "m", "first-tie", "u"),
+			newTestAuditLogEntry(base, "m", "oldest", "u"),
+			newTestAuditLogEntry(base.Add(2*time.Hour), "m", "newest", "u"),
+			newTestAuditLogEntry(base.Add(time.Hour), "m", "second-tie", "u"),
+		}
+	}
+
+	tests := []struct {
+		order    string
+		expected []string
+	}{
+		{order: auditLogOrderAsc, expected: []string{"oldest", "first-tie", "second-tie", "newest"}},
+		{order: auditLogOrderDesc, expected: []string{"newest", "first-tie", "second-tie", "oldest"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			entries := newEntries()
+			sortLogEntries(entries, tt.order)
+			for i, entry := range entries {
+				if entry.ProtoPayload.ResourceName != tt.expected[i] {
+					t.Errorf("Position %d = %s, expected %s", i, entry.ProtoPayload.ResourceName, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+// TestGetOperationDescription tests that every filterable operation is described
+func TestGetOperationDescription(t *testing.T) {
+	operations := []string{
+		"ACCESS", "CREATE", "UPDATE", "DELETE", "GET_METADATA",
+		"LIST", "UPDATE_METADATA", "DESTROY_VERSION",
+		"DISABLE_VERSION", "ENABLE_VERSION",
+	}
+	for _, operation := range operations {
+		if !isValidOperation(operation) {
+			t.Errorf("%s is not a valid operation", operation)
+		}
+		if description := getOperationDescription(operation); description == "-" {
+			t.Errorf("No description for %s", operation)
+		}
+	}
+
+	if description := getOperationDescription("SomeNewMethod"); description != "-" {
+		t.Errorf("Expected \"-\" for an unknown operation, got %q", description)
+	}
+}
+
+// TestFilterLogEntriesExclusions tests that exclusions drop entries after the positive filters
+func TestFilterLogEntriesExclusions(t *testing.T) {
+	now := time.Now()
+	access := "google.cloud.secretmanager.v1.SecretManagerService.AccessSecretVersion"
+	list := "google.cloud.secretmanager.v1.SecretManagerService.ListSecrets"
+	entries := []AuditLogEntry{
+		newTestAuditLogEntry(now, access, "projects/test/secrets/db/versions/1", "ci-bot@my-proj.iam.gserviceaccount.com"),
+		newTestAuditLogEntry(now, access, "projects/test/secrets/db/versions/1", "alice@example.com"),
+		newTestAuditLogEntry(now, list, "projects/test/secrets/db", "alice@example.com"),
+		newTestAuditLogEntry(now, access, "projects/test/secrets/api/versions/2", "Deployer@example.com"),
+	}
+
+	tests := []struct {
+		name          string
+		operations    []string
+		exclusions    auditLogExclusions
+		expectedUsers []string
+	}{
+		{
+			name:          "Exclude a service account",
+			exclusions:    auditLogExclusions{Principals: parsePrincipalExclusions("ci-bot@")},
+			expectedUsers: []string{"alice@example.com", "alice@example.com", "Deployer@example.com"},
+		},
+		{
+			name:          "Exclude several principals case-insensitively",
+			exclusions:    auditLogExclusions{Principals: parsePrincipalExclusions("ci-bot, deployer@")},
+			expectedUsers: []string{"alice@example.com", "alice@example.com"},
+		},
+		{
+			name:          "Exclude an operation",
+			exclusions:    auditLogExclusions{Operations: []string{"LIST"}},
+			expectedUsers: []string{"ci-bot@my-proj.iam.gserviceaccount.com", "alice@example.com", "Deployer@example.com"},
+		},
+		{
+			name:          "Exclusions apply after positive filters",
+			operations:    []string{"ACCESS"},
+			exclusions:    auditLogExclusions{Principals: []string{"ci-bot"}, Operations: []string{"LIST"}},
+			expectedUsers: []string{"alice@example.com", "Deployer@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var users []string
+			for _, entry := range filterLogEntries(entries, "", "", tt.operations, tt.exclusions) {
+				users = append(users, entry.ProtoPayload.AuthenticationInfo.PrincipalEmail)
+			}
+			if strings.Join(users, ",") != strings.Join(tt.expectedUsers, ",") {
+				t.Errorf("filterLogEntries() users = %v, expected %v", users, tt.expectedUsers)
+			}
+		})
+	}
+
+	if got := normalizeAuditLogFlagName(nil, "exclude-user"); got != "exclude-principal" {
+		t.Errorf("Expected --exclude-user to normalize to exclude-principal, got %s", got)
+	}
+	if got := normalizeAuditLogFlagName(nil, "exclude-operations"); got != "exclude-operation" {
+		t.Errorf("Expected --exclude-operations to normalize to exclude-operation, got %s", got)
+	}
+	if got := normalizeAuditLogFlagName(nil, "user"); got != "principal" {
+		t.Errorf("Expected --user to normalize to principal, got %s", got)
+	}
+	if got := normalizeAuditLogFlagName(nil, "operations"); got != "operation" {
+		t.Errorf("Expected --operations to normalize to operation, got %s", got)
+	}
+}
+
+// TestAuditLogTableColumns tests the columns shared by the text and Markdown tables
+func TestAuditLogTableColumns(t *testing.T) {
+	var entry AuditLogEntry
+	entry.Timestamp = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	entry.ProtoPayload.MethodName = "google.cloud.secretmanager.v1.SecretManagerService.AccessSecretVersion"
+	entry.ProtoPayload.ResourceName = "projects/test/secrets/db/versions/1"
+	entries := []AuditLogEntry{entry}
+
+	header, rows := auditLogTableColumns(entries, defaultAuditLogFields(false))
+	if strings.Join(header, ",") != "TIMESTAMP,OPERATION,USER,RESOURCE" {
+		t.Errorf("Unexpected header: %v", header)
+	}
+	expected := []string{"2025-03-01 12:00:00", "ACCESS", "system", ".../db/versions/1"}
+	if strings.Join(rows[0], ",") != strings.Join(expected, ",") {
+		t.Errorf("Row = %v, expected %v", rows[0], expected)
+	}
+
+	header, rows = auditLogTableColumns(entries, defaultAuditLogFields(true))
+	if len(header) != 5 || rows[0][2] != "Read the secret value" {
+		t.Errorf("Expected a DESCRIPTION column, got %v %v", header, rows[0])
+	}
+
+	header, rows = auditLogTableColumns(entries, []string{"user", "method"})
+	if strings.Join(header, ",") != "USER,METHOD" || rows[0][1] != entry.ProtoPayload.MethodName {
+		t.Errorf("Expected the chosen columns in order, got %v %v", header, rows[0])
+	}
+	csvRow := auditLogCsvFields(entry, []string{"resource", "user", "description"})
+	if strings.Join(csvRow, ",") != "projects/test/secrets/db/versions/1,,Read the secret value" {
+		t.Errorf("Expected full CSV values, got %v", csvRow)
+	}
+}
+
+// TestParseAuditLogFields tests validating auditlog --fields
+func TestParseAuditLogFields(t *testing.T) {
+	fields, err := parseAuditLogFields("timestamp, User,operation")
+	if err != nil || strings.Join(fields, ",") != "timestamp,user,operation" {
+		t.Errorf("parseAuditLogFields() = %v, %v", fields, err)
+	}
+	for _, value := range []string{"timestamp,severity", "user,user", " , "} {
+		if _, err := parseAuditLogFields(value); err == nil {
+			t.Errorf("Expected an error for %q", value)
+		}
+	}
+	if _, err := parseAuditLogFields("who"); err == nil || !strings.Contains(err.Error(), "valid fields: timestamp, operation, description, method, user, resource") {
+		t.Errorf("Expected the valid fields in the error, got %v", err)
+	}
+}
+
+// TestAuditLogOutputFormat tests validation of --format and its --csv spelling
+func TestAuditLogOutputFormat(t *testing.T) {
+	tests := []struct {
+		format      string
+		csvOutput   bool
+		expected    string
+		expectError bool
+	}{
+		{format: "", expected: ""},
+		{format: "table", expected: ""},
+		{format: "json", expected: "json"},
+		{format: "csv", expected: "csv"},
+		{format: "", csvOutput: true, expected: "csv"},
+		{format: "csv", csvOutput: true, expected: "csv"},
+		{format: "markdown", expected: "markdown"},
+		{format: "json", csvOutput: true, expectError: true},
+		{format: "yaml", expectError: true},
+	}
+
+	for _, tt := range tests {
+		result, err := auditLogOutputFormat(tt.format, tt.csvOutput)
+		if tt.expectError {
+			if err == nil {
+				t.Errorf("auditLogOutputFormat(%q, %v): expected error but got none", tt.format, tt.csvOutput)
+			}
+			continue
+		}
+		if err != nil || result != tt.expected {
+			t.Errorf("auditLogOutputFormat(%q, %v) = %q, %v, expected %q", tt.format, tt.csvOutput, result, err, tt.expected)
+		}
+	}
+
+	// Resource names with commas are quoted, not split across columns
+	var buf bytes.Buffer
+	entry := newTestAuditLogEntry(time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC),
+		"google.cloud.secretmanager.v1.SecretManagerService.AccessSecretVersion", "projects/1/secrets/a,b/versions/1", "alice@example.com")
+	if err := writeAuditLogCsv(csv.NewWriter(&buf), []AuditLogEntry{entry}, auditLogCsvHeader, true); err != nil {
+		t.Fatalf("writeAuditLogCsv() error: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(records) != 2 || records[1][4] != "projects/1/secrets/a,b/versions/1" {
+		t.Errorf("Expected a header and one row keeping the quoted resource, got %v, %v", records, err)
+	}
+}
@@ -1,13 +1,16 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
@@ -37,8 +40,43 @@ func copyToClipboard(text string) error {
 	return clipboard.WriteAll(text)
 }
 
-// getSecretInput handles getting secret value from various sources
-func getSecretInput(data, dataFile, prompt string) (string, error) {
+// dataFlagWarning is printed at most once per run
+var dataFlagWarning sync.Once
+
+// shouldWarnAboutDataFlag reports whether a secret passed with --data was
+// likely typed into an interactive shell that records history
+func shouldWarnAboutDataFlag(dataFlagUsed, interactive bool) bool {
+	return dataFlagUsed && interactive && WarnOnDataFlag()
+}
+
+// warnAboutDataFlag warns once, on stderr, that a value given with --data in
+// an interactive shell is probably saved in shell history
+func warnAboutDataFlag(dataFlagUsed bool) {
+	if !shouldWarnAboutDataFlag(dataFlagUsed, term.IsTerminal(int(os.Stdin.Fd()))) {
+		return
+	}
+	dataFlagWarning.Do(func() {
+		fmt.Fprintln(os.Stderr, "Warning: The secret value passed with --data is likely saved in your shell history")
+		fmt.Fprintln(os.Stderr, "and visible in the process list. Prefer the interactive prompt (omit --data) or")
+		fmt.Fprintln(os.Stderr, "stdin (--data-file -). Set defaults.warnOnDataFlag: false in the config to silence this.")
+	})
+}
+
+// promptMessage returns the custom --prompt text, or fallback when none is
+// given. A separating space is added so typed input never touches the prompt.
+func promptMessage(custom, fallback string) string {
+	if custom == "" {
+		return fallback
+	}
+	if !strings.HasSuffix(custom, " ") {
+		custom += " "
+	}
+	return custom
+}
+
+// getSecretInput handles getting secret value from various sources. The
+// interactive prompt hides input unless echo is set.
+func getSecretInput(data, dataFile, prompt string, echo bool) (string, error) {
 	if data != "" {
 		return data, nil
 	}
@@ -60,6 +98,9 @@ func getSecretInput(data, dataFile, prompt string) (string, error) {
 
 	// Interactive prompt
 	fmt.Print(prompt)
+	if echo {
+		return readEchoedSecretInput(bufio.NewReader(os.Stdin))
+	}
 	byteValue, err := term.ReadPassword(int(syscall.Stdin))
 	if err != nil {
 		return "", fmt.Errorf("failed to read secret value: %w", err)
@@ -68,6 +109,16 @@ func getSecretInput(data, dataFile, prompt string) (string, error) {
 	return string(byteValue), nil
 }
 
+// readEchoedSecretInput reads one visible line of input for --echo, dropping
+// only the line ending so the value matches what the hidden prompt would return
+func readEchoedSecretInput(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read secret value: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
 // getSecretVersionInfo retrieves version metadata for a secret
 func getSecretVersionInfo(secretName, version, project string) (*SecretVersionInfo, error) {
 	// Build gcloud command to get version metadata
@@ -77,7 +128,7 @@ func getSecretVersionInfo(secretName, version, project string) (*SecretVersionIn
 	}
 
 	// Execute gcloud command
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
 	output, err := gcloudCmd.Output()
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
@@ -101,6 +152,7 @@ type SecretInfo struct {
 	CreateTime        time.Time         `json:"createTime"`
 	UpdateTime        time.Time         `json:"updateTime"`
 	LatestVersionTime time.Time         `json:"-"` // populated separately from latest SecretVersion
+	ValueSize         *int              `json:"-"` // populated separately from the latest version payload
 	Labels            map[string]string `json:"labels"`
 	Annotations       map[string]string `json:"annotations"`
 	Etag              string            `json:"etag"`
@@ -120,45 +172,93 @@ type SecretInfo struct {
 	} `json:"topics,omitempty"`
 }
 
-// describeSecretWithVersions provides enhanced secret description with comprehensive information
-func describeSecretWithVersions(secretName, userInputName, project string, showVersions bool) error {
-	// Get basic secret information
-	gcloudArgs := []string{"secrets", "describe", secretName, "--format", "json"}
+// describeSecretWithVersions provides enhanced secret description with comprehensive information.
+// The pieces are fetched concurrently; only the metadata (and the version list
+// with --show-versions) is required, other failures are reported as warnings.
+func describeSecretWithVersions(secretName, userInputName, project string, showVersions, showSize, showAccess bool, versionSort string, color bool) error {
+	parts := fetchDescribeParts(secretName, project, showSize, showAccess, secretManagerDescribeFetchers(secretManager))
+	if parts.SecretErr != nil {
+		return parts.SecretErr
+	}
+
+	if parts.DefaultVersionErr != nil {
+		// Don't fail the whole command if we can't get version info
+		fmt.Printf("Warning: Could not retrieve default version info: %v\n", parts.DefaultVersionErr)
+	}
+	versions := parts.Versions
+	if parts.VersionsErr != nil {
+		if showVersions {
+			return parts.VersionsErr
+		}
+		fmt.Printf("Warning: Could not retrieve version list: %v\n", parts.VersionsErr)
+		versions = nil
+	}
+	if parts.SizeErr != nil {
+		fmt.Printf("Warning: Could not retrieve value size: %v\n", parts.SizeErr)
+	}
+	if parts.PolicyErr != nil {
+		fmt.Printf("Warning: Could not retrieve IAM policy: %v\n", parts.PolicyErr)
+	}
+
+	if err := displayEnhancedSecretInfo(parts.Secret, parts.DefaultVersion, versions, userInputName, showVersions, versionSort, color); err != nil {
+		return err
+	}
+	if parts.Policy != nil {
+		displayDescribeAccess(parts.Policy)
+	}
+	return nil
+}
+
+// getSecretValueSize returns the size in bytes of a secret version's payload
+func getSecretValueSize(secretName, version, project string) (int, error) {
+	gcloudArgs := []string{"secrets", "versions", "access", version, "--secret", secretName}
 	if project != "" {
 		gcloudArgs = append(gcloudArgs, "--project", project)
 	}
 
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
 	output, err := gcloudCmd.Output()
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
-			return formatGcloudError(string(exitError.Stderr))
+			return 0, formatGcloudError(string(exitError.Stderr))
 		}
-		return fmt.Errorf("failed to execute gcloud command: %v", err)
+		return 0, fmt.Errorf("failed to execute gcloud command: %v", err)
 	}
+	return len(output), nil
+}
 
-	var secretInfo SecretInfo
-	if err := json.Unmarshal(output, &secretInfo); err != nil {
-		return fmt.Errorf("failed to parse secret metadata: %w", err)
-	}
+// VersionStats summarizes the versions of a secret by state
+type VersionStats struct {
+	Total     int `json:"total" yaml:"total"`
+	Enabled   int `json:"enabled" yaml:"enabled"`
+	Disabled  int `json:"disabled" yaml:"disabled"`
+	Destroyed int `json:"destroyed" yaml:"destroyed"`
+}
 
-	// Get default version information
-	defaultVersion, err := getDefaultVersionInfo(secretName, project)
-	if err != nil {
-		// Don't fail the whole command if we can't get version info
-		fmt.Printf("Warning: Could not retrieve default version info: %v\n", err)
+// countVersionStates counts versions by state
+func countVersionStates(versions []SecretVersionInfo) VersionStats {
+	stats := VersionStats{Total: len(versions)}
+	for _, version := range versions {
+		switch version.State {
+		case "ENABLED":
+			stats.Enabled++
+		case "DISABLED":
+			stats.Disabled++
+		case "DESTROYED":
+			stats.Destroyed++
+		}
 	}
-
-	return displayEnhancedSecretInfo(secretInfo, defaultVersion, secretName, userInputName, project, showVersions)
+	return stats
 }
 
-// getDefaultVersionInfo retrieves information about the default (latest enabled) version
-func getDefaultVersionInfo(secretName, project string) (*SecretVersionInfo, error) {
-	return getSecretVersionInfo(secretName, "latest", project)
+// formatVersionStats formats version counts as a single summary line value
+func formatVersionStats(stats VersionStats) string {
+	return fmt.Sprintf("%d total (%d enabled, %d disabled, %d destroyed)", stats.Total, stats.Enabled, stats.Disabled, stats.Destroyed)
 }
 
 // displayEnhancedSecretInfo displays comprehensive secret information
-func displayEnhancedSecretInfo(secretInfo SecretInfo, defaultVersion *SecretVersionInfo, secretName, userInputName, project string, showVersions bool) error {
+// versions may be nil when the version list could not be retrieved.
+func displayEnhancedSecretInfo(secretInfo SecretInfo, defaultVersion *SecretVersionInfo, versions []SecretVersionInfo, userInputName string, showVersions bool, versionSort string, color bool) error {
 	// Basic information
 	fmt.Printf("Name: %s\n", secretInfo.Name)
 	fmt.Printf("Created: %s\n", secretInfo.CreateTime.Format(time.RFC3339))
@@ -177,6 +277,12 @@ func displayEnhancedSecretInfo(secretInfo SecretInfo, defaultVersion *SecretVers
 			fmt.Printf("Default Version Destroy Time: %s\n", defaultVersion.DestroyTime.Format(time.RFC3339))
 		}
 	}
+	if secretInfo.ValueSize != nil {
+		fmt.Printf("Default Version Size: %s\n", formatByteSize(*secretInfo.ValueSize))
+	}
+	if versions != nil {
+		fmt.Printf("Versions: %s\n", formatVersionStats(countVersionStates(versions)))
+	}
 
 	// Replication strategy
 	fmt.Printf("Replication: %s\n", getReplicationStrategy(secretInfo.Replication))
@@ -250,7 +356,7 @@ func displayEnhancedSecretInfo(secretInfo SecretInfo, defaultVersion *SecretVers
 
 	if showVersions {
 		fmt.Println("\n--- All Versions ---")
-		return listSecretVersions(secretName, project)
+		displaySecretVersions(versions, versionSort, color)
 	}
 
 	return nil
@@ -307,37 +413,112 @@ func getReplicationStrategy(replication struct {
 	return "Unknown"
 }
 
-// listSecretVersions lists all versions of a secret with their metadata
-func listSecretVersions(secretName, project string) error {
-	// Get version list
+// fetchSecretVersions retrieves metadata for all versions of a secret
+func fetchSecretVersions(secretName, project string) ([]SecretVersionInfo, error) {
 	gcloudArgs := []string{"secrets", "versions", "list", secretName, "--format", "json"}
 	if project != "" {
 		gcloudArgs = append(gcloudArgs, "--project", project)
 	}
 
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
 	output, err := gcloudCmd.Output()
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
-			return fmt.Errorf("gcloud command failed: %s", string(exitError.Stderr))
+			if typed := classifyGcloudFailure(string(exitError.Stderr), secretName, ""); typed != nil {
+				return nil, typed
+			}
+			return nil, fmt.Errorf("gcloud command failed: %s", string(exitError.Stderr))
 		}
-		return fmt.Errorf("failed to execute gcloud command: %w", err)
+		return nil, fmt.Errorf("failed to execute gcloud command: %w", err)
 	}
 
 	var versions []SecretVersionInfo
 	if err := json.Unmarshal(output, &versions); err != nil {
-		return fmt.Errorf("failed to parse version list: %w", err)
+		return nil, fmt.Errorf("failed to parse version list: %w", err)
 	}
 
-	if len(versions) == 0 {
-		fmt.Println("No versions found.")
+	return versions, nil
+}
+
+// listSecretVersions lists all versions of a secret with their metadata,
+// newest first, as text or as a JSON or YAML []SecretVersionInfo
+func listSecretVersions(secretName, project, format string) error {
+	versions, err := secretManager.ListVersions(secretName, project)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" || format == "yaml" {
+		if versions == nil {
+			versions = []SecretVersionInfo{} // print [] rather than null
+		}
+		sortSecretVersions(versions, versionSortNewest)
+		return printStructuredOutput(versions, format)
+	}
+	displaySecretVersions(versions, versionSortNewest, false)
+	return nil
+}
+
+// Orders accepted by describe --sort-versions
+const (
+	versionSortNewest        = "-created"
+	versionSortOldest        = "created"
+	versionSortNumber        = "version"
+	versionSortNumberReverse = "-version"
+)
+
+// validateVersionSort checks a --sort-versions value
+func validateVersionSort(order string) error {
+	switch order {
+	case versionSortNewest, versionSortOldest, versionSortNumber, versionSortNumberReverse:
 		return nil
 	}
+	return fmt.Errorf("invalid --sort-versions value '%s' (use created, -created, version, or -version)", order)
+}
 
-	// Sort versions by creation time (newest first)
-	sort.Slice(versions, func(i, j int) bool {
-		return versions[i].CreateTime.After(versions[j].CreateTime)
+// sortSecretVersions sorts versions by creation time or by version number.
+// Version numbers compare numerically, so 10 sorts after 9.
+func sortSecretVersions(versions []SecretVersionInfo, order string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		switch order {
+		case versionSortOldest:
+			return versions[i].CreateTime.Before(versions[j].CreateTime)
+		case versionSortNumber:
+			return versionNumberLess(versions[i].Name, versions[j].Name)
+		case versionSortNumberReverse:
+			return versionNumberLess(versions[j].Name, versions[i].Name)
+		default:
+			return versions[i].CreateTime.After(versions[j].CreateTime)
+		}
 	})
+}
+
+// versionNumberLess compares two version names by number, falling back to
+// string order for names that are not numeric
+func versionNumberLess(a, b string) bool {
+	numberA, errA := strconv.Atoi(extractVersionNumber(a))
+	numberB, errB := strconv.Atoi(extractVersionNumber(b))
+	if errA == nil && errB == nil {
+		return numberA < numberB
+	}
+	return extractVersionNumber(a) < extractVersionNumber(b)
+}
+
+// versionStateColors highlights versions that can no longer be accessed
+var versionStateColors = map[string]string{
+	"DISABLED":  ansiYellow,
+	"DESTROYED": ansiRed,
+}
+
+// displaySecretVersions prints versions in the given order with their
+// metadata, coloring disabled and destroyed states when color is enabled
+func displaySecretVersions(versions []SecretVersionInfo, order string, color bool) {
+	if len(versions) == 0 {
+		fmt.Println("No versions found.")
+		return
+	}
+
+	sortSecretVersions(versions, order)
 
 	// Display versions
 	for i, version := range versions {
@@ -352,15 +533,13 @@ func listSecretVersions(secretName, project string) error {
 		}
 
 		fmt.Printf("Version: %s\n", versionNumber)
-		fmt.Printf("  State: %s\n", version.State)
+		fmt.Printf("  State: %s\n", colorize(version.State, versionStateColors[version.State], color))
 		fmt.Printf("  Created: %s\n", version.CreateTime.Format(time.RFC3339))
 		if !version.DestroyTime.IsZero() {
 			fmt.Printf("  Destroy Time: %s\n", version.DestroyTime.Format(time.RFC3339))
 		}
 		fmt.Printf("  ETag: %s\n", version.Etag)
 	}
-
-	return nil
 }
 
 // VersionInfo represents a simplified version structure for version management
@@ -373,28 +552,17 @@ type VersionInfo struct {
 
 // getActiveVersions returns all active (enabled) versions of a secret
 func getActiveVersions(secretName, project string) ([]VersionInfo, error) {
-	gcloudArgs := []string{"secrets", "versions", "list", secretName, "--format", "json", "--filter", "state=ENABLED"}
-	if project != "" {
-		gcloudArgs = append(gcloudArgs, "--project", project)
-	}
-
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-	output, err := gcloudCmd.Output()
+	versions, err := secretManager.ListVersions(secretName, project)
 	if err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
-			return nil, fmt.Errorf("gcloud command failed: %s", string(exitError.Stderr))
-		}
-		return nil, fmt.Errorf("failed to execute gcloud command: %w", err)
-	}
-
-	var versions []SecretVersionInfo
-	if err := json.Unmarshal(output, &versions); err != nil {
-		return nil, fmt.Errorf("failed to parse version list: %w", err)
+		return nil, err
 	}
 
 	// Convert to simplified format and extract version numbers
 	var activeVersions []VersionInfo
 	for _, v := range versions {
+		if v.State != "ENABLED" {
+			continue
+		}
 		versionNumber := extractVersionNumber(v.Name)
 		activeVersions = append(activeVersions, VersionInfo{
 			Number:     versionNumber,
@@ -416,7 +584,7 @@ func getActiveVersions(secretName, project string) ([]VersionInfo, error) {
 
 // getDefaultVersion returns the default version number for a secret
 func getDefaultVersion(secretName, project string) (string, error) {
-	versionInfo, err := getSecretVersionInfo(secretName, "latest", project)
+	versionInfo, err := secretManager.GetVersion(secretName, "latest", project)
 	if err != nil {
 		return "", fmt.Errorf("failed to get default version: %w", err)
 	}
@@ -430,7 +598,7 @@ func disableVersion(secretName, versionNumber, project string) error {
 		gcloudArgs = append(gcloudArgs, "--project", project)
 	}
 
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
 	output, err := gcloudCmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("failed to disable version %s: %s", versionNumber, string(output))
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"bufio"
 	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -71,7 +73,7 @@ func TestGetSecretInputFromFile(t *testing.T) {
 		t.Fatalf("Failed to close temp file: %v", err)
 	}
 
-	result, err := getSecretInput("", tempFile.Name(), "")
+	result, err := getSecretInput("", tempFile.Name(), "", false)
 	if err != nil {
 		t.Fatalf("Unexpected error reading file input: %v", err)
 	}
@@ -98,7 +100,7 @@ func TestGetSecretInputFromStdinDash(t *testing.T) {
 		t.Fatalf("Failed to close stdin writer: %v", err)
 	}
 
-	result, err := getSecretInput("", "-", "")
+	result, err := getSecretInput("", "-", "", false)
 	if err != nil {
 		t.Fatalf("Unexpected error reading stdin input: %v", err)
 	}
@@ -107,6 +109,54 @@ func TestGetSecretInputFromStdinDash(t *testing.T) {
 	}
 }
 
+// TestReadEchoedSecretInput tests that --echo input drops only the line ending
+func TestReadEchoedSecretInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "unix newline", input: "value\nignored\n", expected: "value"},
+		{name: "windows newline", input: "value\r\n", expected: "value"},
+		{name: "surrounding spaces kept", input: "  spaced value  \n", expected: "  spaced value  "},
+		{name: "no newline at EOF", input: "value", expected: "value"},
+		{name: "empty input", input: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := readEchoedSecretInput(bufio.NewReader(strings.NewReader(tt.input)))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("readEchoedSecretInput() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestPromptMessage tests the --prompt override of the interactive prompt
+func TestPromptMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		custom   string
+		expected string
+	}{
+		{name: "default", custom: "", expected: "Enter secret value: "},
+		{name: "custom gets a separating space", custom: "Paste the production DB password:", expected: "Paste the production DB password: "},
+		{name: "custom with trailing space kept", custom: "Token> ", expected: "Token> "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := promptMessage(tt.custom, "Enter secret value: "); result != tt.expected {
+				t.Errorf("promptMessage() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
 // TestGetReplicationStrategy tests replication strategy detection
 func TestGetReplicationStrategy(t *testing.T) {
 	tests := []struct {
@@ -559,7 +609,7 @@ func TestGetSecretInput(t *testing.T) {
 				return
 			}
 
-			result, err := getSecretInput(tt.data, tt.dataFile, tt.prompt)
+			result, err := getSecretInput(tt.data, tt.dataFile, tt.prompt, false)
 
 			if tt.wantErr && err == nil {
 				t.Errorf("Expected error but got none")
@@ -577,3 +627,111 @@ func TestGetSecretInput(t *testing.T) {
 		})
 	}
 }
+
+// TestCountVersionStates tests counting versions by state for describe
+func TestCountVersionStates(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []SecretVersionInfo
+		expected VersionStats
+		summary  string
+	}{
+		{
+			name:     "No versions",
+			versions: nil,
+			expected: VersionStats{},
+			summary:  "0 total (0 enabled, 0 disabled, 0 destroyed)",
+		},
+		{
+			name: "Mixed states",
+			versions: []SecretVersionInfo{
+				{State: "ENABLED"},
+				{State: "ENABLED"},
+				{State: "DISABLED"},
+				{State: "DESTROYED"},
+			},
+			expected: VersionStats{Total: 4, Enabled: 2, Disabled: 1, Destroyed: 1},
+			summary:  "4 total (2 enabled, 1 disabled, 1 destroyed)",
+		},
+		{
+			name: "Unknown state counts toward total only",
+			versions: []SecretVersionInfo{
+				{State: "ENABLED"},
+				{State: "STATE_UNSPECIFIED"},
+			},
+			expected: VersionStats{Total: 2, Enabled: 1},
+			summary:  "2 total (1 enabled, 0 disabled, 0 destroyed)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := countVersionStates(tt.versions)
+			if stats != tt.expected {
+				t.Errorf("countVersionStates() = %+v, expected %+v", stats, tt.expected)
+			}
+			if got := formatVersionStats(stats); got != tt.summary {
+				t.Errorf("formatVersionStats() = %q, expected %q", got, tt.summary)
+			}
+		})
+	}
+}
+
+// TestSortSecretVersions tests the --sort-versions orders
+func TestSortSecretVersions(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newVersions := func() []SecretVersionInfo {
+		return []SecretVersionInfo{
+			{Name: "projects/p/secrets/s/versions/9", CreateTime: base.Add(2 * time.Hour)},
+			{Name: "projects/p/secrets/s/versions/10", CreateTime: base.Add(1 * time.Hour)},
+			{Name: "projects/p/secrets/s/versions/2", CreateTime: base.Add(3 * time.Hour)},
+		}
+	}
+
+	tests := []struct {
+		order    string
+		expected []string
+	}{
+		{versionSortNewest, []string{"2", "9", "10"}},
+		{versionSortOldest, []string{"10", "9", "2"}},
+		{versionSortNumber, []string{"2", "9", "10"}},
+		{versionSortNumberReverse, []string{"10", "9", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			versions := newVersions()
+			sortSecretVersions(versions, tt.order)
+			var got []string
+			for _, version := range versions {
+				got = append(got, extractVersionNumber(version.Name))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("sortSecretVersions(%s) = %v, expected %v", tt.order, got, tt.expected)
+			}
+		})
+	}
+
+	if err := validateVersionSort("newest"); err == nil {
+		t.Error("Expected error for unknown sort order")
+	}
+}
+
+// TestColorize tests that color codes are only added when enabled
+func TestColorize(t *testing.T) {
+	if got := colorize("DESTROYED", versionStateColors["DESTROYED"], true); got != ansiRed+"DESTROYED"+ansiReset {
+		t.Errorf("Expected red DESTROYED, got %q", got)
+	}
+	if got := colorize("DESTROYED", versionStateColors["DESTROYED"], false); got != "DESTROYED" {
+		t.Errorf("Expected plain text when color is disabled, got %q", got)
+	}
+	if got := colorize("ENABLED", versionStateColors["ENABLED"], true); got != "ENABLED" {
+		t.Errorf("Expected enabled state to stay uncolored, got %q", got)
+	}
+	if _, err := colorEnabled("sometimes"); err == nil {
+		t.Error("Expected error for invalid --color value")
+	}
+	if enabled, _ := colorEnabled("never"); enabled {
+		t.Error("Expected --color never to disable color")
+	}
+}
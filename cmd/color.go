@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ANSI escape sequences used for colored output
+const (
+	ansiReset  = "\033[0m"
+	ansiRed    = "\033[31m"
+	ansiYellow = "\033[33m"
+)
+
+// colorEnabled resolves a --color value: "always" and "never" force the
+// choice, and "auto" (or empty) colors only when stdout is a terminal and
+// NO_COLOR is not set
+func colorEnabled(mode string) (bool, error) {
+	switch mode {
+	case "", "auto":
+		if os.Getenv("NO_COLOR") != "" {
+			return false, nil
+		}
+		return term.IsTerminal(int(os.Stdout.Fd())), nil
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid --color value '%s' (use auto, always, or never)", mode)
+	}
+}
+
+// colorize wraps text in an ANSI color when color is enabled
+func colorize(text, color string, enabled bool) string {
+	if !enabled || color == "" {
+		return text
+	}
+	return color + text + ansiReset
+}
@@ -12,11 +12,12 @@ import (
 
 // Config represents the gsecutil configuration file structure
 type Config struct {
-	Project     string           `yaml:"project,omitempty"`
-	Prefix      string           `yaml:"prefix,omitempty"`
-	List        ListConfig       `yaml:"list,omitempty"`
-	Credentials []CredentialInfo `yaml:"credentials,omitempty"`
-	Defaults    DefaultConfig    `yaml:"defaults,omitempty"`
+	Project         string           `yaml:"project,omitempty"`
+	Prefix          string           `yaml:"prefix,omitempty"`
+	PrefixSeparator string           `yaml:"prefixSeparator,omitempty"`
+	List            ListConfig       `yaml:"list,omitempty"`
+	Credentials     []CredentialInfo `yaml:"credentials,omitempty"`
+	Defaults        DefaultConfig    `yaml:"defaults,omitempty"`
 }
 
 // ListConfig contains configuration for the list command
@@ -33,14 +34,54 @@ type CredentialInfo struct {
 
 // DefaultConfig contains default settings for new secrets
 type DefaultConfig struct {
-	Labels map[string]string `yaml:"labels,omitempty"`
+	Labels                  map[string]string `yaml:"labels,omitempty"`
+	RequireNameConfirmation bool              `yaml:"requireNameConfirmation,omitempty"`
+	MaxSecretSize           int               `yaml:"maxSecretSize,omitempty"`
+	WarnOnDataFlag          *bool             `yaml:"warnOnDataFlag,omitempty"`
+}
+
+// WarnOnDataFlag reports whether --data should trigger the shell history
+// warning. It defaults to true; set defaults.warnOnDataFlag: false to silence it.
+func WarnOnDataFlag() bool {
+	if warn := GetConfig().Defaults.WarnOnDataFlag; warn != nil {
+		return *warn
+	}
+	return true
+}
+
+// defaultMaxSecretSize is the Secret Manager payload limit (64 KiB)
+const defaultMaxSecretSize = 64 * 1024
+
+// GetMaxSecretSize returns the maximum secret value size in bytes. A positive
+// flag value wins, then defaults.maxSecretSize, then the Secret Manager limit.
+func GetMaxSecretSize(flagValue int) int {
+	if flagValue > 0 {
+		return flagValue
+	}
+	if size := GetConfig().Defaults.MaxSecretSize; size > 0 {
+		return size
+	}
+	return defaultMaxSecretSize
 }
 
 var (
 	globalConfig   *Config
 	configFilePath string
+	configDisabled bool // set by --no-config
 )
 
+// errConfigDisabled is returned by commands that would write the configuration file under --no-config
+var errConfigDisabled = fmt.Errorf("the configuration file is disabled by --no-config")
+
+// DisableConfig makes GetConfig return an empty configuration for the rest
+// of the process: no project, prefix, credentials, or defaults come from a
+// configuration file. Flags and environment variables still apply.
+func DisableConfig() {
+	configDisabled = true
+	globalConfig = &Config{}
+	configFilePath = ""
+}
+
 // LoadConfig loads configuration from the specified file or default location
 func LoadConfig(customPath string) (*Config, error) {
 	var configPath string
@@ -73,6 +114,9 @@ func LoadConfig(customPath string) (*Config, error) {
 	if err := validatePrefix(config.Prefix); err != nil {
 		return nil, fmt.Errorf("config file %q: %w", configPath, err)
 	}
+	if err := validatePrefixSeparator(config.Prefix, config.PrefixSeparator); err != nil {
+		return nil, fmt.Errorf("config file %q: %w", configPath, err)
+	}
 
 	// Store the config path for reference
 	configFilePath = configPath
@@ -120,6 +164,9 @@ func fileExists(path string) bool {
 
 // GetConfig returns the global configuration, loading it if not already loaded
 func GetConfig() *Config {
+	if configDisabled {
+		return &Config{}
+	}
 	if globalConfig == nil {
 		config, err := LoadConfig("")
 		if err != nil {
@@ -169,6 +216,20 @@ func GetProject(cliProject string) string {
 	return ""
 }
 
+// resolveProject returns the project a command runs against: GetProject's
+// sources, then the gcloud default project. Commands call it before any
+// Secret Manager call so a missing project fails with one clear message
+// instead of an obscure gcloud error.
+func resolveProject(cliProject string) (string, error) {
+	if project := GetProject(cliProject); project != "" {
+		return project, nil
+	}
+	if project := secretManager.DefaultProject(); project != "" {
+		return project, nil
+	}
+	return "", missingProjectIDError()
+}
+
 // validatePrefix checks that a prefix contains only characters valid in GCP secret names.
 // GCP secret names allow only letters, digits, hyphens, and underscores.
 func validatePrefix(prefix string) error {
@@ -183,10 +244,47 @@ func validatePrefix(prefix string) error {
 	return nil
 }
 
-// GetPrefix returns the secret name prefix from configuration
+// validatePrefixSeparator checks prefixSeparator: it uses the same characters
+// as the prefix and is only meaningful together with one
+func validatePrefixSeparator(prefix, separator string) error {
+	if separator == "" {
+		return nil
+	}
+	if prefix == "" {
+		return fmt.Errorf("prefixSeparator %q requires prefix to be set", separator)
+	}
+	for _, c := range separator {
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-' || c == '_') {
+			return fmt.Errorf("prefixSeparator %q contains invalid character %q: only letters, digits, hyphens (-), and underscores (_) are allowed", separator, string(c))
+		}
+	}
+	return nil
+}
+
+// effectivePrefix returns prefix followed by separator, unless the prefix
+// already ends with it ("team" and "team-" both become "team-" with "-")
+func effectivePrefix(prefix, separator string) string {
+	if prefix == "" || separator == "" || strings.HasSuffix(prefix, separator) {
+		return prefix
+	}
+	return prefix + separator
+}
+
+// GetPrefix returns the secret name prefix from configuration, including
+// prefixSeparator when one is configured
 func GetPrefix() string {
 	config := GetConfig()
-	return config.Prefix
+	return effectivePrefix(config.Prefix, config.PrefixSeparator)
+}
+
+// hasConfiguredPrefix reports whether secretName carries the configured
+// prefix. With prefixSeparator set, a name equal to the prefix does not count.
+func hasConfiguredPrefix(secretName string) bool {
+	prefix := GetPrefix()
+	if prefix == "" || !strings.HasPrefix(secretName, prefix) {
+		return false
+	}
+	return GetConfig().PrefixSeparator == "" || len(secretName) > len(prefix)
 }
 
 // GetCredentialInfo returns metadata for a specific credential
@@ -228,11 +326,10 @@ func HasCredentialsConfig() bool {
 
 // FilterSecretsByPrefix filters secret names based on configured prefix
 func FilterSecretsByPrefix(secretName string) bool {
-	prefix := GetPrefix()
-	if prefix == "" {
+	if GetPrefix() == "" {
 		return true // No prefix filtering
 	}
-	return strings.HasPrefix(secretName, prefix)
+	return hasConfiguredPrefix(secretName)
 }
 
 // AddPrefixToSecretName adds the configured prefix to a secret name if not already present
@@ -243,13 +340,37 @@ func AddPrefixToSecretName(secretName string) string {
 	}
 
 	// If already has prefix, return as-is
-	if strings.HasPrefix(secretName, prefix) {
+	if hasConfiguredPrefix(secretName) {
 		return secretName
 	}
 
 	return prefix + secretName
 }
 
+// maxSecretNameLength is the longest secret ID Secret Manager accepts
+const maxSecretNameLength = 255
+
+// validateSecretName checks a full secret name (after the prefix is added)
+// against the Secret Manager naming rules: 1 to 255 letters, digits, hyphens,
+// and underscores
+func validateSecretName(secretName string) error {
+	if secretName == "" {
+		return fmt.Errorf("secret name cannot be empty")
+	}
+	for _, c := range secretName {
+		if !((c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-' || c == '_') {
+			return fmt.Errorf("invalid secret name '%s': contains %q, but only letters, digits, hyphens (-), and underscores (_) are allowed", secretName, string(c))
+		}
+	}
+	if len(secretName) > maxSecretNameLength {
+		if prefix := GetPrefix(); prefix != "" && strings.HasPrefix(secretName, prefix) {
+			return fmt.Errorf("secret name '%s' is %d characters long including the prefix '%s'; the maximum is %d", secretName, len(secretName), prefix, maxSecretNameLength)
+		}
+		return fmt.Errorf("secret name '%s' is %d characters long; the maximum is %d", secretName, len(secretName), maxSecretNameLength)
+	}
+	return nil
+}
+
 // FilterCredentialsByAttributes filters credentials based on attribute values
 func FilterCredentialsByAttributes(filters map[string]string) []CredentialInfo {
 	config := GetConfig()
@@ -290,6 +411,27 @@ func FilterCredentialsByAttributes(filters map[string]string) []CredentialInfo {
 	return filtered
 }
 
+// reservedCredentialFields are the credential keys stored in CredentialInfo
+// fields rather than in the inline Attributes map
+var reservedCredentialFields = []string{"name", "title"}
+
+// reservedAttributeCollisions returns the attributes of a credential whose
+// names match a reserved field, ignoring case, mapped to that field. YAML
+// decoding never puts an exact "name" or "title" key in Attributes, but case
+// variants such as "Title" are kept there and would be ambiguous, and an exact
+// key added in code makes the credential impossible to marshal.
+func reservedAttributeCollisions(cred CredentialInfo) map[string]string {
+	collisions := make(map[string]string)
+	for key := range cred.Attributes {
+		for _, field := range reservedCredentialFields {
+			if strings.EqualFold(key, field) {
+				collisions[key] = field
+			}
+		}
+	}
+	return collisions
+}
+
 // GetAttributeValue returns the value of a specific attribute for a credential
 func GetAttributeValue(cred *CredentialInfo, attribute string) string {
 	if cred == nil {
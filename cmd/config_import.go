@@ -81,7 +81,7 @@ func runConfigImport(cmd *cobra.Command, args []string) error {
 	}
 
 	// Write configuration to output path
-	if err := os.WriteFile(outputPath, sourceData, 0644); err != nil {
+	if err := atomicWriteFile(outputPath, sourceData, 0644); err != nil {
 		return fmt.Errorf("failed to write configuration file: %w", err)
 	}
 
@@ -114,6 +114,13 @@ func validateConfig(config *Config) error {
 	if err := validatePrefix(config.Prefix); err != nil {
 		return err
 	}
+	if err := validatePrefixSeparator(config.Prefix, config.PrefixSeparator); err != nil {
+		return err
+	}
+
+	if config.Defaults.MaxSecretSize < 0 {
+		return fmt.Errorf("defaults.maxSecretSize must not be negative, got %d", config.Defaults.MaxSecretSize)
+	}
 
 	// Validate credentials
 	seenNames := make(map[string]bool)
@@ -93,7 +93,7 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 
 	// Try to detect current gcloud project
 	var detectedProject string
-	if output, err := exec.Command("gcloud", "config", "get-value", "project").Output(); err == nil {
+	if output, err := exec.Command(gcloudBinary(), "config", "get-value", "project").Output(); err == nil {
 		detectedProject = strings.TrimSpace(string(output))
 		if detectedProject == "(unset)" {
 			detectedProject = ""
@@ -198,7 +198,7 @@ func runConfigInit(cmd *cobra.Command, args []string) error {
 	}
 
 	// Write to file
-	if err := os.WriteFile(outputPath, yamlData, 0644); err != nil {
+	if err := atomicWriteFile(outputPath, yamlData, 0644); err != nil {
 		return fmt.Errorf("failed to write configuration file: %w", err)
 	}
 
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var configPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove credential entries whose secrets no longer exist",
+	Long: `Remove credential entries from the configuration file whose secrets no
+longer exist in Google Secret Manager.
+
+The secrets of the resolved project are listed (honoring the configured
+prefix, so entries are matched as prefix + name) and every credential entry
+without a matching secret is reported. After confirmation the orphaned entries
+are removed and the configuration file is saved. Use --dry-run to only report
+them, and --force to skip the confirmation prompt.
+
+A project must be resolvable (from --project, the configuration, or gcloud)
+and gcloud must be authenticated, since removing entries based on a failed or
+empty listing of the wrong project would lose configuration.`,
+	Example: `  gsecutil config prune --dry-run
+  gsecutil config prune
+  gsecutil config prune --project my-project --force`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, _ := cmd.Flags().GetString("project")
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		force, _ := cmd.Flags().GetBool("force")
+
+		config, err := loadOrCreateConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		if len(config.Credentials) == 0 {
+			fmt.Println("No credential entries in the configuration file; nothing to prune.")
+			return nil
+		}
+
+		prefix := GetPrefix()
+		existing, err := secretManager.ListNames(project, prefix)
+		if err != nil {
+			return fmt.Errorf("failed to list secrets in project '%s': %w", project, err)
+		}
+
+		orphaned := findOrphanedCredentials(config.Credentials, existing, prefix)
+		if len(orphaned) == 0 {
+			fmt.Printf("All %d credential entries match a secret in project '%s'.\n", len(config.Credentials), project)
+			return nil
+		}
+
+		fmt.Printf("Credential entries without a secret in project '%s' (%d of %d):\n", project, len(orphaned), len(config.Credentials))
+		for _, cred := range orphaned {
+			if cred.Title != "" {
+				fmt.Printf("  - %s (%s)\n", cred.Name, cred.Title)
+			} else {
+				fmt.Printf("  - %s\n", cred.Name)
+			}
+		}
+
+		if dryRun {
+			fmt.Println("\nDry run: the configuration file was not changed.")
+			return nil
+		}
+
+		if !force {
+			fmt.Printf("\nRemove these %d entries from %s? (y/N): ", len(orphaned), resolveConfigSavePath())
+			response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("failed to read confirmation input: %w", err)
+			}
+			response = strings.TrimSpace(strings.ToLower(response))
+			if response != "y" && response != "yes" {
+				fmt.Println("Prune cancelled.")
+				return nil
+			}
+		}
+
+		config.Credentials = removeCredentials(config.Credentials, orphaned)
+		if err := saveConfig(config); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+
+		fmt.Printf("✓ Removed %d credential entries\n", len(orphaned))
+		fmt.Printf("  Configuration file: %s\n", resolveConfigSavePath())
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configPruneCmd)
+	configPruneCmd.Flags().Bool("dry-run", false, "Show the entries that would be removed without changing the configuration file")
+	configPruneCmd.Flags().BoolP("force", "f", false, "Remove the entries without a confirmation prompt")
+}
+
+// findOrphanedCredentials returns the credentials whose secret (prefix + name)
+// is not in existing, in config order
+func findOrphanedCredentials(credentials []CredentialInfo, existing map[string]bool, prefix string) []CredentialInfo {
+	var orphaned []CredentialInfo
+	for _, cred := range credentials {
+		if !existing[prefix+cred.Name] {
+			orphaned = append(orphaned, cred)
+		}
+	}
+	return orphaned
+}
+
+// removeCredentials returns credentials without the entries named in removed
+func removeCredentials(credentials []CredentialInfo, removed []CredentialInfo) []CredentialInfo {
+	drop := make(map[string]bool, len(removed))
+	for _, cred := range removed {
+		drop[cred.Name] = true
+	}
+	kept := make([]CredentialInfo, 0, len(credentials))
+	for _, cred := range credentials {
+		if !drop[cred.Name] {
+			kept = append(kept, cred)
+		}
+	}
+	return kept
+}
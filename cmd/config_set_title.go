@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -15,11 +17,28 @@ var configSetTitleCmd = &cobra.Command{
 This updates the local configuration file only and does not affect the
 secret stored in Google Secret Manager.
 
+With --from-file, titles for many secrets are read from a CSV file with
+name and title columns (use - to read from stdin). All entries are updated
+in one pass and the configuration file is saved once. Rows with an empty
+name or title are skipped.
+
 Examples:
   gsecutil config set-title database-password "Production Database Password"
-  gsecutil config set-title api-key "External API Key"`,
-	Args: cobra.ExactArgs(2),
+  gsecutil config set-title api-key "External API Key"
+  gsecutil config set-title --from-file titles.csv
+  generate-titles | gsecutil config set-title --from-file -`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if fromFile, _ := cmd.Flags().GetString("from-file"); fromFile != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		fromFile, _ := cmd.Flags().GetString("from-file")
+		if fromFile != "" {
+			return setTitlesFromFile(fromFile)
+		}
+
 		secretName := args[0]
 		title := args[1]
 
@@ -58,4 +77,113 @@ Examples:
 
 func init() {
 	configCmd.AddCommand(configSetTitleCmd)
+	configSetTitleCmd.Flags().String("from-file", "", "Read name,title rows from this CSV file (- for stdin) and update them all at once")
+}
+
+// titleUpdateStats counts the outcome of 'config set-title --from-file'
+type titleUpdateStats struct {
+	created   int
+	updated   int
+	unchanged int
+	skipped   int
+}
+
+// setTitlesFromFile applies the titles in a name,title CSV and saves the config once
+func setTitlesFromFile(path string) error {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open CSV file: %w", err)
+		}
+		defer file.Close()
+		r = file
+	}
+
+	records, header, err := readCsvData(r)
+	if err != nil {
+		return err
+	}
+
+	config, err := loadOrCreateConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	stats, err := applyTitlesFromRecords(config, header, records, GetPrefix())
+	if err != nil {
+		return err
+	}
+
+	configPath := resolveConfigSavePath()
+	if stats.created+stats.updated > 0 {
+		if err := saveConfig(config); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("Set Title Summary:")
+	fmt.Printf("  Created: %d\n", stats.created)
+	fmt.Printf("  Updated: %d\n", stats.updated)
+	fmt.Printf("  Unchanged: %d\n", stats.unchanged)
+	fmt.Printf("  Skipped: %d\n", stats.skipped)
+	fmt.Printf("  Configuration file: %s\n", configPath)
+	return nil
+}
+
+// applyTitlesFromRecords updates config titles from CSV records with name and
+// title columns (matched case-insensitively; other columns are ignored). Names
+// are stored without the prefix, like the single-secret form.
+func applyTitlesFromRecords(config *Config, header []string, records [][]string, prefix string) (titleUpdateStats, error) {
+	var stats titleUpdateStats
+	nameIdx, titleIdx := -1, -1
+	for i, col := range header {
+		switch strings.ToLower(strings.TrimSpace(col)) {
+		case "name":
+			nameIdx = i
+		case "title":
+			titleIdx = i
+		}
+	}
+	if nameIdx < 0 || titleIdx < 0 {
+		return stats, fmt.Errorf("CSV must have 'name' and 'title' columns")
+	}
+
+	for i, record := range records {
+		if len(record) != len(header) {
+			fmt.Printf("Warning: Row %d has %d columns, expected %d. Skipping.\n", i+2, len(record), len(header))
+			stats.skipped++
+			continue
+		}
+		name := strings.TrimPrefix(strings.TrimSpace(record[nameIdx]), prefix)
+		title := strings.TrimSpace(record[titleIdx])
+		if name == "" || title == "" {
+			fmt.Printf("Warning: Row %d has an empty name or title. Skipping.\n", i+2)
+			stats.skipped++
+			continue
+		}
+
+		switch existing := findCredential(config, name); {
+		case existing == nil:
+			stats.created++
+		case existing.Title == title:
+			stats.unchanged++
+			continue
+		default:
+			stats.updated++
+		}
+		updateConfigWithMetadata(config, name, title, nil)
+	}
+	return stats, nil
+}
+
+// findCredential returns the config entry with the given bare name, or nil
+func findCredential(config *Config, name string) *CredentialInfo {
+	for i := range config.Credentials {
+		if config.Credentials[i].Name == name {
+			return &config.Credentials[i]
+		}
+	}
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -22,24 +23,45 @@ credentials count.
 
 Use --show-credentials to display detailed credentials information in table format.
 
+Use --effective to show the settings gsecutil would actually use for other
+commands, after applying --config, --project, the config file, GSECUTIL_PROJECT,
+and gcloud defaults, each annotated with its source.
+
+Both views end with the environment variables gsecutil reads (GSECUTIL_PROJECT,
+GSECUTIL_GCLOUD, NO_COLOR), showing which are set and whether a flag or the
+config file overrides them.
+
 If no file path is provided, shows the default configuration file.`,
 	Example: `  gsecutil config show
   gsecutil config show /path/to/config.yaml
-  gsecutil config show --show-credentials     # Show credentials table`,
+  gsecutil config show --show-credentials     # Show credentials table
+  gsecutil config show --effective            # Show resolved settings and their sources`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runConfigShow,
 }
 
 var (
 	configShowCredentials bool
+	configShowEffective   bool
 )
 
 func init() {
 	configCmd.AddCommand(configShowCmd)
 	configShowCmd.Flags().BoolVarP(&configShowCredentials, "show-credentials", "c", false, "Show credentials table")
+	configShowCmd.Flags().BoolVar(&configShowEffective, "effective", false, "Show the resolved settings gsecutil will use, with their sources")
 }
 
 func runConfigShow(cmd *cobra.Command, args []string) error {
+	if configShowEffective {
+		if len(args) > 0 {
+			return fmt.Errorf("--effective shows the configuration gsecutil would load; use --config to select a file")
+		}
+		displayEffectiveSettings("Effective settings:", collectEffectiveSettings(cmd))
+		fmt.Println()
+		displayEffectiveSettings("Environment variables:", collectEnvironmentSettings(cmd, GetConfig()))
+		return nil
+	}
+
 	// Determine config file path
 	var configPath string
 	if len(args) > 0 {
@@ -121,6 +143,13 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	fmt.Printf("🔐 Credentials: %d entries\n", len(config.Credentials))
 	fmt.Println()
 
+	// Environment variables
+	fmt.Println("🌐 Environment Variables:")
+	for _, setting := range collectEnvironmentSettings(cmd, &config) {
+		fmt.Printf("   %s: %s (%s)\n", setting.Name, setting.Value, setting.Source)
+	}
+	fmt.Println()
+
 	// Show credentials table if requested
 	if configShowCredentials && len(config.Credentials) > 0 {
 		fmt.Println("═══════════════════════════════════════════════════════════")
@@ -134,6 +163,164 @@ func runConfigShow(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// effectiveSetting is a resolved setting and where its value came from
+type effectiveSetting struct {
+	Name   string
+	Value  string
+	Source string
+}
+
+// collectEffectiveSettings resolves the settings other commands would use
+func collectEffectiveSettings(cmd *cobra.Command) []effectiveSetting {
+	config := GetConfig()
+	var settings []effectiveSetting
+
+	// Configuration file
+	configFlag, _ := cmd.Flags().GetString("config")
+	switch {
+	case configDisabled:
+		settings = append(settings, effectiveSetting{"Config file", "(disabled)", "--no-config"})
+	case configFilePath == "":
+		settings = append(settings, effectiveSetting{"Config file", "(none loaded)", "built-in defaults"})
+	case configFlag != "":
+		settings = append(settings, effectiveSetting{"Config file", configFilePath, "from --config flag"})
+	default:
+		source := "from default location"
+		if cwd, err := os.Getwd(); err == nil && configFilePath == filepath.Join(cwd, "gsecutil.conf") {
+			source = "from current directory"
+		}
+		settings = append(settings, effectiveSetting{"Config file", configFilePath, source})
+	}
+
+	// Project
+	if projectID, source := getProjectWithSource(cmd, config); projectID != "" {
+		settings = append(settings, effectiveSetting{"Project", projectID, source})
+	} else {
+		settings = append(settings, effectiveSetting{"Project", "(not set)", "no flag, config, env, or gcloud default"})
+	}
+
+	// Prefix
+	if config.Prefix != "" {
+		settings = append(settings, effectiveSetting{"Prefix", effectivePrefix(config.Prefix, config.PrefixSeparator), "from config file"})
+		if config.PrefixSeparator != "" {
+			settings = append(settings, effectiveSetting{"Prefix separator", config.PrefixSeparator, "from config file (a name must follow the prefix)"})
+		}
+	} else {
+		settings = append(settings, effectiveSetting{"Prefix", "(none)", "not set"})
+	}
+
+	// List attributes
+	if attrs := GetListAttributes(); len(attrs) > 0 {
+		source := "default (credentials are configured)"
+		if len(config.List.Attributes) > 0 {
+			source = "from config file"
+		}
+		settings = append(settings, effectiveSetting{"List attributes", strings.Join(attrs, ", "), source})
+	} else {
+		settings = append(settings, effectiveSetting{"List attributes", "(none)", "default (no credentials configured)"})
+	}
+
+	// Default labels
+	if len(config.Defaults.Labels) > 0 {
+		keys := make([]string, 0, len(config.Defaults.Labels))
+		for key := range config.Defaults.Labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		labels := make([]string, 0, len(keys))
+		for _, key := range keys {
+			labels = append(labels, key+"="+config.Defaults.Labels[key])
+		}
+		settings = append(settings, effectiveSetting{"Default labels", strings.Join(labels, ", "), "from config file"})
+	} else {
+		settings = append(settings, effectiveSetting{"Default labels", "(none)", "not set"})
+	}
+
+	// Delete name confirmation
+	if config.Defaults.RequireNameConfirmation {
+		settings = append(settings, effectiveSetting{"Delete name confirmation", "required", "from config file"})
+	} else {
+		settings = append(settings, effectiveSetting{"Delete name confirmation", "only with --confirm-name", "default"})
+	}
+
+	// Shell history warning for --data
+	if config.Defaults.WarnOnDataFlag != nil && !*config.Defaults.WarnOnDataFlag {
+		settings = append(settings, effectiveSetting{"Warn on --data", "disabled", "from config file"})
+	} else if config.Defaults.WarnOnDataFlag != nil {
+		settings = append(settings, effectiveSetting{"Warn on --data", "enabled", "from config file"})
+	} else {
+		settings = append(settings, effectiveSetting{"Warn on --data", "enabled", "default"})
+	}
+
+	// Maximum secret value size
+	if config.Defaults.MaxSecretSize > 0 {
+		settings = append(settings, effectiveSetting{"Max secret size", fmt.Sprintf("%d bytes", config.Defaults.MaxSecretSize), "from config file"})
+	} else {
+		settings = append(settings, effectiveSetting{"Max secret size", fmt.Sprintf("%d bytes", defaultMaxSecretSize), "Secret Manager limit"})
+	}
+
+	return settings
+}
+
+// displayEffectiveSettings prints resolved settings under title as an aligned table
+func displayEffectiveSettings(title string, settings []effectiveSetting) {
+	nameWidth := 0
+	valueWidth := 0
+	for _, setting := range settings {
+		if w := displayWidth(setting.Name); w > nameWidth {
+			nameWidth = w
+		}
+		if w := displayWidth(setting.Value); w > valueWidth {
+			valueWidth = w
+		}
+	}
+
+	fmt.Println(title)
+	fmt.Println()
+	for _, setting := range settings {
+		fmt.Printf("  %s  %s  (%s)\n", padRight(setting.Name+":", nameWidth+1), padRight(setting.Value, valueWidth), setting.Source)
+	}
+}
+
+// gsecutilEnvironmentVariables are the environment variables gsecutil reads,
+// with what each one controls
+var gsecutilEnvironmentVariables = []struct {
+	Name    string
+	Purpose string
+}{
+	{"GSECUTIL_PROJECT", "project when neither --project nor the config file sets one"},
+	{"GSECUTIL_GCLOUD", "gcloud executable to run"},
+	{"NO_COLOR", "disables colored output"},
+}
+
+// collectEnvironmentSettings reports which gsecutil environment variables are
+// set and whether they affect resolution or are overridden
+func collectEnvironmentSettings(cmd *cobra.Command, config *Config) []effectiveSetting {
+	settings := make([]effectiveSetting, 0, len(gsecutilEnvironmentVariables))
+	for _, variable := range gsecutilEnvironmentVariables {
+		value, ok := os.LookupEnv(variable.Name)
+		if !ok {
+			settings = append(settings, effectiveSetting{variable.Name, "(not set)", variable.Purpose})
+			continue
+		}
+
+		source := "in effect: " + variable.Purpose
+		if variable.Name == "GSECUTIL_PROJECT" {
+			if projectFlag, _ := cmd.Flags().GetString("project"); projectFlag != "" {
+				source = "overridden by --project flag"
+			} else if config.Project != "" {
+				source = "overridden by config file"
+			} else if value == "" {
+				source = "empty, ignored"
+			}
+		} else if value == "" {
+			source = "empty, ignored"
+		}
+		settings = append(settings, effectiveSetting{variable.Name, value, source})
+	}
+	return settings
+}
+
 // getProjectWithSource returns the project ID and its source
 func getProjectWithSource(cmd *cobra.Command, config *Config) (string, string) {
 	// 1. Check --project flag
@@ -152,7 +339,7 @@ func getProjectWithSource(cmd *cobra.Command, config *Config) (string, string) {
 	}
 
 	// 4. Check gcloud default
-	cmd2 := exec.Command("gcloud", "config", "get-value", "project")
+	cmd2 := exec.Command(gcloudBinary(), "config", "get-value", "project")
 	output, err := cmd2.Output()
 	if err == nil {
 		gcloudProject := strings.TrimSpace(string(output))
@@ -4,7 +4,11 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 // TestGetCredentialInfo tests credential lookup functionality
@@ -488,6 +492,129 @@ func TestConfigFileLoadingErrors(t *testing.T) {
 	}
 }
 
+// TestPrefixSeparator tests the prefix boundary cases that a plain prefix
+// leaves ambiguous, with and without prefixSeparator
+func TestPrefixSeparator(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+
+	tests := []struct {
+		name           string
+		prefix         string
+		separator      string
+		secretName     string
+		expectedPrefix string
+		expectedMatch  bool
+		expectedName   string
+	}{
+		{
+			name:           "Plain prefix accepts a name equal to the prefix",
+			prefix:         "team-",
+			secretName:     "team-",
+			expectedPrefix: "team-",
+			expectedMatch:  true,
+			expectedName:   "team-",
+		},
+		{
+			name:           "Plain prefix without boundary matches a longer word",
+			prefix:         "team",
+			secretName:     "teammate-db",
+			expectedPrefix: "team",
+			expectedMatch:  true,
+			expectedName:   "teammate-db",
+		},
+		{
+			name:           "Separator rejects a name equal to the prefix",
+			prefix:         "team-",
+			separator:      "-",
+			secretName:     "team-",
+			expectedPrefix: "team-",
+			expectedMatch:  false,
+			expectedName:   "team-team-",
+		},
+		{
+			name:           "Separator accepts prefix plus a name",
+			prefix:         "team-",
+			separator:      "-",
+			secretName:     "team-foo",
+			expectedPrefix: "team-",
+			expectedMatch:  true,
+			expectedName:   "team-foo",
+		},
+		{
+			name:           "Separator is appended to a bare prefix",
+			prefix:         "team",
+			separator:      "-",
+			secretName:     "team-foo",
+			expectedPrefix: "team-",
+			expectedMatch:  true,
+			expectedName:   "team-foo",
+		},
+		{
+			name:           "Separator requires the boundary",
+			prefix:         "team",
+			separator:      "-",
+			secretName:     "teammate-db",
+			expectedPrefix: "team-",
+			expectedMatch:  false,
+			expectedName:   "team-teammate-db",
+		},
+		{
+			name:           "Multi-character separator",
+			prefix:         "team",
+			separator:      "__",
+			secretName:     "team__db",
+			expectedPrefix: "team__",
+			expectedMatch:  true,
+			expectedName:   "team__db",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			globalConfig = &Config{Prefix: tt.prefix, PrefixSeparator: tt.separator}
+
+			if got := GetPrefix(); got != tt.expectedPrefix {
+				t.Errorf("GetPrefix() = %q, expected %q", got, tt.expectedPrefix)
+			}
+			if got := FilterSecretsByPrefix(tt.secretName); got != tt.expectedMatch {
+				t.Errorf("FilterSecretsByPrefix(%q) = %v, expected %v", tt.secretName, got, tt.expectedMatch)
+			}
+			if got := AddPrefixToSecretName(tt.secretName); got != tt.expectedName {
+				t.Errorf("AddPrefixToSecretName(%q) = %q, expected %q", tt.secretName, got, tt.expectedName)
+			}
+		})
+	}
+}
+
+// TestValidatePrefixSeparator tests prefixSeparator validation
+func TestValidatePrefixSeparator(t *testing.T) {
+	tests := []struct {
+		name        string
+		prefix      string
+		separator   string
+		expectError bool
+	}{
+		{name: "No separator", prefix: "team-", separator: ""},
+		{name: "Hyphen", prefix: "team", separator: "-"},
+		{name: "Underscores", prefix: "team", separator: "__"},
+		{name: "Separator without prefix", prefix: "", separator: "-", expectError: true},
+		{name: "Invalid character", prefix: "team", separator: "/", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePrefixSeparator(tt.prefix, tt.separator)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 // TestGetProject tests project resolution with different priorities
 func TestGetProject(t *testing.T) {
 	tests := []struct {
@@ -548,3 +675,350 @@ func TestGetProject(t *testing.T) {
 		})
 	}
 }
+
+// TestCollectEffectiveSettings tests resolution of effective settings and their sources
+func TestCollectEffectiveSettings(t *testing.T) {
+	originalConfig := globalConfig
+	originalConfigPath := configFilePath
+	defer func() {
+		globalConfig = originalConfig
+		configFilePath = originalConfigPath
+	}()
+
+	tests := []struct {
+		name        string
+		config      *Config
+		configPath  string
+		projectFlag string
+		configFlag  string
+		expected    map[string]effectiveSetting
+	}{
+		{
+			name: "Config file from --config with prefix and labels",
+			config: &Config{
+				Project:     "config-project",
+				Prefix:      "team-",
+				Defaults:    DefaultConfig{Labels: map[string]string{"team": "backend", "env": "dev"}},
+				Credentials: []CredentialInfo{{Name: "a"}},
+			},
+			configPath:  "/tmp/custom.conf",
+			projectFlag: "",
+			configFlag:  "/tmp/custom.conf",
+			expected: map[string]effectiveSetting{
+				"Config file":     {"Config file", "/tmp/custom.conf", "from --config flag"},
+				"Project":         {"Project", "config-project", "from config file"},
+				"Prefix":          {"Prefix", "team-", "from config file"},
+				"List attributes": {"List attributes", "title", "default (credentials are configured)"},
+				"Default labels":  {"Default labels", "env=dev, team=backend", "from config file"},
+			},
+		},
+		{
+			name:        "Project flag overrides config",
+			config:      &Config{Project: "config-project", List: ListConfig{Attributes: []string{"title", "owner"}}},
+			configPath:  "",
+			projectFlag: "flag-project",
+			expected: map[string]effectiveSetting{
+				"Config file":     {"Config file", "(none loaded)", "built-in defaults"},
+				"Project":         {"Project", "flag-project", "from --project flag"},
+				"Prefix":          {"Prefix", "(none)", "not set"},
+				"List attributes": {"List attributes", "title, owner", "from config file"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			globalConfig = tt.config
+			configFilePath = tt.configPath
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("project", tt.projectFlag, "")
+			cmd.Flags().String("config", tt.configFlag, "")
+
+			settings := make(map[string]effectiveSetting)
+			for _, setting := range collectEffectiveSettings(cmd) {
+				settings[setting.Name] = setting
+			}
+
+			for name, want := range tt.expected {
+				if got := settings[name]; got != want {
+					t.Errorf("%s = %+v, expected %+v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestCollectEnvironmentSettings tests reporting gsecutil environment
+// variables and whether they are overridden
+func TestCollectEnvironmentSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *Config
+		projectFlag string
+		env         map[string]string
+		expected    map[string]effectiveSetting
+	}{
+		{
+			name:   "GSECUTIL_PROJECT in effect",
+			config: &Config{},
+			env:    map[string]string{"GSECUTIL_PROJECT": "env-project"},
+			expected: map[string]effectiveSetting{
+				"GSECUTIL_PROJECT": {"GSECUTIL_PROJECT", "env-project", "in effect: project when neither --project nor the config file sets one"},
+				"GSECUTIL_GCLOUD":  {"GSECUTIL_GCLOUD", "(not set)", "gcloud executable to run"},
+			},
+		},
+		{
+			name:   "Config file overrides GSECUTIL_PROJECT",
+			config: &Config{Project: "config-project"},
+			env:    map[string]string{"GSECUTIL_PROJECT": "env-project", "GSECUTIL_GCLOUD": "/opt/gcloud"},
+			expected: map[string]effectiveSetting{
+				"GSECUTIL_PROJECT": {"GSECUTIL_PROJECT", "env-project", "overridden by config file"},
+				"GSECUTIL_GCLOUD":  {"GSECUTIL_GCLOUD", "/opt/gcloud", "in effect: gcloud executable to run"},
+			},
+		},
+		{
+			name:        "Project flag overrides GSECUTIL_PROJECT",
+			config:      &Config{Project: "config-project"},
+			projectFlag: "flag-project",
+			env:         map[string]string{"GSECUTIL_PROJECT": "env-project"},
+			expected: map[string]effectiveSetting{
+				"GSECUTIL_PROJECT": {"GSECUTIL_PROJECT", "env-project", "overridden by --project flag"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, variable := range gsecutilEnvironmentVariables {
+				t.Setenv(variable.Name, "")
+				os.Unsetenv(variable.Name)
+			}
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("project", tt.projectFlag, "")
+
+			settings := make(map[string]effectiveSetting)
+			for _, setting := range collectEnvironmentSettings(cmd, tt.config) {
+				settings[setting.Name] = setting
+			}
+			for name, want := range tt.expected {
+				if got := settings[name]; got != want {
+					t.Errorf("%s = %+v, expected %+v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestApplyTitlesFromRecords tests bulk title updates and their counts
+func TestApplyTitlesFromRecords(t *testing.T) {
+	config := &Config{Credentials: []CredentialInfo{
+		{Name: "db", Title: "Old DB"},
+		{Name: "api", Title: "API Key"},
+	}}
+	header := []string{"Name", "owner", "Title"}
+	records := [][]string{
+		{"team-db", "alice", "Database"},
+		{"api", "bob", "API Key"},
+		{"team-cache", "carol", "Cache"},
+		{"", "x", "No name"},
+		{"empty-title", "x", " "},
+		{"short"},
+	}
+
+	var stats titleUpdateStats
+	var err error
+	captureStdout(func() {
+		stats, err = applyTitlesFromRecords(config, header, records, "team-")
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := titleUpdateStats{created: 1, updated: 1, unchanged: 1, skipped: 3}
+	if stats != expected {
+		t.Errorf("Stats = %+v, expected %+v", stats, expected)
+	}
+	if cred := findCredential(config, "db"); cred == nil || cred.Title != "Database" {
+		t.Errorf("Expected db title to be updated, got %+v", cred)
+	}
+	if cred := findCredential(config, "cache"); cred == nil || cred.Title != "Cache" {
+		t.Errorf("Expected cache entry to be created without prefix, got %+v", cred)
+	}
+	if len(config.Credentials[0].Attributes) != 0 {
+		t.Error("Extra columns should not be stored as attributes")
+	}
+
+	if _, err := applyTitlesFromRecords(config, []string{"name", "value"}, nil, ""); err == nil {
+		t.Error("Expected error for CSV without a title column")
+	}
+}
+
+// TestReservedAttributeCollisions tests detection of attributes named like the name and title fields
+func TestReservedAttributeCollisions(t *testing.T) {
+	var cred CredentialInfo
+	configContent := `
+name: db-password
+title: Database Password
+Title: Shadow title
+NAME: shadow-name
+owner: alice
+`
+	if err := yaml.Unmarshal([]byte(configContent), &cred); err != nil {
+		t.Fatalf("Failed to parse credential: %v", err)
+	}
+
+	expected := map[string]string{"Title": "title", "NAME": "name"}
+	if got := reservedAttributeCollisions(cred); !reflect.DeepEqual(got, expected) {
+		t.Errorf("reservedAttributeCollisions() = %v, expected %v", got, expected)
+	}
+	if got := reservedAttributeCollisions(CredentialInfo{Name: "db", Attributes: map[string]interface{}{"owner": "alice"}}); len(got) != 0 {
+		t.Errorf("Expected no collisions, got %v", got)
+	}
+}
+
+// TestCredentialYAMLRoundTrip tests that the inline attribute map survives marshal and unmarshal
+func TestCredentialYAMLRoundTrip(t *testing.T) {
+	original := Config{Credentials: []CredentialInfo{{
+		Name:  "db-password",
+		Title: "Database Password",
+		Attributes: map[string]interface{}{
+			"owner":         "alice",
+			"rotation_days": 30,
+			"Title":         "Shadow title",
+		},
+	}}}
+
+	data, err := yaml.Marshal(original)
+	if err != nil {
+		t.Fatalf("yaml.Marshal() failed: %v", err)
+	}
+	var decoded Config
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("yaml.Unmarshal() failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, original) {
+		t.Errorf("Round trip changed the config:\n got %+v\nwant %+v", decoded, original)
+	}
+}
+
+// TestSaveConfigToRejectsReservedAttribute tests that an exact name or title
+// attribute is reported instead of reaching yaml.Marshal, which panics on it
+func TestSaveConfigToRejectsReservedAttribute(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "gsecutil.conf")
+	config := &Config{Credentials: []CredentialInfo{{
+		Name:       "db-password",
+		Attributes: map[string]interface{}{"title": "From an attribute"},
+	}}}
+
+	err := saveConfigTo(config, outputPath)
+	if err == nil || !strings.Contains(err.Error(), "reserved") {
+		t.Fatalf("Expected reserved attribute error, got %v", err)
+	}
+	if _, statErr := os.Stat(outputPath); !os.IsNotExist(statErr) {
+		t.Error("Expected no config file to be written")
+	}
+}
+
+// TestFindOrphanedCredentials tests matching config entries against existing secrets
+func TestFindOrphanedCredentials(t *testing.T) {
+	credentials := []CredentialInfo{
+		{Name: "db-password", Title: "Database"},
+		{Name: "old-token"},
+		{Name: "api-key"},
+		{Name: "removed-key", Title: "Removed"},
+	}
+
+	tests := []struct {
+		name     string
+		existing map[string]bool
+		prefix   string
+		expected []string
+	}{
+		{
+			name:     "Without prefix",
+			existing: map[string]bool{"db-password": true, "api-key": true},
+			expected: []string{"old-token", "removed-key"},
+		},
+		{
+			name:     "Prefixed secret names",
+			existing: map[string]bool{"team-db-password": true, "team-api-key": true, "team-old-token": true},
+			prefix:   "team-",
+			expected: []string{"removed-key"},
+		},
+		{
+			name:     "Bare names do not match when a prefix is set",
+			existing: map[string]bool{"db-password": true},
+			prefix:   "team-",
+			expected: []string{"db-password", "old-token", "api-key", "removed-key"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, cred := range findOrphanedCredentials(credentials, tt.existing, tt.prefix) {
+				names = append(names, cred.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("findOrphanedCredentials() = %v, expected %v", names, tt.expected)
+			}
+		})
+	}
+
+	kept := removeCredentials(credentials, []CredentialInfo{{Name: "old-token"}, {Name: "removed-key"}})
+	if len(kept) != 2 || kept[0].Name != "db-password" || kept[1].Name != "api-key" || kept[0].Title != "Database" {
+		t.Errorf("removeCredentials() = %+v", kept)
+	}
+}
+
+// TestDisableConfig tests that --no-config removes every config-file setting
+func TestDisableConfig(t *testing.T) {
+	originalConfig, originalPath := globalConfig, configFilePath
+	defer func() {
+		globalConfig, configFilePath, configDisabled = originalConfig, originalPath, false
+	}()
+	globalConfig = &Config{
+		Project:     "config-project",
+		Prefix:      "team-",
+		Credentials: []CredentialInfo{{Name: "db-password", Title: "Database"}},
+	}
+	configFilePath = "/tmp/gsecutil.conf"
+
+	DisableConfig()
+
+	if got := AddPrefixToSecretName("db-password"); got != "db-password" {
+		t.Errorf("AddPrefixToSecretName() = %q, expected no prefix", got)
+	}
+	if !FilterSecretsByPrefix("other-secret") {
+		t.Error("Expected FilterSecretsByPrefix to accept every secret without a prefix")
+	}
+	if GetCredentialInfo("db-password") != nil {
+		t.Error("Expected no credentials from the config file")
+	}
+	if got := GetProject(""); got != "" {
+		t.Errorf("GetProject() = %q, expected no project from the config file", got)
+	}
+	if got := GetProject("cli-project"); got != "cli-project" {
+		t.Errorf("GetProject() = %q, expected the flag to still apply", got)
+	}
+
+	os.Setenv("GSECUTIL_PROJECT", "env-project")
+	defer os.Unsetenv("GSECUTIL_PROJECT")
+	if got := GetProject(""); got != "env-project" {
+		t.Errorf("GetProject() = %q, expected the environment variable to still apply", got)
+	}
+
+	// Tests that replace globalConfig must not bring the file back either
+	globalConfig = &Config{Prefix: "team-"}
+	if got := GetPrefix(); got != "" {
+		t.Errorf("GetPrefix() = %q, expected no prefix", got)
+	}
+	if _, err := loadOrCreateConfig(); err == nil {
+		t.Error("Expected loadOrCreateConfig to refuse writing under --no-config")
+	}
+}
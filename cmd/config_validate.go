@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
 
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -19,6 +20,7 @@ This command checks the configuration file for:
 - Prefix format (letters, digits, hyphens, and underscores only)
 - No duplicate credential names
 - No empty credential names
+- No credential attributes named like the reserved name and title fields
 - Valid attribute references
 
 If no file path is provided, validates the default configuration file.`,
@@ -76,6 +78,9 @@ func runConfigValidate(cmd *cobra.Command, args []string) error {
 	if err := validatePrefix(config.Prefix); err != nil {
 		validationErrors = append(validationErrors, err.Error())
 	}
+	if err := validatePrefixSeparator(config.Prefix, config.PrefixSeparator); err != nil {
+		validationErrors = append(validationErrors, err.Error())
+	}
 
 	// Validate credentials
 	if len(config.Credentials) > 0 {
@@ -94,6 +99,16 @@ func runConfigValidate(cmd *cobra.Command, args []string) error {
 				}
 				seenNames[cred.Name] = true
 			}
+
+			collisions := reservedAttributeCollisions(cred)
+			keys := make([]string, 0, len(collisions))
+			for key := range collisions {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				validationErrors = append(validationErrors, fmt.Sprintf("credential '%s' has attribute '%s', which collides with the reserved '%s' field", cred.Name, key, collisions[key]))
+			}
 		}
 	}
 
@@ -1,12 +1,17 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var createCmd = &cobra.Command{
@@ -14,70 +19,125 @@ var createCmd = &cobra.Command{
 	Short: "Create a new secret in Google Secret Manager",
 	Long: `Create a new secret in Google Secret Manager.
 You can provide the secret value via --data flag, from a file using --data-file,
-or interactively (prompt).`,
+from an http(s) endpoint using --data-url, or interactively (prompt).
+
+Empty values are rejected unless --allow-empty-value is given. To store an
+empty value deliberately, use: gsecutil create SECRET_NAME --data "" --allow-empty-value
+
+The secret name, including the prefix, must follow the Secret Manager naming
+rules: only letters, digits, hyphens, and underscores, at most 255 characters.
+Invalid names are rejected before calling gcloud.
+
+Values larger than 64 KiB (the Secret Manager payload limit) are rejected before
+calling gcloud. Set defaults.maxSecretSize in the configuration file or pass
+--max-size to change the limit.
+
+--ttl (a duration such as 24h) or --expire-time (an RFC 3339 timestamp)
+makes Secret Manager delete the secret automatically, for short-lived values
+such as temporary tokens. The two cannot be combined.
+
+--annotation key=value (repeatable) stores an annotation on the secret. Unlike
+label values, annotation values are free-form, so they can record an owner's
+name or a ticket URL; a value may contain commas.
+
+Configuration file:
+--title saves a title for the secret to the configuration file. With
+--update-config, a secret that has no configuration entry gets one; when
+--title is not given and stdin is a terminal, you are asked for the title
+(leave it empty to add the entry without one). --attr key=value (repeatable,
+requires --update-config) stores attributes such as owner or environment on
+the entry, with keys lowercased like import. This replaces a separate
+'config set-title' step. Failing to write the configuration file is reported
+as a warning, since the secret itself was created.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		userInputName := args[0]                           // What the user typed
 		secretName := AddPrefixToSecretName(userInputName) // Add prefix if configured
 		project, _ := cmd.Flags().GetString("project")
-		project = GetProject(project) // Use configuration-based project resolution
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
 		data, _ := cmd.Flags().GetString("data")
 		dataFile, _ := cmd.Flags().GetString("data-file")
 		labels, _ := cmd.Flags().GetStringSlice("labels")
-		title, _ := cmd.Flags().GetString("title")
+		annotations, _ := cmd.Flags().GetStringArray("annotation")
+		allowEmptyValue, _ := cmd.Flags().GetBool("allow-empty-value")
+		maxSize, _ := cmd.Flags().GetInt("max-size")
+		echo, _ := cmd.Flags().GetBool("echo")
+		prompt, _ := cmd.Flags().GetString("prompt")
+
+		if err := validateSecretName(secretName); err != nil {
+			return err
+		}
+		attributes, err := configMetadataFlags(cmd)
+		if err != nil {
+			return err
+		}
+		if err := validateAnnotations(annotations); err != nil {
+			return err
+		}
+		ttlValue, _ := cmd.Flags().GetString("ttl")
+		expireTimeValue, _ := cmd.Flags().GetString("expire-time")
+		ttl, expireTime, err := parseSecretExpiration(ttlValue, expireTimeValue, time.Now())
+		if err != nil {
+			return err
+		}
+
+		warnAboutDataFlag(data != "")
+		if err := validateDataSources(cmd); err != nil {
+			return err
+		}
+		dataURL, _ := cmd.Flags().GetString("data-url")
 
 		// Merge default labels from config with user-provided labels
 		labels = mergeLabelsWithDefaults(labels)
 
 		// Create command should fail for existing secrets.
-		exists, err := secretExists(secretName, project)
+		exists, err := secretManager.Exists(secretName, project)
 		if err != nil {
 			return err
 		}
 		if exists {
-			return fmt.Errorf("secret '%s' already exists. Use `gsecutil update %s` to create a new version", secretName, userInputName)
+			return &SecretExistsError{Secret: secretName, Name: userInputName}
 		}
 
-		// Get secret value
-		secretValue, err := getSecretInput(data, dataFile, "Enter secret value: ")
-		if err != nil {
+		// Get secret value. An explicit --data "" means an intentionally empty
+		// value rather than "no value provided", so it must not fall back to the prompt.
+		var secretValue string
+		if dataURL != "" {
+			secretValue, err = fetchSecretFromFlags(cmd, GetMaxSecretSize(maxSize))
+			if err != nil {
+				return err
+			}
+		} else if cmd.Flags().Changed("data") && data == "" && dataFile == "" {
+			secretValue = ""
+		} else {
+			secretValue, err = getSecretInput(data, dataFile, promptMessage(prompt, "Enter secret value: "), echo)
+			if err != nil {
+				return err
+			}
+		}
+		if err := checkEmptySecretValue(secretValue, allowEmptyValue); err != nil {
 			return err
 		}
-
-		// Build gcloud command to create secret
-		gcloudArgs := []string{"secrets", "create", secretName}
-
-		if project != "" {
-			gcloudArgs = append(gcloudArgs, "--project", project)
+		if err := checkSecretValueSize(secretValue, GetMaxSecretSize(maxSize)); err != nil {
+			return err
 		}
 
-		// Add labels if provided
-		for _, label := range labels {
-			gcloudArgs = append(gcloudArgs, "--labels", label)
-		}
-
-		gcloudArgs = append(gcloudArgs, "--data-file", "-")
-
-		// Execute gcloud command
-		gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-		gcloudCmd.Stdin = strings.NewReader(secretValue)
-
-		output, err := gcloudCmd.CombinedOutput()
-		if err != nil {
-			return fmt.Errorf("gcloud command failed: %s", string(output))
+		if err := secretManager.Create(secretName, project, secretValue, SecretCreateOptions{
+			Labels:      labels,
+			Annotations: annotations,
+			TTL:         ttl,
+			ExpireTime:  expireTime,
+		}); err != nil {
+			// The secret may have been created since the existence check
+			return classifyWriteFailure(err, secretName, userInputName)
 		}
 
 		fmt.Printf("Secret '%s' created successfully\n", secretName)
 
-		// Save title to config file if provided
-		if title != "" {
-			if err := saveTitleToConfig(userInputName, title); err != nil {
-				fmt.Fprintf(os.Stderr, "Warning: Failed to save title to config: %v\n", err)
-			} else {
-				fmt.Printf("Title saved to configuration file\n")
-			}
-		}
-
+		recordConfigMetadata(cmd, userInputName, attributes)
 		return nil
 	},
 }
@@ -86,8 +146,43 @@ func init() {
 	rootCmd.AddCommand(createCmd)
 	createCmd.Flags().StringP("data", "d", "", "Secret data to store")
 	createCmd.Flags().String("data-file", "", "Path to file containing secret data")
+	addDataURLFlags(createCmd)
 	createCmd.Flags().StringSlice("labels", []string{}, "Labels to apply to the secret (format: key=value)")
+	createCmd.Flags().String("ttl", "", "Delete the secret automatically after this duration (e.g. 24h, 90m)")
+	createCmd.Flags().String("expire-time", "", "Delete the secret automatically at this RFC 3339 time (e.g. 2026-01-01T00:00:00Z)")
+	createCmd.Flags().StringArray("annotation", nil, "Annotation to apply to the secret (format: key=value, repeatable; the value may contain commas)")
 	createCmd.Flags().StringP("title", "t", "", "Title for the secret (saved to config file)")
+	createCmd.Flags().Bool("update-config", false, "Add a configuration entry for the secret if it has none, asking for a title unless --title is given")
+	createCmd.Flags().StringArray("attr", nil, "Config attribute to store on the entry, as key=value (repeatable, requires --update-config)")
+	createCmd.Flags().Bool("allow-empty-value", false, "Allow storing an empty secret value")
+	createCmd.Flags().Bool("echo", false, "Show the value as it is typed at the interactive prompt (for low-sensitivity values)")
+	createCmd.Flags().String("prompt", "", "Message shown at the interactive prompt (default: \"Enter secret value:\")")
+	createCmd.Flags().Int("max-size", 0, "Maximum secret value size in bytes (default: defaults.maxSecretSize or 65536)")
+}
+
+// checkEmptySecretValue rejects an empty value unless it was explicitly allowed
+func checkEmptySecretValue(value string, allowEmpty bool) error {
+	if value == "" && !allowEmpty {
+		return fmt.Errorf("secret value is empty; use --allow-empty-value to store an empty value")
+	}
+	return nil
+}
+
+// checkSecretValueSize rejects values larger than maxSize bytes, so oversized
+// secrets fail with a clear message instead of deep inside gcloud
+func checkSecretValueSize(value string, maxSize int) error {
+	if len(value) > maxSize {
+		return fmt.Errorf("secret value is %d bytes, which exceeds the maximum of %d bytes (Secret Manager payloads are limited to 64 KiB)", len(value), maxSize)
+	}
+	return nil
+}
+
+// formatByteSize formats a byte count for display
+func formatByteSize(size int) string {
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f KiB", float64(size)/1024)
 }
 
 func secretExists(secretName, project string) (bool, error) {
@@ -96,7 +191,7 @@ func secretExists(secretName, project string) (bool, error) {
 		gcloudArgs = append(gcloudArgs, "--project", project)
 	}
 
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
 	output, err := gcloudCmd.CombinedOutput()
 	if err == nil {
 		return true, nil
@@ -110,18 +205,146 @@ func secretExists(secretName, project string) (bool, error) {
 	return false, formatGcloudError(string(output))
 }
 
-// saveTitleToConfig saves the secret title to the configuration file
-func saveTitleToConfig(secretName, title string) error {
+// parseAttrFlags parses repeated --attr key=value flags into config
+// attributes, lowercasing keys like import. title and name are rejected since
+// they are not attributes (use --title).
+func parseAttrFlags(values []string) (map[string]string, error) {
+	attributes := make(map[string]string)
+	for _, value := range values {
+		key, attrValue, ok := strings.Cut(value, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --attr '%s': expected key=value", value)
+		}
+		for _, field := range reservedCredentialFields {
+			if key == field {
+				return nil, fmt.Errorf("invalid --attr '%s': '%s' is not an attribute (use --title for titles)", value, key)
+			}
+		}
+		attributes[key] = attrValue
+	}
+	return attributes, nil
+}
+
+// configMetadataFlags validates --attr before a create or update changes
+// anything, returning the attributes to save
+func configMetadataFlags(cmd *cobra.Command) (map[string]string, error) {
+	attrValues, _ := cmd.Flags().GetStringArray("attr")
+	if len(attrValues) == 0 {
+		return nil, nil
+	}
+	if updateConfig, _ := cmd.Flags().GetBool("update-config"); !updateConfig {
+		return nil, fmt.Errorf("--attr requires --update-config")
+	}
+	return parseAttrFlags(attrValues)
+}
+
+// recordConfigMetadata handles --title, --update-config, and --attr after a
+// successful create or update. Without --title, --update-config asks for a
+// title on a terminal when the secret has no configuration entry yet.
+// Failures are warnings because the secret change has already been made.
+func recordConfigMetadata(cmd *cobra.Command, userInputName string, attributes map[string]string) {
+	title, _ := cmd.Flags().GetString("title")
+	updateConfig, _ := cmd.Flags().GetBool("update-config")
+	name := strings.TrimPrefix(AddPrefixToSecretName(userInputName), GetPrefix()) // config stores bare names
+	newEntry := updateConfig && GetCredentialInfo(name) == nil
+
+	if title == "" && newEntry && term.IsTerminal(int(os.Stdin.Fd())) {
+		var err error
+		if title, err = promptForTitle(bufio.NewReader(os.Stdin), name); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			return
+		}
+	}
+	if title == "" && len(attributes) == 0 && !newEntry {
+		return
+	}
+
+	if err := saveMetadataToConfig(name, title, attributes); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to save metadata to config: %v\n", err)
+		return
+	}
+	if title != "" {
+		fmt.Printf("Title saved to configuration file\n")
+	}
+	if len(attributes) > 0 {
+		keys := make([]string, 0, len(attributes))
+		for key := range attributes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fmt.Printf("Attributes saved to configuration file: %s\n", strings.Join(keys, ", "))
+	}
+	if title == "" && len(attributes) == 0 {
+		fmt.Printf("Configuration entry added for '%s'\n", name)
+	}
+}
+
+// promptForTitle asks for the title of a secret; an empty answer means none
+func promptForTitle(reader *bufio.Reader, name string) (string, error) {
+	fmt.Printf("Title for '%s' (leave empty for none): ", name)
+	response, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read title: %w", err)
+	}
+	return strings.TrimSpace(response), nil
+}
+
+// saveMetadataToConfig saves the secret title and attributes to the configuration file
+func saveMetadataToConfig(secretName, title string, attributes map[string]string) error {
 	config, err := loadOrCreateConfig()
 	if err != nil {
 		return err
 	}
 
-	updateConfigWithMetadata(config, secretName, title, nil)
+	updateConfigWithMetadata(config, secretName, title, attributes)
 
 	return saveConfig(config)
 }
 
+// validateAnnotations checks --annotation values before gcloud is called.
+// Values are free-form, unlike label values; keys must be non-empty.
+func validateAnnotations(annotations []string) error {
+	seen := make(map[string]bool, len(annotations))
+	for _, annotation := range annotations {
+		key, _, ok := strings.Cut(annotation, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return fmt.Errorf("invalid annotation '%s': expected key=value", annotation)
+		}
+		if seen[key] {
+			return fmt.Errorf("invalid annotation '%s': key '%s' is given more than once", annotation, key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// parseSecretExpiration parses the --ttl and --expire-time values of create,
+// which cannot be combined. Zero results mean the secret does not expire.
+func parseSecretExpiration(ttlValue, expireTimeValue string, now time.Time) (time.Duration, time.Time, error) {
+	if ttlValue != "" && expireTimeValue != "" {
+		return 0, time.Time{}, fmt.Errorf("--ttl cannot be combined with --expire-time")
+	}
+	if ttlValue != "" {
+		ttl, err := time.ParseDuration(ttlValue)
+		if err != nil || ttl <= 0 {
+			return 0, time.Time{}, fmt.Errorf("invalid --ttl '%s': use a positive duration such as 24h or 90m", ttlValue)
+		}
+		return ttl, time.Time{}, nil
+	}
+	if expireTimeValue != "" {
+		expireTime, err := time.Parse(time.RFC3339, expireTimeValue)
+		if err != nil {
+			return 0, time.Time{}, fmt.Errorf("invalid --expire-time '%s': use an RFC 3339 timestamp such as 2026-01-01T00:00:00Z", expireTimeValue)
+		}
+		if !expireTime.After(now) {
+			return 0, time.Time{}, fmt.Errorf("--expire-time '%s' is not in the future", expireTimeValue)
+		}
+		return 0, expireTime, nil
+	}
+	return 0, time.Time{}, nil
+}
+
 // mergeLabelsWithDefaults merges default labels from config with user-provided labels.
 // User-provided labels take precedence over default labels.
 func mergeLabelsWithDefaults(userLabels []string) []string {
@@ -3,7 +3,9 @@ package cmd
 import (
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestMergeLabelsWithDefaults(t *testing.T) {
@@ -91,3 +93,215 @@ func TestMergeLabelsWithDefaults(t *testing.T) {
 	// Reset global config
 	globalConfig = nil
 }
+
+// TestCheckEmptySecretValue tests the empty value guard shared by create and import
+func TestCheckEmptySecretValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		allowEmpty  bool
+		expectError bool
+	}{
+		{name: "Non-empty value", value: "secret", allowEmpty: false, expectError: false},
+		{name: "Non-empty value with allow flag", value: "secret", allowEmpty: true, expectError: false},
+		{name: "Empty value rejected by default", value: "", allowEmpty: false, expectError: true},
+		{name: "Empty value allowed explicitly", value: "", allowEmpty: true, expectError: false},
+		{name: "Whitespace is not empty", value: " ", allowEmpty: false, expectError: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkEmptySecretValue(tt.value, tt.allowEmpty)
+			if (err != nil) != tt.expectError {
+				t.Errorf("checkEmptySecretValue(%q, %v) error = %v, expectError %v", tt.value, tt.allowEmpty, err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestCheckSecretValueSize tests the client-side payload size guard
+func TestCheckSecretValueSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		size        int
+		maxSize     int
+		expectError bool
+	}{
+		{name: "Empty value", size: 0, maxSize: defaultMaxSecretSize, expectError: false},
+		{name: "Exactly at the limit", size: defaultMaxSecretSize, maxSize: defaultMaxSecretSize, expectError: false},
+		{name: "One byte over the limit", size: defaultMaxSecretSize + 1, maxSize: defaultMaxSecretSize, expectError: true},
+		{name: "Custom lower limit", size: 200, maxSize: 100, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSecretValueSize(strings.Repeat("x", tt.size), tt.maxSize)
+			if (err != nil) != tt.expectError {
+				t.Errorf("checkSecretValueSize(%d bytes, %d) error = %v, expectError %v", tt.size, tt.maxSize, err, tt.expectError)
+			}
+		})
+	}
+}
+
+// TestGetMaxSecretSize tests resolution of the size limit from flag, config, and default
+func TestGetMaxSecretSize(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+
+	globalConfig = &Config{}
+	if got := GetMaxSecretSize(0); got != defaultMaxSecretSize {
+		t.Errorf("GetMaxSecretSize(0) without config = %d, expected %d", got, defaultMaxSecretSize)
+	}
+
+	globalConfig = &Config{Defaults: DefaultConfig{MaxSecretSize: 1024}}
+	if got := GetMaxSecretSize(0); got != 1024 {
+		t.Errorf("GetMaxSecretSize(0) with config = %d, expected 1024", got)
+	}
+	if got := GetMaxSecretSize(2048); got != 2048 {
+		t.Errorf("GetMaxSecretSize(2048) = %d, expected flag value to win", got)
+	}
+}
+
+// TestFormatByteSize tests size formatting for list and describe output
+func TestFormatByteSize(t *testing.T) {
+	tests := map[int]string{0: "0 B", 1023: "1023 B", 1024: "1.0 KiB", 65536: "64.0 KiB"}
+	for size, expected := range tests {
+		if got := formatByteSize(size); got != expected {
+			t.Errorf("formatByteSize(%d) = %q, expected %q", size, got, expected)
+		}
+	}
+}
+
+// TestShouldWarnAboutDataFlag tests when the shell history warning for --data is shown
+func TestShouldWarnAboutDataFlag(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+
+	enabled, disabled := true, false
+	tests := []struct {
+		name        string
+		warnSetting *bool
+		dataUsed    bool
+		interactive bool
+		expected    bool
+	}{
+		{name: "Interactive --data warns by default", dataUsed: true, interactive: true, expected: true},
+		{name: "Non-interactive --data (scripts, CI)", dataUsed: true, interactive: false, expected: false},
+		{name: "No --data", dataUsed: false, interactive: true, expected: false},
+		{name: "Silenced in config", warnSetting: &disabled, dataUsed: true, interactive: true, expected: false},
+		{name: "Explicitly enabled in config", warnSetting: &enabled, dataUsed: true, interactive: true, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			globalConfig = &Config{Defaults: DefaultConfig{WarnOnDataFlag: tt.warnSetting}}
+			if got := shouldWarnAboutDataFlag(tt.dataUsed, tt.interactive); got != tt.expected {
+				t.Errorf("shouldWarnAboutDataFlag(%v, %v) = %v, expected %v", tt.dataUsed, tt.interactive, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseAttrFlags tests parsing --attr key=value flags
+func TestParseAttrFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected map[string]string
+		wantErr  bool
+	}{
+		{name: "Keys are lowercased", values: []string{"Owner=backend", "environment=prod"}, expected: map[string]string{"owner": "backend", "environment": "prod"}},
+		{name: "Value may contain equals", values: []string{"url=https://x?a=b"}, expected: map[string]string{"url": "https://x?a=b"}},
+		{name: "Empty value is allowed", values: []string{"notes="}, expected: map[string]string{"notes": ""}},
+		{name: "Later value wins", values: []string{"owner=a", "owner=b"}, expected: map[string]string{"owner": "b"}},
+		{name: "Missing equals", values: []string{"owner"}, wantErr: true},
+		{name: "Empty key", values: []string{"=value"}, wantErr: true},
+		{name: "Reserved title", values: []string{"Title=Database"}, wantErr: true},
+		{name: "Reserved name", values: []string{"name=db"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAttrFlags(tt.values)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseAttrFlags(%v) expected an error, got %v", tt.values, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAttrFlags(%v) failed: %v", tt.values, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("parseAttrFlags(%v) = %v, expected %v", tt.values, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestValidateAnnotations tests validation of create --annotation values
+func TestValidateAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations []string
+		expectError bool
+	}{
+		{name: "No annotations"},
+		{name: "Free-form values", annotations: []string{"owner=Backend Team", "ticket=https://example.com/OPS-1?a=b,c", "note="}},
+		{name: "Missing value separator", annotations: []string{"owner"}, expectError: true},
+		{name: "Missing key", annotations: []string{"=backend"}, expectError: true},
+		{name: "Duplicate key", annotations: []string{"owner=a", "owner=b"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAnnotations(tt.annotations)
+			if tt.expectError && err == nil {
+				t.Error("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+// TestParseSecretExpiration tests parsing of create --ttl and --expire-time
+func TestParseSecretExpiration(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		ttl          string
+		expireTime   string
+		expectTTL    time.Duration
+		expectExpire time.Time
+		expectError  bool
+	}{
+		{name: "Neither"},
+		{name: "TTL", ttl: "24h", expectTTL: 24 * time.Hour},
+		{name: "Expire time", expireTime: "2025-06-02T00:00:00+02:00", expectExpire: time.Date(2025, 6, 1, 22, 0, 0, 0, time.UTC)},
+		{name: "Both", ttl: "24h", expireTime: "2025-06-02T00:00:00Z", expectError: true},
+		{name: "Invalid TTL", ttl: "1 day", expectError: true},
+		{name: "Negative TTL", ttl: "-1h", expectError: true},
+		{name: "Invalid expire time", expireTime: "2025-06-02", expectError: true},
+		{name: "Expire time in the past", expireTime: "2025-06-01T11:00:00Z", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ttl, expireTime, err := parseSecretExpiration(tt.ttl, tt.expireTime, now)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if ttl != tt.expectTTL || !expireTime.Equal(tt.expectExpire) {
+				t.Errorf("parseSecretExpiration() = %v, %v, expected %v, %v", ttl, expireTime, tt.expectTTL, tt.expectExpire)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultDataURLTimeout bounds how long --data-url waits for the whole response
+const defaultDataURLTimeout = 30 * time.Second
+
+// addDataURLFlags registers --data-url and its options on create and update
+func addDataURLFlags(cmd *cobra.Command) {
+	cmd.Flags().String("data-url", "", "Fetch the secret data from this http(s) URL")
+	cmd.Flags().StringArray("data-url-header", []string{}, "HTTP header for --data-url requests (format: 'Name: value', repeatable)")
+	cmd.Flags().Duration("data-url-timeout", defaultDataURLTimeout, "Timeout for --data-url requests")
+}
+
+// validateDataSources rejects giving --data-url together with --data or --data-file
+func validateDataSources(cmd *cobra.Command) error {
+	if dataURL, _ := cmd.Flags().GetString("data-url"); dataURL == "" {
+		return nil
+	}
+	if cmd.Flags().Changed("data") || cmd.Flags().Changed("data-file") {
+		return fmt.Errorf("--data-url cannot be combined with --data or --data-file")
+	}
+	return nil
+}
+
+// fetchSecretFromFlags fetches the value for --data-url using the header and timeout flags
+func fetchSecretFromFlags(cmd *cobra.Command, maxSize int) (string, error) {
+	dataURL, _ := cmd.Flags().GetString("data-url")
+	headers, _ := cmd.Flags().GetStringArray("data-url-header")
+	timeout, _ := cmd.Flags().GetDuration("data-url-timeout")
+	return fetchSecretFromURL(dataURL, headers, timeout, maxSize)
+}
+
+// fetchSecretFromURL GETs rawURL and returns the response body as the secret
+// value. Only http and https are accepted, non-2xx responses are errors, and
+// bodies larger than maxSize bytes are rejected without being read in full.
+func fetchSecretFromURL(rawURL string, headers []string, timeout time.Duration, maxSize int) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", fmt.Errorf("invalid --data-url '%s': must be an http:// or https:// URL", rawURL)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to build request for --data-url: %w", err)
+	}
+	for _, header := range headers {
+		name, value, ok := strings.Cut(header, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return "", fmt.Errorf("invalid --data-url-header '%s' (use 'Name: value')", header)
+		}
+		req.Header.Add(name, strings.TrimSpace(value))
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch secret data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("failed to fetch secret data from %s: server returned %s", parsed.Redacted(), resp.Status)
+	}
+	if resp.ContentLength > int64(maxSize) {
+		return "", fmt.Errorf("secret data at %s is %d bytes, which exceeds the maximum of %d bytes", parsed.Redacted(), resp.ContentLength, maxSize)
+	}
+
+	// Read one byte past the limit to detect oversized bodies without a Content-Length
+	body, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret data from %s: %w", parsed.Redacted(), err)
+	}
+	if len(body) > maxSize {
+		return "", fmt.Errorf("secret data at %s exceeds the maximum of %d bytes", parsed.Redacted(), maxSize)
+	}
+	return string(body), nil
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFetchSecretFromURL tests fetching a value for --data-url
+func TestFetchSecretFromURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/value":
+			fmt.Fprint(w, "s3cret")
+		case "/auth":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, "authorized")
+		case "/large":
+			fmt.Fprint(w, strings.Repeat("x", 20))
+		case "/streamed":
+			// Flushing before writing forces a chunked response without Content-Length
+			w.(http.Flusher).Flush()
+			fmt.Fprint(w, strings.Repeat("x", 20))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		url         string
+		headers     []string
+		expected    string
+		expectError string
+	}{
+		{name: "body is the value", url: server.URL + "/value", expected: "s3cret"},
+		{name: "auth header", url: server.URL + "/auth", headers: []string{"Authorization: Bearer token"}, expected: "authorized"},
+		{name: "missing auth header", url: server.URL + "/auth", expectError: "401"},
+		{name: "not found", url: server.URL + "/missing", expectError: "404"},
+		{name: "too large by Content-Length", url: server.URL + "/large", expectError: "exceeds the maximum of 10 bytes"},
+		{name: "too large without Content-Length", url: server.URL + "/streamed", expectError: "exceeds the maximum of 10 bytes"},
+		{name: "invalid header", url: server.URL + "/value", headers: []string{"no-colon"}, expectError: "invalid --data-url-header"},
+		{name: "unsupported scheme", url: "file:///etc/passwd", expectError: "must be an http:// or https:// URL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := fetchSecretFromURL(tt.url, tt.headers, 5*time.Second, 10)
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("fetchSecretFromURL() error = %v, expected it to contain %q", err, tt.expectError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("fetchSecretFromURL() error = %v", err)
+			}
+			if value != tt.expected {
+				t.Errorf("fetchSecretFromURL() = %q, expected %q", value, tt.expected)
+			}
+		})
+	}
+}
@@ -3,8 +3,8 @@ package cmd
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -15,18 +15,44 @@ var deleteCmd = &cobra.Command{
 	Short: "Delete a secret from Google Secret Manager",
 	Long: `Delete a secret from Google Secret Manager.
 This operation is irreversible and will permanently remove the secret
-and all of its versions.`,
+and all of its versions.
+
+Use --confirm-name to require retyping the secret name before deletion.
+Teams can enforce this for every delete by setting
+defaults.requireNameConfirmation: true in the configuration file.
+Name confirmation is independent of --force: --force only skips the y/N prompt.
+
+Use --remove-config to also remove the secret's entry from the configuration
+file, so it does not linger as an orphan. Failing to update the configuration
+file is reported as a warning, since the secret itself was deleted.`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		userInputName := args[0]                           // What the user typed
 		secretName := AddPrefixToSecretName(userInputName) // Add prefix if configured
 		project, _ := cmd.Flags().GetString("project")
-		project = GetProject(project) // Use configuration-based project resolution
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
 		force, _ := cmd.Flags().GetBool("force")
+		confirmName, _ := cmd.Flags().GetBool("confirm-name")
+		removeConfig, _ := cmd.Flags().GetBool("remove-config")
+		if removeConfig && configDisabled {
+			return fmt.Errorf("--remove-config cannot be combined with --no-config")
+		}
+
+		reader := bufio.NewReader(os.Stdin)
 
-		if !force {
+		if confirmName || GetConfig().Defaults.RequireNameConfirmation {
+			confirmed, err := confirmSecretName(reader, secretName)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return fmt.Errorf("secret name did not match; delete operation cancelled")
+			}
+		} else if !force {
 			fmt.Printf("Are you sure you want to delete secret '%s'? This action is irreversible. (y/N): ", secretName)
-			reader := bufio.NewReader(os.Stdin)
 			response, err := reader.ReadString('\n')
 			if err != nil {
 				return fmt.Errorf("failed to read confirmation input: %w", err)
@@ -38,26 +64,68 @@ and all of its versions.`,
 			}
 		}
 
-		// Build gcloud command
-		gcloudArgs := []string{"secrets", "delete", secretName, "--quiet"}
-
-		if project != "" {
-			gcloudArgs = append(gcloudArgs, "--project", project)
-		}
-
-		// Execute gcloud command
-		gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-		output, err := gcloudCmd.CombinedOutput()
-		if err != nil {
-			return fmt.Errorf("gcloud command failed: %s", string(output))
+		if err := secretManager.Delete(secretName, project); err != nil {
+			return err
 		}
 
 		fmt.Printf("Secret '%s' deleted successfully\n", secretName)
+
+		if removeConfig {
+			name := strings.TrimPrefix(secretName, GetPrefix()) // config stores bare names
+			removed, err := removeConfigEntry(name)
+			switch {
+			case err != nil:
+				fmt.Fprintf(os.Stderr, "Warning: Failed to remove the configuration entry: %v\n", err)
+			case removed:
+				fmt.Printf("Configuration entry '%s' removed\n", name)
+			default:
+				fmt.Printf("No configuration entry for '%s'; configuration file unchanged\n", name)
+			}
+		}
 		return nil
 	},
 }
 
+// removeConfigEntry removes the credential entry named name from the
+// configuration file, reporting whether there was one
+func removeConfigEntry(name string) (bool, error) {
+	config, err := loadOrCreateConfig()
+	if err != nil {
+		return false, err
+	}
+
+	var entries []CredentialInfo
+	for _, cred := range config.Credentials {
+		if cred.Name == name {
+			entries = append(entries, cred)
+		}
+	}
+	if len(entries) == 0 {
+		return false, nil
+	}
+
+	config.Credentials = removeCredentials(config.Credentials, entries)
+	if err := saveConfig(config); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// confirmSecretName asks the user to retype the secret name and reports
+// whether the typed value matches it exactly
+func confirmSecretName(reader *bufio.Reader, secretName string) (bool, error) {
+	fmt.Printf("This will permanently delete secret '%s' and all of its versions.\n", secretName)
+	fmt.Printf("Type the secret name to confirm: ")
+	response, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read confirmation input: %w", err)
+	}
+	return strings.TrimSpace(response) == secretName, nil
+}
+
 func init() {
 	rootCmd.AddCommand(deleteCmd)
 	deleteCmd.Flags().BoolP("force", "f", false, "Force deletion without confirmation prompt")
+	deleteCmd.Flags().Bool("confirm-name", false, "Require retyping the secret name before deletion (not bypassed by --force)")
+	deleteCmd.Flags().Bool("remove-config", false, "Also remove the secret's entry from the configuration file")
 }
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+// TestConfirmSecretName tests the retype-the-name confirmation used by delete
+func TestConfirmSecretName(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		secretName string
+		expected   bool
+	}{
+		{
+			name:       "Exact match",
+			input:      "team-db-password\n",
+			secretName: "team-db-password",
+			expected:   true,
+		},
+		{
+			name:       "Surrounding whitespace is ignored",
+			input:      "  team-db-password  \n",
+			secretName: "team-db-password",
+			expected:   true,
+		},
+		{
+			name:       "Match without trailing newline",
+			input:      "team-db-password",
+			secretName: "team-db-password",
+			expected:   true,
+		},
+		{
+			name:       "Bare name without prefix does not match",
+			input:      "db-password\n",
+			secretName: "team-db-password",
+			expected:   false,
+		},
+		{
+			name:       "Case differs",
+			input:      "Team-DB-Password\n",
+			secretName: "team-db-password",
+			expected:   false,
+		},
+		{
+			name:       "Plain yes is not enough",
+			input:      "y\n",
+			secretName: "team-db-password",
+			expected:   false,
+		},
+		{
+			name:       "Empty input",
+			input:      "",
+			secretName: "team-db-password",
+			expected:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := bufio.NewReader(strings.NewReader(tt.input))
+			var confirmed bool
+			var err error
+			captureStdout(func() {
+				confirmed, err = confirmSecretName(reader, tt.secretName)
+			})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if confirmed != tt.expected {
+				t.Errorf("confirmSecretName(%q, %q) = %v, expected %v", tt.input, tt.secretName, confirmed, tt.expected)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 
 	"github.com/spf13/cobra"
@@ -13,6 +15,7 @@ var describeCmd = &cobra.Command{
 	Long: `Get comprehensive information about a secret including:
 - Basic metadata (name, creation time, ETag)
 - Default version information (version number, state, creation time)
+- Version counts by state (enabled, disabled, destroyed)
 - Replication strategy (automatic or user-managed)
 - Labels (key-value pairs for organization)
 - Tags/Annotations (additional metadata)
@@ -20,44 +23,163 @@ var describeCmd = &cobra.Command{
 - Expiration and rotation settings (if configured)
 - Pub/Sub topics (if configured)
 
-Use --show-versions to also display detailed information about all versions.`,
+Use --show-versions to also display detailed information about all versions.
+--sort-versions orders them: -created (newest first, the default), created,
+version (by version number, so 10 follows 9), or -version. Disabled and
+destroyed states are colored when --color allows it (auto colors only on a
+terminal, and NO_COLOR disables it).
+Use --show-size to display the size of the latest version's value; this reads
+the value, so it requires access permission and is recorded in audit logs.
+Use --show-access to also list the secret-level IAM bindings.
+
+The metadata, default version, version list, value size, and IAM policy are
+fetched concurrently. Only the metadata is required (and the version list with
+--show-versions); any other piece that fails is reported as a warning.
+
+--format selects how this enhanced description is printed: table (the
+default), json, or yaml. json and yaml contain gcloud's secret metadata
+extended with a "versionStats" object holding the same version counts; both
+formats share the same schema.
+
+--raw-gcloud-format passes a format straight to 'gcloud secrets describe'
+(for example value(name) or a jq-style projection) and prints gcloud's output
+unchanged, without any of the enhancements above.
+
+--template renders the output with a Go text/template, and --template-file
+reads the template from a file, so longer templates (for example to generate
+config files or documentation) need not fit on the command line. A file can
+hold several {{define "name"}} blocks; --template-name selects the one to
+execute. The template data has .Name (as typed), .Secret (the secret metadata),
+.Versions (newest first), and .Title and .Attributes from the configuration
+file. Functions: join, upper, lower, date LAYOUT TIME, json, and version
+(the number at the end of a version name).`,
+	Example: `  gsecutil describe my-secret
+  gsecutil describe my-secret --show-versions
+  gsecutil describe my-secret --show-versions --sort-versions version
+  gsecutil describe my-secret --show-access --show-size
+  gsecutil describe my-secret --format json
+  gsecutil describe my-secret --raw-gcloud-format "value(createTime)"
+  gsecutil describe my-secret --template '{{.Name}}: {{len .Versions}} versions{{"\n"}}'
+  gsecutil describe my-secret --template-file docs.tmpl --template-name summary
+  gsecutil describe my-secret --projects "team-*"`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if projects, _ := cmd.Flags().GetString("projects"); projects != "" {
+			return runAcrossProjects(cmd, args, projects)
+		}
 		userInputName := args[0]                           // What the user typed
 		secretName := AddPrefixToSecretName(userInputName) // Add prefix if configured
 		project, _ := cmd.Flags().GetString("project")
-		project = GetProject(project) // Use configuration-based project resolution
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
 		format, _ := cmd.Flags().GetString("format")
+		rawFormat, _ := cmd.Flags().GetString("raw-gcloud-format")
 		showVersions, _ := cmd.Flags().GetBool("show-versions")
+		showSize, _ := cmd.Flags().GetBool("show-size")
+		showAccess, _ := cmd.Flags().GetBool("show-access")
+		versionSort, _ := cmd.Flags().GetString("sort-versions")
+		colorMode, _ := cmd.Flags().GetString("color")
 
-		// If custom format is specified, use original behavior
-		if format != "" {
-			gcloudArgs := []string{"secrets", "describe", secretName, "--format", format}
-			if project != "" {
-				gcloudArgs = append(gcloudArgs, "--project", project)
+		if cmd.Flags().Changed("sort-versions") && !showVersions {
+			return fmt.Errorf("--sort-versions is only supported with --show-versions")
+		}
+		if err := validateVersionSort(versionSort); err != nil {
+			return err
+		}
+		color, err := colorEnabled(colorMode)
+		if err != nil {
+			return err
+		}
+		tmpl, err := templateFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		if tmpl != nil {
+			if format != "" || rawFormat != "" {
+				return fmt.Errorf("--template and --template-file cannot be combined with --format or --raw-gcloud-format")
 			}
+			return describeSecretWithTemplate(secretName, userInputName, project, tmpl)
+		}
 
-			gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-			output, err := gcloudCmd.Output()
+		if rawFormat != "" {
+			if format != "" {
+				return fmt.Errorf("--format and --raw-gcloud-format cannot be used together")
+			}
+			output, err := runGcloudDescribe(secretName, project, rawFormat)
 			if err != nil {
-				if exitError, ok := err.(*exec.ExitError); ok {
-					return formatGcloudError(string(exitError.Stderr))
-				}
-				return fmt.Errorf("failed to execute gcloud command: %v", err)
+				return err
 			}
-
 			fmt.Print(string(output))
 			return nil
 		}
 
-		// Enhanced describe with version information
-		// Pass both the full secret name (with prefix) and user input name
-		return describeSecretWithVersions(secretName, userInputName, project, showVersions)
+		switch format {
+		case "", "table":
+			// Enhanced describe with version information
+			// Pass both the full secret name (with prefix) and user input name
+			return describeSecretWithVersions(secretName, userInputName, project, showVersions, showSize, showAccess, versionSort, color)
+		case "json", "yaml":
+			// json and yaml are both rendered from gcloud's JSON so the schemas match
+			output, err := runGcloudDescribe(secretName, project, "json")
+			if err != nil {
+				return err
+			}
+			return printDescribeWithVersionStats(output, secretName, project, format)
+		default:
+			return fmt.Errorf("unsupported format '%s' (use table, json, or yaml; use --raw-gcloud-format for other gcloud formats)", format)
+		}
 	},
 }
 
+// runGcloudDescribe runs 'gcloud secrets describe' with the given gcloud format
+func runGcloudDescribe(secretName, project, gcloudFormat string) ([]byte, error) {
+	gcloudArgs := []string{"secrets", "describe", secretName, "--format", gcloudFormat}
+	if project != "" {
+		gcloudArgs = append(gcloudArgs, "--project", project)
+	}
+
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
+	output, err := gcloudCmd.Output()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return nil, formatGcloudError(string(exitError.Stderr))
+		}
+		return nil, fmt.Errorf("failed to execute gcloud command: %v", err)
+	}
+	return output, nil
+}
+
+// printDescribeWithVersionStats adds a versionStats object to gcloud's JSON describe output
+// and prints it as JSON or YAML. The secret is printed without versionStats (with a warning
+// on stderr) if the version list cannot be retrieved.
+func printDescribeWithVersionStats(output []byte, secretName, project, format string) error {
+	var secret map[string]interface{}
+	if err := json.Unmarshal(output, &secret); err != nil {
+		return fmt.Errorf("failed to parse secret metadata: %w", err)
+	}
+
+	versions, err := fetchSecretVersions(secretName, project)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Could not retrieve version list: %v\n", err)
+	} else {
+		secret["versionStats"] = countVersionStates(versions)
+	}
+
+	return printStructuredOutput(secret, format)
+}
+
 func init() {
 	rootCmd.AddCommand(describeCmd)
-	describeCmd.Flags().String("format", "", "Output format (e.g., json, yaml)")
+	describeCmd.Flags().String("format", "", "Output format for the enhanced description: table (default), json, or yaml")
+	describeCmd.Flags().String("raw-gcloud-format", "", "Pass this format to gcloud and print its output unchanged (e.g., value(name))")
 	describeCmd.Flags().BoolP("show-versions", "v", false, "Show detailed version information including creation and update times")
+	describeCmd.Flags().String("sort-versions", versionSortNewest, "Order of --show-versions: -created, created, version, or -version")
+	describeCmd.Flags().String("color", "auto", "Color version states: auto, always, or never")
+	describeCmd.Flags().Bool("show-size", false, "Show the size of the latest version's value (accesses the value)")
+	describeCmd.Flags().Bool("show-access", false, "Show the secret-level IAM bindings")
+	addTemplateFlags(describeCmd)
+	addProjectsFlag(describeCmd)
 }
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// describeFetchers are the gcloud reads behind the enhanced describe output
+type describeFetchers struct {
+	Metadata       func(secretName, project string) (SecretInfo, error)
+	DefaultVersion func(secretName, project string) (*SecretVersionInfo, error)
+	Versions       func(secretName, project string) ([]SecretVersionInfo, error)
+	ValueSize      func(secretName, project string) (int, error)
+	Policy         func(secretName, project string) (*IAMPolicy, error)
+}
+
+// secretManagerDescribeFetchers reads every piece through a SecretManager
+func secretManagerDescribeFetchers(sm SecretManager) describeFetchers {
+	return describeFetchers{
+		Metadata: sm.Describe,
+		DefaultVersion: func(secretName, project string) (*SecretVersionInfo, error) {
+			return sm.GetVersion(secretName, "latest", project)
+		},
+		Versions: sm.ListVersions,
+		// The size is of the raw payload, which GetValue trims
+		ValueSize: func(secretName, project string) (int, error) {
+			return getSecretValueSize(secretName, "latest", project)
+		},
+		Policy: sm.GetIamPolicy,
+	}
+}
+
+// describeParts holds the results of fetchDescribeParts. Each optional piece
+// has its own error so a failure can be reported without losing the rest.
+type describeParts struct {
+	Secret            SecretInfo
+	SecretErr         error
+	DefaultVersion    *SecretVersionInfo
+	DefaultVersionErr error
+	Versions          []SecretVersionInfo
+	VersionsErr       error
+	SizeErr           error
+	Policy            *IAMPolicy
+	PolicyErr         error
+}
+
+// fetchDescribeParts runs the independent reads of an enhanced describe
+// concurrently: metadata, default version, and version list always, the value
+// size with showSize, and the IAM policy with showAccess. It waits for all of
+// them; callers decide which failures are fatal.
+func fetchDescribeParts(secretName, project string, showSize, showAccess bool, fetch describeFetchers) describeParts {
+	var parts describeParts
+	var size *int
+	var wg sync.WaitGroup
+	run := func(f func()) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f()
+		}()
+	}
+
+	run(func() { parts.Secret, parts.SecretErr = fetch.Metadata(secretName, project) })
+	run(func() { parts.DefaultVersion, parts.DefaultVersionErr = fetch.DefaultVersion(secretName, project) })
+	run(func() { parts.Versions, parts.VersionsErr = fetch.Versions(secretName, project) })
+	if showSize {
+		run(func() {
+			value, err := fetch.ValueSize(secretName, project)
+			if err != nil {
+				parts.SizeErr = err
+				return
+			}
+			size = &value
+		})
+	}
+	if showAccess {
+		run(func() { parts.Policy, parts.PolicyErr = fetch.Policy(secretName, project) })
+	}
+	wg.Wait()
+
+	parts.Secret.ValueSize = size
+	return parts
+}
+
+// displayDescribeAccess prints the secret-level IAM bindings for
+// 'describe --show-access', one line per role
+func displayDescribeAccess(policy *IAMPolicy) {
+	if len(policy.Bindings) == 0 {
+		fmt.Println("Access: None (project-level IAM permissions may still provide access)")
+		return
+	}
+
+	bindings := append([]Binding(nil), policy.Bindings...)
+	sort.SliceStable(bindings, func(i, j int) bool {
+		return bindings[i].Role < bindings[j].Role
+	})
+
+	fmt.Println("Access:")
+	for _, binding := range bindings {
+		members := make([]string, 0, len(binding.Members))
+		for _, member := range normalizeMembers(binding.Members) {
+			members = append(members, formatPrincipal(member))
+		}
+		line := fmt.Sprintf("  %s: %s", shortRoleName(binding.Role), strings.Join(members, ", "))
+		if binding.Condition != nil {
+			line += fmt.Sprintf(" (condition: %s)", binding.Condition.Expression)
+		}
+		fmt.Println(line)
+	}
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestFetchDescribePartsConcurrent tests that all pieces are fetched at the same time
+func TestFetchDescribePartsConcurrent(t *testing.T) {
+	const pieces = 5
+	var started sync.WaitGroup
+	started.Add(pieces)
+	allStarted := make(chan struct{})
+	go func() {
+		started.Wait()
+		close(allStarted)
+	}()
+	// Each fetcher blocks until every fetcher has started, so a serial
+	// implementation would time out
+	wait := func() error {
+		started.Done()
+		select {
+		case <-allStarted:
+			return nil
+		case <-time.After(2 * time.Second):
+			return errors.New("fetched serially")
+		}
+	}
+
+	fetch := describeFetchers{
+		Metadata: func(secretName, project string) (SecretInfo, error) {
+			return SecretInfo{Name: "projects/p/secrets/" + secretName}, wait()
+		},
+		DefaultVersion: func(secretName, project string) (*SecretVersionInfo, error) {
+			return &SecretVersionInfo{Name: "v/3"}, wait()
+		},
+		Versions: func(secretName, project string) ([]SecretVersionInfo, error) {
+			return []SecretVersionInfo{{Name: "v/3"}}, wait()
+		},
+		ValueSize: func(secretName, project string) (int, error) {
+			return 42, wait()
+		},
+		Policy: func(secretName, project string) (*IAMPolicy, error) {
+			return &IAMPolicy{Bindings: []Binding{{Role: defaultAccessRole}}}, wait()
+		},
+	}
+
+	parts := fetchDescribeParts("db", "p", true, true, fetch)
+	for _, err := range []error{parts.SecretErr, parts.DefaultVersionErr, parts.VersionsErr, parts.SizeErr, parts.PolicyErr} {
+		if err != nil {
+			t.Fatalf("fetchDescribeParts() error: %v", err)
+		}
+	}
+	if parts.Secret.Name != "projects/p/secrets/db" || parts.DefaultVersion == nil || len(parts.Versions) != 1 || parts.Policy == nil {
+		t.Errorf("fetchDescribeParts() returned incomplete parts: %+v", parts)
+	}
+	if parts.Secret.ValueSize == nil || *parts.Secret.ValueSize != 42 {
+		t.Errorf("Expected value size 42, got %v", parts.Secret.ValueSize)
+	}
+}
+
+// TestFetchDescribePartsOptional tests that optional pieces are skipped or fail independently
+func TestFetchDescribePartsOptional(t *testing.T) {
+	calls := make(map[string]bool)
+	var mu sync.Mutex
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[name] = true
+	}
+	fetch := describeFetchers{
+		Metadata: func(secretName, project string) (SecretInfo, error) {
+			record("metadata")
+			return SecretInfo{Name: secretName}, nil
+		},
+		DefaultVersion: func(secretName, project string) (*SecretVersionInfo, error) {
+			record("default")
+			return nil, errors.New("permission denied")
+		},
+		Versions: func(secretName, project string) ([]SecretVersionInfo, error) {
+			record("versions")
+			return nil, nil
+		},
+		ValueSize: func(secretName, project string) (int, error) {
+			record("size")
+			return 0, nil
+		},
+		Policy: func(secretName, project string) (*IAMPolicy, error) {
+			record("policy")
+			return nil, nil
+		},
+	}
+
+	parts := fetchDescribeParts("db", "p", false, false, fetch)
+	if calls["size"] || calls["policy"] {
+		t.Errorf("Expected size and policy not to be fetched, got calls %v", calls)
+	}
+	if parts.SecretErr != nil || parts.Secret.Name != "db" {
+		t.Errorf("Expected metadata despite the default version failure, got %+v", parts)
+	}
+	if parts.DefaultVersionErr == nil {
+		t.Error("Expected the default version error to be kept")
+	}
+	if parts.Secret.ValueSize != nil {
+		t.Errorf("Expected no value size, got %d", *parts.Secret.ValueSize)
+	}
+}
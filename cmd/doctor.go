@@ -0,0 +1,381 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Doctor check statuses
+const (
+	doctorPass = "PASS"
+	doctorWarn = "WARN"
+	doctorFail = "FAIL"
+)
+
+// DoctorCheck is the result of a single environment check
+type DoctorCheck struct {
+	Name    string `json:"name" yaml:"name"`
+	Status  string `json:"status" yaml:"status"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// gcloudRunner runs gcloud with the given arguments and returns its stdout.
+// Checks take a runner so they can be tested without a real gcloud.
+type gcloudRunner func(args ...string) ([]byte, error)
+
+// runGcloud is the default gcloudRunner
+func runGcloud(args ...string) ([]byte, error) {
+	return exec.Command(gcloudBinary(), args...).Output()
+}
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the gsecutil environment",
+	Long: `Check that everything gsecutil depends on is set up correctly:
+
+- gcloud is installed (and its version)
+- gcloud has an active authenticated account
+- a project is resolved (and where it comes from)
+- the Secret Manager API is enabled in that project
+- Data Access audit logs are enabled for Secret Manager (needed by auditlog)
+- the configuration file is valid
+- the clipboard is available
+
+Each check reports PASS, WARN, or FAIL. The command exits with an error if any
+check fails.
+
+--format json or yaml prints a report for CI pipelines and wrapping tools:
+{"ok", "checks"}, where ok is false when any check failed and each check has a
+name, a status (pass, warn, or fail), and a message. --output writes the report
+to a file instead of stdout; the exit status still reflects failed checks.`,
+	Example: `  gsecutil doctor
+  gsecutil doctor --project my-project
+  gsecutil doctor --format json --output doctor.json  # Health gate artifact for CI`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		outputPath, _ := cmd.Flags().GetString("output")
+		if format == "text" {
+			format = ""
+		}
+		if format != "" && format != "json" && format != "yaml" {
+			return fmt.Errorf("unsupported format '%s' (use text, json, or yaml)", format)
+		}
+		if outputPath != "" && format == "" {
+			return fmt.Errorf("--output requires --format json or yaml")
+		}
+
+		project, source := getProjectWithSource(cmd, GetConfig())
+		configPath, _ := cmd.Flags().GetString("config")
+		if configPath == "" {
+			configPath = getDefaultConfigPath()
+		}
+
+		checks := []DoctorCheck{
+			checkGcloudInstalled(exec.LookPath, runGcloud),
+			checkGcloudAuth(runGcloud),
+			checkProjectResolution(project, source),
+			checkSecretManagerAPI(runGcloud, project),
+			checkDataAccessAuditLogs(runGcloud, project),
+			checkConfigFile(configPath),
+			checkClipboard(clipboard.Unsupported),
+		}
+
+		if format != "" {
+			return exportDoctorReport(checks, format, outputPath)
+		}
+		return displayDoctorChecks(checks)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+	doctorCmd.Flags().String("format", "", "Output format: text (default), json, or yaml")
+	doctorCmd.Flags().StringP("output", "o", "", "Write the --format report to this file instead of stdout")
+}
+
+// DoctorReport is the structured output of 'doctor --format json|yaml'
+type DoctorReport struct {
+	OK     bool          `json:"ok" yaml:"ok"`
+	Checks []DoctorCheck `json:"checks" yaml:"checks"`
+}
+
+// newDoctorReport builds the structured report, with statuses lowercased
+func newDoctorReport(checks []DoctorCheck) DoctorReport {
+	report := DoctorReport{OK: true, Checks: make([]DoctorCheck, 0, len(checks))}
+	for _, check := range checks {
+		if check.Status == doctorFail {
+			report.OK = false
+		}
+		check.Status = strings.ToLower(check.Status)
+		report.Checks = append(report.Checks, check)
+	}
+	return report
+}
+
+// exportDoctorReport writes the report as JSON or YAML to stdout or
+// outputPath, returning an error if any check failed
+func exportDoctorReport(checks []DoctorCheck, format, outputPath string) error {
+	report := newDoctorReport(checks)
+
+	var data []byte
+	var err error
+	if format == "yaml" {
+		data, err = yaml.Marshal(report)
+	} else {
+		data, err = json.MarshalIndent(report, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode doctor report: %w", err)
+	}
+
+	if outputPath == "" {
+		if _, err := os.Stdout.Write(data); err != nil {
+			return err
+		}
+	} else {
+		if err := atomicWriteFile(outputPath, data, 0644); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+		fmt.Printf("Wrote doctor report with %d checks to %s\n", len(checks), outputPath)
+	}
+
+	if !report.OK {
+		failed := 0
+		for _, check := range checks {
+			if check.Status == doctorFail {
+				failed++
+			}
+		}
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
+
+// displayDoctorChecks prints the checklist and a summary, returning an error if any check failed
+func displayDoctorChecks(checks []DoctorCheck) error {
+	passed, warned, failed := 0, 0, 0
+	for _, check := range checks {
+		fmt.Printf("[%s] %s: %s\n", check.Status, check.Name, check.Message)
+		switch check.Status {
+		case doctorPass:
+			passed++
+		case doctorWarn:
+			warned++
+		case doctorFail:
+			failed++
+		}
+	}
+
+	fmt.Printf("\n%d passed, %d warning(s), %d failed\n", passed, warned, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d check(s) failed", failed)
+	}
+	return nil
+}
+
+// checkGcloudInstalled verifies gcloud (or GSECUTIL_GCLOUD) can be found and
+// reports its version
+func checkGcloudInstalled(lookPath func(string) (string, error), run gcloudRunner) DoctorCheck {
+	check := DoctorCheck{Name: "gcloud"}
+
+	path, err := lookPath(gcloudBinary())
+	if err != nil {
+		check.Status = doctorFail
+		check.Message = "gcloud not found in PATH; install the Google Cloud CLI"
+		if override := os.Getenv("GSECUTIL_GCLOUD"); override != "" {
+			check.Message = fmt.Sprintf("GSECUTIL_GCLOUD is set to %s, which was not found", override)
+		}
+		return check
+	}
+
+	output, err := run("version", "--format", "json")
+	if err != nil {
+		check.Status = doctorWarn
+		check.Message = fmt.Sprintf("found at %s, but 'gcloud version' failed", path)
+		return check
+	}
+
+	var versions map[string]interface{}
+	if err := json.Unmarshal(output, &versions); err != nil || versions["Google Cloud SDK"] == nil {
+		check.Status = doctorPass
+		check.Message = fmt.Sprintf("found at %s (version unknown)", path)
+		return check
+	}
+
+	check.Status = doctorPass
+	check.Message = fmt.Sprintf("Google Cloud SDK %v (%s)", versions["Google Cloud SDK"], path)
+	return check
+}
+
+// checkGcloudAuth verifies gcloud has an active account
+func checkGcloudAuth(run gcloudRunner) DoctorCheck {
+	check := DoctorCheck{Name: "authentication"}
+
+	output, err := run("auth", "list", "--filter", "status:ACTIVE", "--format", "value(account)")
+	if err != nil {
+		check.Status = doctorFail
+		check.Message = "could not query gcloud accounts"
+		return check
+	}
+
+	account := strings.TrimSpace(strings.Split(strings.TrimSpace(string(output)), "\n")[0])
+	if account == "" {
+		check.Status = doctorFail
+		check.Message = "no active account; run 'gcloud auth login'"
+		return check
+	}
+
+	check.Status = doctorPass
+	check.Message = fmt.Sprintf("active account %s", account)
+	return check
+}
+
+// checkProjectResolution reports the resolved project and its source
+func checkProjectResolution(project, source string) DoctorCheck {
+	check := DoctorCheck{Name: "project"}
+	if project == "" {
+		check.Status = doctorFail
+		check.Message = "no project configured; use --project, the config file, GSECUTIL_PROJECT, or 'gcloud config set project'"
+		return check
+	}
+
+	check.Status = doctorPass
+	check.Message = fmt.Sprintf("%s (%s)", project, source)
+	return check
+}
+
+// checkSecretManagerAPI verifies the Secret Manager API is enabled in the project
+func checkSecretManagerAPI(run gcloudRunner, project string) DoctorCheck {
+	check := DoctorCheck{Name: "Secret Manager API"}
+	if project == "" {
+		check.Status = doctorWarn
+		check.Message = "skipped (no project)"
+		return check
+	}
+
+	output, err := run("services", "list", "--enabled", "--project", project,
+		"--filter", "config.name=secretmanager.googleapis.com", "--format", "value(config.name)")
+	if err != nil {
+		check.Status = doctorWarn
+		check.Message = "could not list enabled services (missing serviceusage permission?)"
+		return check
+	}
+
+	if !strings.Contains(string(output), "secretmanager.googleapis.com") {
+		check.Status = doctorFail
+		check.Message = fmt.Sprintf("not enabled; run 'gcloud services enable secretmanager.googleapis.com --project %s'", project)
+		return check
+	}
+
+	check.Status = doctorPass
+	check.Message = "enabled"
+	return check
+}
+
+// auditConfigPolicy is the subset of a project IAM policy holding audit log configuration
+type auditConfigPolicy struct {
+	AuditConfigs []struct {
+		Service         string `json:"service"`
+		AuditLogConfigs []struct {
+			LogType string `json:"logType"`
+		} `json:"auditLogConfigs"`
+	} `json:"auditConfigs"`
+}
+
+// checkDataAccessAuditLogs verifies DATA_READ audit logs are enabled for Secret Manager
+func checkDataAccessAuditLogs(run gcloudRunner, project string) DoctorCheck {
+	check := DoctorCheck{Name: "Data Access audit logs"}
+	if project == "" {
+		check.Status = doctorWarn
+		check.Message = "skipped (no project)"
+		return check
+	}
+
+	output, err := run("projects", "get-iam-policy", project, "--format", "json")
+	if err != nil {
+		check.Status = doctorWarn
+		check.Message = "could not read the project IAM policy"
+		return check
+	}
+
+	var policy auditConfigPolicy
+	if err := json.Unmarshal(output, &policy); err != nil {
+		check.Status = doctorWarn
+		check.Message = "could not parse the project IAM policy"
+		return check
+	}
+
+	for _, auditConfig := range policy.AuditConfigs {
+		if auditConfig.Service != "secretmanager.googleapis.com" && auditConfig.Service != "allServices" {
+			continue
+		}
+		for _, logConfig := range auditConfig.AuditLogConfigs {
+			if logConfig.LogType == "DATA_READ" {
+				check.Status = doctorPass
+				check.Message = fmt.Sprintf("DATA_READ enabled (via %s)", auditConfig.Service)
+				return check
+			}
+		}
+	}
+
+	check.Status = doctorWarn
+	check.Message = "DATA_READ not enabled; auditlog will not show ACCESS events (see docs/audit-logging.md)"
+	return check
+}
+
+// checkConfigFile verifies the configuration file, if present, parses and validates
+func checkConfigFile(configPath string) DoctorCheck {
+	check := DoctorCheck{Name: "config file"}
+
+	data, err := os.ReadFile(configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		check.Status = doctorPass
+		check.Message = fmt.Sprintf("none at %s (optional)", configPath)
+		return check
+	}
+	if err != nil {
+		check.Status = doctorFail
+		check.Message = fmt.Sprintf("cannot read %s: %v", configPath, err)
+		return check
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		check.Status = doctorFail
+		check.Message = fmt.Sprintf("%s has invalid YAML: %v", configPath, err)
+		return check
+	}
+	if err := validateConfig(&config); err != nil {
+		check.Status = doctorFail
+		check.Message = fmt.Sprintf("%s is invalid: %v", configPath, err)
+		return check
+	}
+
+	check.Status = doctorPass
+	check.Message = fmt.Sprintf("%s is valid (%d credentials)", configPath, len(config.Credentials))
+	return check
+}
+
+// checkClipboard reports whether clipboard support is available
+func checkClipboard(unsupported bool) DoctorCheck {
+	check := DoctorCheck{Name: "clipboard"}
+	if unsupported {
+		check.Status = doctorWarn
+		check.Message = "not available; install xclip, xsel, or wl-clipboard to use --clipboard"
+		return check
+	}
+
+	check.Status = doctorPass
+	check.Message = "available"
+	return check
+}
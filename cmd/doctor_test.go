@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeGcloud returns a gcloudRunner that answers with the given output or error
+func fakeGcloud(output string, err error) gcloudRunner {
+	return func(args ...string) ([]byte, error) {
+		return []byte(output), err
+	}
+}
+
+// TestDoctorGcloudChecks tests the checks that depend on gcloud output
+func TestDoctorGcloudChecks(t *testing.T) {
+	found := func(string) (string, error) { return "/usr/bin/gcloud", nil }
+	missing := func(string) (string, error) { return "", errors.New("not found") }
+	failure := errors.New("exit status 1")
+
+	tests := []struct {
+		name            string
+		check           DoctorCheck
+		expectedStatus  string
+		expectedMessage string
+	}{
+		{
+			name:            "gcloud installed with version",
+			check:           checkGcloudInstalled(found, fakeGcloud(`{"Google Cloud SDK": "470.0.0"}`, nil)),
+			expectedStatus:  doctorPass,
+			expectedMessage: "470.0.0",
+		},
+		{
+			name:            "gcloud missing",
+			check:           checkGcloudInstalled(missing, fakeGcloud("", nil)),
+			expectedStatus:  doctorFail,
+			expectedMessage: "not found",
+		},
+		{
+			name:            "gcloud version fails",
+			check:           checkGcloudInstalled(found, fakeGcloud("", failure)),
+			expectedStatus:  doctorWarn,
+			expectedMessage: "/usr/bin/gcloud",
+		},
+		{
+			name:            "active account",
+			check:           checkGcloudAuth(fakeGcloud("alice@example.com\n", nil)),
+			expectedStatus:  doctorPass,
+			expectedMessage: "alice@example.com",
+		},
+		{
+			name:            "no active account",
+			check:           checkGcloudAuth(fakeGcloud("\n", nil)),
+			expectedStatus:  doctorFail,
+			expectedMessage: "gcloud auth login",
+		},
+		{
+			name:            "API enabled",
+			check:           checkSecretManagerAPI(fakeGcloud("secretmanager.googleapis.com\n", nil), "p"),
+			expectedStatus:  doctorPass,
+			expectedMessage: "enabled",
+		},
+		{
+			name:            "API disabled",
+			check:           checkSecretManagerAPI(fakeGcloud("", nil), "p"),
+			expectedStatus:  doctorFail,
+			expectedMessage: "gcloud services enable",
+		},
+		{
+			name:            "API check without project",
+			check:           checkSecretManagerAPI(fakeGcloud("", nil), ""),
+			expectedStatus:  doctorWarn,
+			expectedMessage: "skipped",
+		},
+		{
+			name: "audit logs enabled for Secret Manager",
+			check: checkDataAccessAuditLogs(fakeGcloud(`{"auditConfigs": [{"service": "secretmanager.googleapis.com",
+				"auditLogConfigs": [{"logType": "ADMIN_READ"}, {"logType": "DATA_READ"}]}]}`, nil), "p"),
+			expectedStatus:  doctorPass,
+			expectedMessage: "secretmanager.googleapis.com",
+		},
+		{
+			name: "audit logs enabled for all services",
+			check: checkDataAccessAuditLogs(fakeGcloud(`{"auditConfigs": [{"service": "allServices",
+				"auditLogConfigs": [{"logType": "DATA_READ"}]}]}`, nil), "p"),
+			expectedStatus:  doctorPass,
+			expectedMessage: "allServices",
+		},
+		{
+			name:            "audit logs not enabled",
+			check:           checkDataAccessAuditLogs(fakeGcloud(`{"bindings": []}`, nil), "p"),
+			expectedStatus:  doctorWarn,
+			expectedMessage: "DATA_READ not enabled",
+		},
+		{
+			name:            "audit policy unreadable",
+			check:           checkDataAccessAuditLogs(fakeGcloud("", failure), "p"),
+			expectedStatus:  doctorWarn,
+			expectedMessage: "could not read",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.check.Status != tt.expectedStatus {
+				t.Errorf("Status = %s, expected %s (message: %s)", tt.check.Status, tt.expectedStatus, tt.check.Message)
+			}
+			if !strings.Contains(tt.check.Message, tt.expectedMessage) {
+				t.Errorf("Message = %q, expected to contain %q", tt.check.Message, tt.expectedMessage)
+			}
+		})
+	}
+}
+
+// TestDoctorLocalChecks tests the checks that do not call gcloud
+func TestDoctorLocalChecks(t *testing.T) {
+	tempDir := t.TempDir()
+	writeConfig := func(name, content string) string {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name           string
+		check          DoctorCheck
+		expectedStatus string
+	}{
+		{name: "project resolved", check: checkProjectResolution("p", "from config file"), expectedStatus: doctorPass},
+		{name: "no project", check: checkProjectResolution("", ""), expectedStatus: doctorFail},
+		{name: "config missing", check: checkConfigFile(filepath.Join(tempDir, "missing.conf")), expectedStatus: doctorPass},
+		{name: "config valid", check: checkConfigFile(writeConfig("valid.conf", "project: p\ncredentials:\n  - name: a\n")), expectedStatus: doctorPass},
+		{name: "config invalid YAML", check: checkConfigFile(writeConfig("bad.conf", "project: [unclosed\n")), expectedStatus: doctorFail},
+		{name: "config duplicate names", check: checkConfigFile(writeConfig("dup.conf", "credentials:\n  - name: a\n  - name: a\n")), expectedStatus: doctorFail},
+		{name: "clipboard available", check: checkClipboard(false), expectedStatus: doctorPass},
+		{name: "clipboard unavailable", check: checkClipboard(true), expectedStatus: doctorWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.check.Status != tt.expectedStatus {
+				t.Errorf("Status = %s, expected %s (message: %s)", tt.check.Status, tt.expectedStatus, tt.check.Message)
+			}
+		})
+	}
+}
+
+// TestDisplayDoctorChecks tests that failing checks produce an error
+func TestDisplayDoctorChecks(t *testing.T) {
+	var err error
+	output := captureStdout(func() {
+		err = displayDoctorChecks([]DoctorCheck{
+			{Name: "a", Status: doctorPass, Message: "ok"},
+			{Name: "b", Status: doctorWarn, Message: "hmm"},
+		})
+	})
+	if err != nil {
+		t.Errorf("Expected no error without failures, got %v", err)
+	}
+	if !strings.Contains(output, "[WARN] b: hmm") || !strings.Contains(output, "1 passed, 1 warning(s), 0 failed") {
+		t.Errorf("Unexpected output:\n%s", output)
+	}
+
+	captureStdout(func() {
+		err = displayDoctorChecks([]DoctorCheck{{Name: "c", Status: doctorFail, Message: "broken"}})
+	})
+	if err == nil {
+		t.Error("Expected error when a check fails")
+	}
+}
+
+// TestExportDoctorReport tests the JSON report and its overall result
+func TestExportDoctorReport(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "doctor.json")
+	var err error
+	captureStdout(func() {
+		err = exportDoctorReport([]DoctorCheck{
+			{Name: "gcloud", Status: doctorPass, Message: "installed"},
+			{Name: "project", Status: doctorFail, Message: "no project configured"},
+		}, "json", outputPath)
+	})
+	if err == nil {
+		t.Error("Expected an error when a check fails")
+	}
+
+	data, readErr := os.ReadFile(outputPath)
+	if readErr != nil {
+		t.Fatalf("Failed to read report: %v", readErr)
+	}
+	var report DoctorReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("Failed to parse report: %v\n%s", err, data)
+	}
+	if report.OK || len(report.Checks) != 2 || report.Checks[0].Status != "pass" || report.Checks[1].Status != "fail" {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+
+	output := captureStdout(func() {
+		err = exportDoctorReport([]DoctorCheck{{Name: "gcloud", Status: doctorWarn, Message: "old"}}, "yaml", "")
+	})
+	if err != nil {
+		t.Errorf("Expected no error without failures, got %v", err)
+	}
+	if !strings.Contains(output, "ok: true") || !strings.Contains(output, "status: warn") {
+		t.Errorf("Unexpected YAML report:\n%s", output)
+	}
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Exit codes returned by gsecutil. Errors that carry a specific code
+// implement ExitCode; every other error exits with exitCodeError.
+const (
+	exitCodeError         = 1
+	exitCodeNotFound      = 3
+	exitCodeAlreadyExists = 4
+)
+
+// SecretExistsError is returned when creating a secret that already exists
+type SecretExistsError struct {
+	Secret string // full secret name, including the prefix
+	Name   string // name as given by the user, for the suggested command
+}
+
+func (e *SecretExistsError) Error() string {
+	name := e.Name
+	if name == "" {
+		name = e.Secret
+	}
+	return fmt.Sprintf("secret '%s' already exists. Use `gsecutil update %s` to create a new version", e.Secret, name)
+}
+
+// ExitCode returns the process exit code for this error
+func (e *SecretExistsError) ExitCode() int {
+	return exitCodeAlreadyExists
+}
+
+// NotFoundError is returned when a secret or one of its versions does not exist
+type NotFoundError struct {
+	Secret  string // full secret name, including the prefix
+	Version string // missing version, or empty when the secret itself is missing
+	Name    string // name as given by the user, for the suggested command (optional)
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Version != "" {
+		return fmt.Sprintf("version '%s' of secret '%s' not found", e.Version, e.Secret)
+	}
+	if e.Name != "" {
+		return fmt.Sprintf("secret '%s' not found. Use `gsecutil create %s` to create it", e.Secret, e.Name)
+	}
+	return fmt.Sprintf("secret '%s' not found", e.Secret)
+}
+
+// ExitCode returns the process exit code for this error
+func (e *NotFoundError) ExitCode() int {
+	return exitCodeNotFound
+}
+
+// gcloudVersionPattern extracts the version from a resource name in gcloud errors
+var gcloudVersionPattern = regexp.MustCompile(`/versions/([^/\]\s]+)`)
+
+// classifyGcloudFailure recognizes "already exists" and "not found" failures
+// in the output of a failed gcloud secrets command and returns a typed error
+// for them, or nil for any other failure
+func classifyGcloudFailure(output, secretName, userInputName string) error {
+	lower := strings.ToLower(output)
+	switch {
+	case strings.Contains(lower, "already exists") || strings.Contains(lower, "already_exists"):
+		return &SecretExistsError{Secret: secretName, Name: userInputName}
+	case strings.Contains(lower, "not found") || strings.Contains(lower, "not_found") || strings.Contains(lower, "notfound"):
+		if strings.Contains(lower, "secret version") {
+			version := "unknown"
+			if match := gcloudVersionPattern.FindStringSubmatch(output); match != nil {
+				version = match[1]
+			}
+			return &NotFoundError{Secret: secretName, Version: version}
+		}
+		return &NotFoundError{Secret: secretName, Name: userInputName}
+	}
+	return nil
+}
+
+// exitCodeFor returns the process exit code for an error returned by a command
+func exitCodeFor(err error) int {
+	var coded interface{ ExitCode() int }
+	if errors.As(err, &coded) {
+		return coded.ExitCode()
+	}
+	return exitCodeError
+}
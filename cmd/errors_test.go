@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestClassifyGcloudFailure tests recognizing create and update failures in gcloud stderr
+func TestClassifyGcloudFailure(t *testing.T) {
+	tests := []struct {
+		name         string
+		output       string
+		expectedCode int
+		expectedMsg  string
+	}{
+		{
+			name:         "create conflict",
+			output:       "ERROR: (gcloud.secrets.create) Resource in projects [my-project] is the subject of a conflict: Secret [projects/123/secrets/team-db] already exists.\n",
+			expectedCode: exitCodeAlreadyExists,
+			expectedMsg:  "secret 'team-db' already exists. Use `gsecutil update db` to create a new version",
+		},
+		{
+			name:         "missing secret on versions add",
+			output:       "ERROR: (gcloud.secrets.versions.add) NOT_FOUND: Secret [projects/123/secrets/team-db] not found or has no versions.\n",
+			expectedCode: exitCodeNotFound,
+			expectedMsg:  "secret 'team-db' not found. Use `gsecutil create db` to create it",
+		},
+		{
+			name:         "missing version",
+			output:       "ERROR: (gcloud.secrets.versions.access) NOT_FOUND: Secret Version [projects/123/secrets/team-db/versions/7] not found.\n",
+			expectedCode: exitCodeNotFound,
+			expectedMsg:  "version '7' of secret 'team-db' not found",
+		},
+		{
+			name:         "permission denied is not classified",
+			output:       "ERROR: (gcloud.secrets.create) PERMISSION_DENIED: Permission 'secretmanager.secrets.create' denied.\n",
+			expectedCode: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classifyGcloudFailure(tt.output, "team-db", "db")
+			if tt.expectedCode == 0 {
+				if err != nil {
+					t.Fatalf("classifyGcloudFailure() = %v, expected nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("classifyGcloudFailure() = nil, expected a typed error")
+			}
+			if err.Error() != tt.expectedMsg {
+				t.Errorf("Error() = %q, expected %q", err.Error(), tt.expectedMsg)
+			}
+			if code := exitCodeFor(err); code != tt.expectedCode {
+				t.Errorf("exitCodeFor() = %d, expected %d", code, tt.expectedCode)
+			}
+		})
+	}
+}
+
+// TestExitCodeFor tests that typed errors keep their exit code when wrapped
+func TestExitCodeFor(t *testing.T) {
+	wrapped := fmt.Errorf("failed to get active versions: %w", &NotFoundError{Secret: "team-db"})
+	if code := exitCodeFor(wrapped); code != exitCodeNotFound {
+		t.Errorf("exitCodeFor(wrapped not found) = %d, expected %d", code, exitCodeNotFound)
+	}
+	if code := exitCodeFor(errors.New("boom")); code != exitCodeError {
+		t.Errorf("exitCodeFor(plain error) = %d, expected %d", code, exitCodeError)
+	}
+
+	named := withUserInputName(wrapped, "db")
+	if !strings.Contains(named.Error(), "gsecutil create db") {
+		t.Errorf("withUserInputName() = %q, expected a create suggestion", named.Error())
+	}
+}
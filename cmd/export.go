@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -19,11 +24,61 @@ The CSV includes secret names, values, labels, and metadata from the configurati
 If no output file is specified, output is written to stdout.
 
 The exported CSV can be edited in Excel or other spreadsheet applications and
-re-imported using the 'import' command.`,
+re-imported using the 'import' command.
+
+Use --redact to produce a safe-to-share inventory: each value is replaced by a
+SHA-256 fingerprint placeholder in a 'value:redacted' column, keeping names,
+labels, and attributes intact. --redact implies --with-values. Redacted exports
+cannot be re-imported.
+
+Use --format json to write a JSON manifest instead of CSV. Importing a manifest
+with 'import secrets.json' reproduces the same names, labels, titles, and
+attributes. --assert-roundtrip re-reads the export the way a dry-run import
+would and reports any differences from the exported secrets.
+
+Use --version-column to add a 'version' column with the number of the version
+each row describes (the latest version). With --with-values the value is read
+from exactly that version, so the export records where each value came from.
+
+Columns normally follow the data: one per label key and config attribute that
+the exported secrets have. Use --columns to fix the exact columns and their
+order instead, so the header stays the same however the secrets change.
+Requested labels and attributes that a secret lacks are left empty. Column
+names are name, title, value (needs --with-values), value:redacted (needs
+--redact), version (needs --version-column), label:<key>, or an attribute
+name used in the configuration file; name is required and anything else is
+an error.
+
+Use --changed-since to export only the secrets whose latest version was
+created after a point in time, for incremental sync jobs. The value is an
+RFC 3339 timestamp, a date (YYYY-MM-DD, midnight UTC), or a duration such as
+24h counted back from now. This reads the latest version metadata of every
+listed secret (one extra gcloud call per secret, up to 10 at a time); secrets
+whose change time cannot be determined are exported with a warning.
+
+Use --sort-attr to order the rows by a configuration file attribute (such as
+environment) instead of by name. Secrets without the attribute come last.
+
+Use --with-header-comment to start a CSV export with a provenance line such as
+"# exported by gsecutil v1.2.0 on 2025-06-01T12:00:00Z from project my-project,
+12 secrets", so archived exports describe themselves. Import recognizes and
+skips this line; other CSV tools may need to be told to skip it.
+
+--summary-only replaces the line naming the output file with just the number
+of exported secrets.`,
 	Example: `  gsecutil export secrets.csv
   gsecutil export secrets.csv --with-values
   gsecutil export > secrets.csv
-  gsecutil export --filter "labels.env=prod" secrets.csv`,
+  gsecutil export --filter "labels.env=prod" secrets.csv
+  gsecutil export --filter-not "env=prod" secrets.csv
+  gsecutil export --with-values --redact inventory.csv
+  gsecutil export --format json --assert-roundtrip secrets.json
+  gsecutil export --with-values --version-column backup.csv
+  gsecutil export --columns name,title,label:env,owner inventory.csv
+  gsecutil export --changed-since 2025-06-01T00:00:00Z --format json changes.json
+  gsecutil export --changed-since 24h --with-values changes.csv
+  gsecutil export --sort-attr environment inventory.csv
+  gsecutil export --with-header-comment archive.csv`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runExport,
 }
@@ -32,68 +87,246 @@ func init() {
 	rootCmd.AddCommand(exportCmd)
 	exportCmd.Flags().Bool("with-values", false, "Include secret values in export (use with caution)")
 	exportCmd.Flags().String("filter", "", "Filter secrets by label")
+	exportCmd.Flags().String("filter-not", "", "Exclude secrets with matching labels (format: key=value,key2 - a bare key matches any value)")
+	exportCmd.Flags().Bool("redact", false, "Replace secret values with SHA-256 fingerprints (implies --with-values)")
+	exportCmd.Flags().String("format", "csv", "Output format: csv or json")
+	exportCmd.Flags().Bool("assert-roundtrip", false, "Verify that re-importing the export reproduces the same secrets")
+	exportCmd.Flags().Bool("version-column", false, "Add a 'version' column with the exported version number of each secret")
+	exportCmd.Flags().String("columns", "", "Comma-separated list of columns to export, in order (e.g., name,title,label:env,owner)")
+	exportCmd.Flags().String("changed-since", "", "Only export secrets whose latest version was created after this time (RFC 3339, YYYY-MM-DD, or a duration like 24h)")
+	exportCmd.Flags().String("sort-attr", "", "Sort rows by the value of a configuration file attribute (e.g., environment); secrets without it come last")
+	exportCmd.Flags().Bool("with-header-comment", false, "Start the CSV with a '# exported by gsecutil' line naming the version, time, project, and secret count")
+	exportCmd.Flags().Bool("summary-only", false, "Report only the number of exported secrets, not the output file")
 }
 
 func runExport(cmd *cobra.Command, args []string) error {
 	project, _ := cmd.Flags().GetString("project")
-	project = GetProject(project)
+	project, err := resolveProject(project)
+	if err != nil {
+		return err
+	}
 	exportWithValues, _ := cmd.Flags().GetBool("with-values")
 	exportFilter, _ := cmd.Flags().GetString("filter")
+	exportFilterNot, _ := cmd.Flags().GetString("filter-not")
+	exportRedact, _ := cmd.Flags().GetBool("redact")
+	exportFormat, _ := cmd.Flags().GetString("format")
+	assertRoundTrip, _ := cmd.Flags().GetBool("assert-roundtrip")
+	versionColumn, _ := cmd.Flags().GetBool("version-column")
+	columnsValue, _ := cmd.Flags().GetString("columns")
+	changedSinceValue, _ := cmd.Flags().GetString("changed-since")
+	summaryOnly, _ := cmd.Flags().GetBool("summary-only")
+	sortAttr, _ := cmd.Flags().GetString("sort-attr")
+	headerComment, _ := cmd.Flags().GetBool("with-header-comment")
+
+	if exportFormat != "csv" && exportFormat != "json" {
+		return fmt.Errorf("unsupported format '%s': use csv or json", exportFormat)
+	}
+	if headerComment && exportFormat != "csv" {
+		return fmt.Errorf("--with-header-comment only applies to CSV exports")
+	}
+
+	exclusions, err := parseLabelExclusions(exportFilterNot)
+	if err != nil {
+		return err
+	}
+
+	var columns []string
+	if cmd.Flags().Changed("columns") {
+		if columns, err = parseExportColumns(columnsValue); err != nil {
+			return err
+		}
+	}
+
+	if cmd.Flags().Changed("sort-attr") && sortAttr == "" {
+		return fmt.Errorf("--sort-attr requires an attribute name")
+	}
+
+	var changedSince time.Time
+	if cmd.Flags().Changed("changed-since") {
+		if changedSince, err = parseChangedSince(changedSinceValue, time.Now()); err != nil {
+			return err
+		}
+	}
 
 	// Get list of secrets
 	secrets, err := fetchSecretsForExport(project, exportFilter)
 	if err != nil {
 		return err
 	}
+	secrets = excludeSecretsByLabels(secrets, exclusions)
+
+	if !changedSince.IsZero() {
+		enrichSecretsWithVersionTimes(secrets, project)
+		var unknown []string
+		secrets, unknown = secretsChangedSince(secrets, changedSince)
+		for _, name := range unknown {
+			fmt.Fprintf(os.Stderr, "Warning: could not determine when secret '%s' last changed; exporting it\n", name)
+		}
+	}
 
 	if len(secrets) == 0 {
 		fmt.Println("No secrets found to export")
 		return nil
 	}
+	sortSecretsByAttribute(secrets, sortAttr)
 
 	// Prepare CSV data
-	records := prepareCsvRecords(secrets, exportWithValues, project)
+	records := prepareCsvRecords(secrets, exportWithValues || exportRedact, exportRedact, versionColumn, project)
+	if columns != nil {
+		if records, err = selectExportColumns(records, columns, configAttributeNames()); err != nil {
+			return err
+		}
+	}
+
+	data, err := encodeExportRecords(records, exportFormat)
+	if err != nil {
+		return err
+	}
+	if headerComment {
+		comment := exportHeaderComment(rootCmd.Version, time.Now(), project, len(secrets))
+		data = append([]byte(comment+"\n"), data...)
+	}
+
+	if assertRoundTrip {
+		if err := assertExportRoundTrip(secrets, data, exportFormat); err != nil {
+			return err
+		}
+	}
 
 	// Write to file or stdout
-	var writer *csv.Writer
 	if len(args) > 0 {
-		file, err := os.Create(args[0])
-		if err != nil {
+		// Plaintext values must only be readable by the owner
+		perm := os.FileMode(0644)
+		if exportWithValues && !exportRedact {
+			perm = 0600
+		}
+		if err := atomicWriteFile(args[0], data, perm); err != nil {
 			return fmt.Errorf("failed to create output file: %w", err)
 		}
-		defer file.Close()
-		writer = csv.NewWriter(file)
-		defer writer.Flush()
-	} else {
-		writer = csv.NewWriter(os.Stdout)
-		defer writer.Flush()
+		if summaryOnly {
+			fmt.Printf("Exported %d secrets\n", len(secrets))
+		} else {
+			fmt.Printf("Exported %d secrets to %s\n", len(secrets), args[0])
+		}
+		return nil
+	}
+
+	if _, err := os.Stdout.Write(data); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// parseChangedSince parses the --changed-since value: an RFC 3339 timestamp,
+// a YYYY-MM-DD date (midnight UTC), or a duration counted back from now
+func parseChangedSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
 	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --changed-since '%s': use an RFC 3339 timestamp (2025-06-01T00:00:00Z), a date (2025-06-01), or a duration (24h)", value)
+}
 
-	// Write CSV
-	for _, record := range records {
-		if err := writer.Write(record); err != nil {
-			return fmt.Errorf("failed to write CSV: %w", err)
+// secretsChangedSince keeps the secrets whose latest version was created
+// after cutoff. Secrets without a known version time are kept too, so an
+// incremental sync never misses a change; their names are returned as well.
+func secretsChangedSince(secrets []SecretInfo, cutoff time.Time) ([]SecretInfo, []string) {
+	var changed []SecretInfo
+	var unknown []string
+	for _, secret := range secrets {
+		switch {
+		case secret.LatestVersionTime.IsZero():
+			unknown = append(unknown, extractSecretName(secret.Name))
+			changed = append(changed, secret)
+		case secret.LatestVersionTime.After(cutoff):
+			changed = append(changed, secret)
 		}
 	}
+	return changed, unknown
+}
 
-	if len(args) > 0 {
-		fmt.Printf("Exported %d secrets to %s\n", len(secrets), args[0])
+// encodeExportRecords serializes export records as CSV or as a JSON manifest
+func encodeExportRecords(records [][]string, format string) ([]byte, error) {
+	if format == "json" {
+		jsonOutput, err := json.MarshalIndent(csvRecordsToManifest(records), "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal JSON output: %w", err)
+		}
+		return append(jsonOutput, '\n'), nil
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.WriteAll(records); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// exportHeaderCommentPrefix starts the provenance line of
+// --with-header-comment, which import skips
+const exportHeaderCommentPrefix = "# exported by gsecutil"
+
+// exportHeaderComment returns the provenance line written before the CSV header
+func exportHeaderComment(version string, now time.Time, project string, count int) string {
+	if version == "" {
+		version = "dev"
+	}
+	return fmt.Sprintf("%s v%s on %s from project %s, %d secrets",
+		exportHeaderCommentPrefix, strings.TrimPrefix(version, "v"), now.UTC().Format(time.RFC3339), project, count)
+}
+
+// assertExportRoundTrip re-reads serialized export data the way a dry-run
+// import would and fails if the result differs from the exported secrets.
+// The report goes to stderr so it never mixes with export data on stdout.
+func assertExportRoundTrip(secrets []SecretInfo, data []byte, format string) error {
+	var header []string
+	var records [][]string
+	var err error
+	if format == "json" {
+		header, records, err = parseManifest(data)
+	} else {
+		records, header, err = readCsvData(bytes.NewReader(data))
+	}
+	if err != nil {
+		return fmt.Errorf("round-trip check failed to re-read export: %w", err)
+	}
+
+	reimported, differences, err := reimportedRoundTripSecrets(header, records)
+	if err != nil {
+		return fmt.Errorf("round-trip check failed: %w", err)
+	}
+	differences = append(differences, compareRoundTrip(expectedRoundTripSecrets(secrets), reimported)...)
+
+	if len(differences) > 0 {
+		fmt.Fprintln(os.Stderr, "Round-trip differences:")
+		for _, difference := range differences {
+			fmt.Fprintf(os.Stderr, "  - %s\n", difference)
+		}
+		return fmt.Errorf("round-trip check found %d difference(s)", len(differences))
 	}
 
+	fmt.Fprintf(os.Stderr, "Round-trip check passed: %d secrets re-import identically\n", len(secrets))
 	return nil
 }
 
 func fetchSecretsForExport(project, filter string) ([]SecretInfo, error) {
-	secrets, err := fetchSecrets(project, filter, 0)
+	secrets, err := secretManager.List(project, filter, 0)
 	if err != nil {
 		return nil, err
 	}
 
 	// Filter by prefix if configured (consistent with list command)
-	if prefix := GetPrefix(); prefix != "" {
+	if GetPrefix() != "" {
 		var filtered []SecretInfo
 		for _, s := range secrets {
-			if strings.HasPrefix(extractSecretName(s.Name), prefix) {
+			if FilterSecretsByPrefix(extractSecretName(s.Name)) {
 				filtered = append(filtered, s)
 			}
 		}
@@ -106,10 +339,27 @@ func fetchSecretsForExport(project, filter string) ([]SecretInfo, error) {
 	return secrets, nil
 }
 
-func prepareCsvRecords(secrets []SecretInfo, withValues bool, project string) [][]string {
+// redactedValueColumn is the header used for fingerprinted values in redacted exports
+const redactedValueColumn = "value:redacted"
+
+// redactSecretValue replaces a secret value with a short SHA-256 fingerprint so
+// that values can be compared across exports without revealing their contents
+func redactSecretValue(value string) string {
+	if value == secretValueErrorPlaceholder {
+		return value
+	}
+	sum := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(sum[:])[:16]
+}
+
+// prepareCsvRecords builds the export header and rows. withVersion adds a
+// version column holding the latest version number, which is then also the
+// version the value is read from.
+func prepareCsvRecords(secrets []SecretInfo, withValues, redact, withVersion bool, project string) [][]string {
 	// Collect all unique label keys and config attributes
 	labelKeys := make(map[string]bool)
 	configAttrs := make(map[string]bool)
+	prefix := GetPrefix()
 
 	for _, secret := range secrets {
 		name := extractSecretName(secret.Name)
@@ -119,8 +369,8 @@ func prepareCsvRecords(secrets []SecretInfo, withValues bool, project string) []
 			labelKeys[key] = true
 		}
 
-		// Collect config attributes using full name
-		if credInfo := GetCredentialInfo(name); credInfo != nil {
+		// Collect config attributes (config stores bare names)
+		if credInfo := GetCredentialInfo(strings.TrimPrefix(name, prefix)); credInfo != nil {
 			for key := range credInfo.Attributes {
 				configAttrs[key] = true
 			}
@@ -143,7 +393,14 @@ func prepareCsvRecords(secrets []SecretInfo, withValues bool, project string) []
 	// Build header
 	header := []string{"name"}
 	if withValues {
-		header = append(header, "value")
+		if redact {
+			header = append(header, redactedValueColumn)
+		} else {
+			header = append(header, "value")
+		}
+	}
+	if withVersion {
+		header = append(header, versionColumn)
 	}
 	header = append(header, "title")
 	for _, key := range labelKeysSorted {
@@ -160,14 +417,31 @@ func prepareCsvRecords(secrets []SecretInfo, withValues bool, project string) []
 		name := extractSecretName(secret.Name)
 		row := []string{name} // export full secret name (with prefix)
 
+		// Pin the version first so the value and version column always agree
+		version := ""
+		if withVersion {
+			version = latestVersionNumber(name, project)
+		}
+
 		// Add value if requested
 		if withValues {
-			value := getSecretValue(name, project)
+			var value string
+			if version != "" {
+				value = getSecretVersionValue(name, version, project)
+			} else {
+				value = getSecretValue(name, project)
+			}
+			if redact {
+				value = redactSecretValue(value)
+			}
 			row = append(row, value)
 		}
+		if withVersion {
+			row = append(row, version)
+		}
 
 		// Add title from config
-		credInfo := GetCredentialInfo(name)
+		credInfo := GetCredentialInfo(strings.TrimPrefix(name, prefix))
 		if credInfo != nil && credInfo.Title != "" {
 			row = append(row, credInfo.Title)
 		} else {
@@ -201,3 +475,93 @@ func prepareCsvRecords(secrets []SecretInfo, withValues bool, project string) []
 
 	return records
 }
+
+// parseExportColumns splits a --columns value, rejecting empty and repeated
+// names and requiring the name column
+func parseExportColumns(value string) ([]string, error) {
+	var columns []string
+	seen := make(map[string]bool)
+	for _, column := range strings.Split(value, ",") {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			return nil, fmt.Errorf("invalid --columns '%s': empty column name", value)
+		}
+		if seen[column] {
+			return nil, fmt.Errorf("invalid --columns '%s': column '%s' is listed more than once", value, column)
+		}
+		seen[column] = true
+		columns = append(columns, column)
+	}
+	if !seen["name"] {
+		return nil, fmt.Errorf("invalid --columns '%s': the name column is required", value)
+	}
+	return columns, nil
+}
+
+// selectExportColumns rebuilds records with exactly the given columns in the
+// given order. Label columns and attributes in knownAttributes that the
+// records lack are filled with empty strings; value and version columns must
+// have been produced by their flags, and any other column is unknown.
+func selectExportColumns(records [][]string, columns []string, knownAttributes map[string]bool) ([][]string, error) {
+	index := make(map[string]int, len(records[0]))
+	for i, column := range records[0] {
+		index[column] = i
+	}
+
+	for _, column := range columns {
+		if _, ok := index[column]; ok {
+			continue
+		}
+		switch {
+		case column == "value":
+			return nil, fmt.Errorf("column 'value' requires --with-values")
+		case column == redactedValueColumn:
+			return nil, fmt.Errorf("column '%s' requires --redact", redactedValueColumn)
+		case column == versionColumn:
+			return nil, fmt.Errorf("column '%s' requires --version-column", versionColumn)
+		case strings.HasPrefix(column, "label:") && len(column) > len("label:"):
+		case knownAttributes[column]:
+		default:
+			return nil, fmt.Errorf("unknown column '%s' (use name, title, value, value:redacted, version, label:<key>, or an attribute from the configuration file)", column)
+		}
+	}
+
+	selected := make([][]string, 0, len(records))
+	selected = append(selected, columns)
+	for _, record := range records[1:] {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			if position, ok := index[column]; ok {
+				row[i] = record[position]
+			}
+		}
+		selected = append(selected, row)
+	}
+	return selected, nil
+}
+
+// configAttributeNames returns every attribute name used by a credential in
+// the configuration file or listed in list.attributes
+func configAttributeNames() map[string]bool {
+	names := make(map[string]bool)
+	config := GetConfig()
+	for _, credential := range config.Credentials {
+		for key := range credential.Attributes {
+			names[key] = true
+		}
+	}
+	for _, key := range config.List.Attributes {
+		names[key] = true
+	}
+	return names
+}
+
+// latestVersionNumber returns the number of the latest version of a secret,
+// or "" when it cannot be determined
+func latestVersionNumber(secretName, project string) string {
+	versionInfo, err := getSecretVersionInfo(secretName, "latest", project)
+	if err != nil {
+		return ""
+	}
+	return extractVersionNumber(versionInfo.Name)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var exportEnvCmd = &cobra.Command{
+	Use:   "export-env [OUTPUT_FILE]",
+	Short: "Export secret values as a .env file",
+	Long: `Export the latest value of every secret as KEY='value' lines for a .env file.
+
+Keys are the secret names without the configured prefix, with dashes turned
+into underscores; --uppercase also uppercases them, so db-password becomes
+DB_PASSWORD. Values are single-quoted so the file can be sourced by a POSIX
+shell. A secret whose name is not a valid environment variable is an error,
+and secrets that map to the same key are reported on stderr.
+
+If no output file is specified, the lines are written to stdout. An output
+file is created with mode 0600, since it holds secret values.`,
+	Example: `  gsecutil export-env .env
+  gsecutil export-env --uppercase > .env
+  gsecutil export-env --filter "labels.env=dev" --uppercase .env.local`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runExportEnv,
+}
+
+func init() {
+	rootCmd.AddCommand(exportEnvCmd)
+	exportEnvCmd.Flags().String("filter", "", "Filter secrets by label")
+	exportEnvCmd.Flags().Bool("uppercase", false, "Uppercase keys (db-password becomes DB_PASSWORD)")
+}
+
+func runExportEnv(cmd *cobra.Command, args []string) error {
+	project, _ := cmd.Flags().GetString("project")
+	project, err := resolveProject(project)
+	if err != nil {
+		return err
+	}
+	filter, _ := cmd.Flags().GetString("filter")
+	uppercase, _ := cmd.Flags().GetBool("uppercase")
+
+	secrets, err := fetchSecretsForExport(project, filter)
+	if err != nil {
+		return err
+	}
+	if len(secrets) == 0 {
+		fmt.Fprintln(os.Stderr, "No secrets found to export")
+		return nil
+	}
+
+	// Validate every key before reading any value
+	names := make([]string, len(secrets))
+	for i, secret := range secrets {
+		names[i] = strings.TrimPrefix(extractSecretName(secret.Name), GetPrefix())
+	}
+	keys, warnings, err := envVarNames(names, envNameOptions{Upper: uppercase, ReplaceDash: true})
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	// Read by full name: re-adding the prefix to a stripped name would miss
+	// a secret whose stripped name still starts with the prefix
+	values := make([]string, len(secrets))
+	for i, secret := range secrets {
+		secretName := extractSecretName(secret.Name)
+		if values[i], err = secretManager.GetValue(secretName, "latest", project); err != nil {
+			return fmt.Errorf("failed to read secret '%s': %w", secretName, err)
+		}
+	}
+	data := []byte(formatEnvLines(keys, values))
+
+	if len(args) > 0 {
+		if err := atomicWriteFile(args[0], data, 0600); err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		fmt.Printf("Exported %d secrets to %s\n", len(secrets), args[0])
+		return nil
+	}
+
+	if _, err := os.Stdout.Write(data); err != nil {
+		return fmt.Errorf("failed to write output: %w", err)
+	}
+	return nil
+}
+
+// formatEnvLines returns one KEY='value' line per key, each ending in a newline
+func formatEnvLines(keys, values []string) string {
+	var b strings.Builder
+	for i, key := range keys {
+		b.WriteString(key + "=" + shellQuote(values[i]) + "\n")
+	}
+	return b.String()
+}
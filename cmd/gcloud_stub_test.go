@@ -0,0 +1,802 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// gcloudStubRulesEnv is set when the test binary runs as a stub gcloud
+// (through GSECUTIL_GCLOUD); it names the JSON file holding the stub's rules
+const gcloudStubRulesEnv = "GSECUTIL_TEST_GCLOUD_RULES"
+
+// gcloudStubLogEnv names the file the stub appends each invocation to
+const gcloudStubLogEnv = "GSECUTIL_TEST_GCLOUD_LOG"
+
+// TestMain lets the test binary double as the stub gcloud: gcloudStub points
+// GSECUTIL_GCLOUD at this binary and sets gcloudStubRulesEnv
+func TestMain(m *testing.M) {
+	if rulesPath := os.Getenv(gcloudStubRulesEnv); rulesPath != "" {
+		os.Exit(runGcloudStub(rulesPath, os.Getenv(gcloudStubLogEnv), os.Args[1:]))
+	}
+	os.Exit(m.Run())
+}
+
+// gcloudStubRule is a canned response. It matches when Args appear in the
+// invocation in order, not necessarily next to each other.
+type gcloudStubRule struct {
+	Args     []string `json:"args"`
+	Stdout   string   `json:"stdout"`
+	Stderr   string   `json:"stderr"`
+	ExitCode int      `json:"exitCode"`
+}
+
+func (r gcloudStubRule) matches(args []string) bool {
+	next := 0
+	for _, arg := range args {
+		if next < len(r.Args) && arg == r.Args[next] {
+			next++
+		}
+	}
+	return next == len(r.Args)
+}
+
+// runGcloudStub answers one gcloud invocation from the first matching rule
+func runGcloudStub(rulesPath, logPath string, args []string) int {
+	if logPath != "" {
+		if line, err := json.Marshal(args); err == nil {
+			if f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600); err == nil {
+				fmt.Fprintln(f, string(line))
+				f.Close()
+			}
+		}
+	}
+
+	data, err := os.ReadFile(rulesPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: stub gcloud cannot read rules: %v\n", err)
+		return 2
+	}
+	var rules []gcloudStubRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: stub gcloud cannot parse rules: %v\n", err)
+		return 2
+	}
+
+	for _, rule := range rules {
+		if rule.matches(args) {
+			fmt.Fprint(os.Stdout, rule.Stdout)
+			fmt.Fprint(os.Stderr, rule.Stderr)
+			return rule.ExitCode
+		}
+	}
+	fmt.Fprintf(os.Stderr, "ERROR: stub gcloud has no rule for: gcloud %s\n", strings.Join(args, " "))
+	return 2
+}
+
+// gcloudStub makes every gcloud call in a test run the test binary, which
+// answers from the rules registered with On
+type gcloudStub struct {
+	t         *testing.T
+	rulesPath string
+	logPath   string
+	rules     []gcloudStubRule
+}
+
+// newGcloudStub installs a stub gcloud for the rest of the test
+func newGcloudStub(t *testing.T) *gcloudStub {
+	t.Helper()
+	executable, err := os.Executable()
+	if err != nil {
+		t.Fatalf("Failed to locate test binary: %v", err)
+	}
+
+	dir := t.TempDir()
+	stub := &gcloudStub{
+		t:         t,
+		rulesPath: filepath.Join(dir, "rules.json"),
+		logPath:   filepath.Join(dir, "calls.log"),
+	}
+	stub.save()
+
+	// The real backend, so commands go through gcloud
+	originalManager := secretManager
+	t.Cleanup(func() { secretManager = originalManager })
+	secretManager = gcloudSecretManager{}
+
+	t.Setenv("GSECUTIL_GCLOUD", executable)
+	t.Setenv(gcloudStubRulesEnv, stub.rulesPath)
+	t.Setenv(gcloudStubLogEnv, stub.logPath)
+	return stub
+}
+
+// On registers stdout for invocations containing args, in order
+func (s *gcloudStub) On(stdout string, args ...string) {
+	s.rules = append(s.rules, gcloudStubRule{Args: args, Stdout: stdout})
+	s.save()
+}
+
+// Fail registers a failure with stderr for invocations containing args
+func (s *gcloudStub) Fail(stderr string, args ...string) {
+	s.rules = append(s.rules, gcloudStubRule{Args: args, Stderr: stderr, ExitCode: 1})
+	s.save()
+}
+
+// Calls returns the arguments of every invocation so far
+func (s *gcloudStub) Calls() [][]string {
+	s.t.Helper()
+	f, err := os.Open(s.logPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		s.t.Fatalf("Failed to read stub gcloud log: %v", err)
+	}
+	defer f.Close()
+
+	var calls [][]string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var args []string
+		if err := json.Unmarshal(scanner.Bytes(), &args); err != nil {
+			s.t.Fatalf("Failed to parse stub gcloud log: %v", err)
+		}
+		calls = append(calls, args)
+	}
+	return calls
+}
+
+func (s *gcloudStub) save() {
+	s.t.Helper()
+	data, err := json.Marshal(s.rules)
+	if err != nil {
+		s.t.Fatalf("Failed to encode stub gcloud rules: %v", err)
+	}
+	if err := os.WriteFile(s.rulesPath, data, 0600); err != nil {
+		s.t.Fatalf("Failed to write stub gcloud rules: %v", err)
+	}
+}
+
+// TestGcloudStubRuleMatches tests argument matching of stub rules
+func TestGcloudStubRuleMatches(t *testing.T) {
+	args := []string{"secrets", "versions", "access", "latest", "--secret", "db", "--project", "p"}
+	tests := []struct {
+		name     string
+		rule     []string
+		expected bool
+	}{
+		{name: "Exact", rule: args, expected: true},
+		{name: "In order with gaps", rule: []string{"secrets", "access", "--secret", "db"}, expected: true},
+		{name: "No arguments matches everything", rule: nil, expected: true},
+		{name: "Out of order", rule: []string{"--secret", "secrets"}, expected: false},
+		{name: "Missing argument", rule: []string{"secrets", "list"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (gcloudStubRule{Args: tt.rule}).matches(args); got != tt.expected {
+				t.Errorf("matches(%v) = %v, expected %v", tt.rule, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestGetThroughGcloud runs the get command end to end against the stub gcloud
+func TestGetThroughGcloud(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Prefix: "team-"}
+
+	stub := newGcloudStub(t)
+	stub.On("s3cr3t\n", "secrets", "versions", "access", "latest", "--secret", "team-db", "--project", "test-project")
+	stub.Fail("ERROR: (gcloud.secrets.versions.access) NOT_FOUND: Secret [projects/1/secrets/team-missing] not found or has no versions.\n",
+		"secrets", "versions", "access", "--secret", "team-missing")
+
+	output, err := executeCommand(t, "get", "db", "--project", "test-project")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if output != "s3cr3t\n" {
+		t.Errorf("Output = %q, expected %q", output, "s3cr3t\n")
+	}
+
+	_, err = executeCommand(t, "get", "missing", "--project", "test-project")
+	if code := exitCodeFor(err); code != exitCodeNotFound {
+		t.Errorf("Expected not-found exit code %d, got %d (%v)", exitCodeNotFound, code, err)
+	}
+
+	if calls := stub.Calls(); len(calls) != 2 {
+		t.Errorf("Expected 2 gcloud calls, got %d: %v", len(calls), calls)
+	}
+}
+
+// TestListThroughGcloud runs the list command end to end against the stub gcloud
+func TestListThroughGcloud(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{
+		Prefix:      "team-",
+		Credentials: []CredentialInfo{{Name: "db", Title: "Database"}},
+	}
+
+	stub := newGcloudStub(t)
+	stub.On(`[
+  {"name": "projects/test-project/secrets/team-db", "createTime": "2025-03-01T12:00:00Z", "labels": {"env": "prod"}},
+  {"name": "projects/test-project/secrets/team-api", "createTime": "2025-03-02T12:00:00Z"},
+  {"name": "projects/test-project/secrets/other", "createTime": "2025-03-03T12:00:00Z"}
+]`, "secrets", "list", "--format", "json", "--project", "test-project")
+
+	output, err := executeCommand(t, "list", "--project", "test-project", "--show-labels")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	for _, expected := range []string{"Database", "env=prod", "2025-03-01 12:00", "api"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "other") {
+		t.Errorf("Expected secrets without the prefix to be hidden:\n%s", output)
+	}
+}
+
+// TestGetExistingSecretNamesThroughGcloud tests that a prefix-scoped lookup
+// passes the prefix to gcloud as a filter and keeps only prefixed secrets
+func TestGetExistingSecretNamesThroughGcloud(t *testing.T) {
+	stub := newGcloudStub(t)
+	prefixFilter := "name~'^projects/[^/]+/secrets/team-'"
+	// The stub ignores the filter, so an unprefixed secret still comes back
+	stub.On("projects/1/secrets/team-db\nprojects/1/secrets/team-api\n\nprojects/1/secrets/other\n",
+		"secrets", "list", "--filter", prefixFilter, "--project", "test-project")
+	stub.Fail("ERROR: (gcloud.secrets.list) PERMISSION_DENIED: Permission denied on resource project denied-project.\n",
+		"secrets", "list", "--project", "denied-project")
+
+	names, err := getExistingSecretNames("test-project", "team-")
+	if err != nil {
+		t.Fatalf("getExistingSecretNames() failed: %v", err)
+	}
+	if len(names) != 2 || !names["team-db"] || !names["team-api"] {
+		t.Errorf("names = %v, expected team-db and team-api", names)
+	}
+
+	if _, err := getExistingSecretNames("denied-project", ""); err == nil || !strings.Contains(err.Error(), "ermission") {
+		t.Errorf("Expected the gcloud failure to be reported, got %v", err)
+	}
+
+	calls := stub.Calls()
+	if len(calls) != 2 {
+		t.Fatalf("Expected 2 gcloud calls, got %d: %v", len(calls), calls)
+	}
+	for _, arg := range calls[1] {
+		if arg == "--filter" {
+			t.Errorf("Expected no filter without a prefix, got %v", calls[1])
+		}
+	}
+}
+
+// TestAccessListThroughGcloud runs access list end to end against the stub gcloud
+func TestAccessListThroughGcloud(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{}
+
+	stub := newGcloudStub(t)
+	stub.On(`{
+  "bindings": [
+    {"role": "roles/secretmanager.secretAccessor", "members": ["user:alice@example.com", "serviceAccount:app@test-project.iam.gserviceaccount.com"]},
+    {"role": "roles/secretmanager.viewer", "members": ["group:ops@example.com"]}
+  ],
+  "etag": "BwX"
+}`, "secrets", "get-iam-policy", "db", "--format", "json")
+
+	output, err := executeCommand(t, "access", "list", "db", "--project", "test-project", "--format", "json")
+	if err != nil {
+		t.Fatalf("access list failed: %v", err)
+	}
+	var report SecretAccessReport
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("Failed to parse output: %v\n%s", err, output)
+	}
+	if report.Secret != "db" || len(report.Bindings) != 2 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	if report.Bindings[0].Role != "roles/secretmanager.secretAccessor" || len(report.Bindings[0].Members) != 2 {
+		t.Errorf("Unexpected first binding: %+v", report.Bindings[0])
+	}
+	if report.Project != "" || report.ProjectBindings != nil {
+		t.Errorf("Expected no project bindings without --include-project, got %+v", report)
+	}
+
+	stub.On(`{"bindings": [
+    {"role": "roles/owner", "members": ["user:root@example.com"]},
+    {"role": "roles/logging.viewer", "members": ["user:bob@example.com"]},
+    {"role": "roles/secretmanager.viewer", "members": ["group:audit@example.com"]}
+  ]}`, "projects", "get-iam-policy", "test-project", "--format", "json")
+	output, err = executeCommand(t, "access", "list", "db", "--project", "test-project", "--format", "json", "--include-project")
+	if err != nil {
+		t.Fatalf("access list --include-project failed: %v", err)
+	}
+	report = SecretAccessReport{}
+	if err := json.Unmarshal([]byte(output), &report); err != nil {
+		t.Fatalf("Failed to parse output: %v\n%s", err, output)
+	}
+	if report.Project != "test-project" || len(report.Bindings) != 2 || len(report.ProjectBindings) != 2 ||
+		report.ProjectBindings[0].Role != "roles/owner" || report.ProjectBindings[1].Role != "roles/secretmanager.viewer" {
+		t.Errorf("Expected the owner and viewer project bindings, got %+v", report)
+	}
+
+	output, err = executeCommand(t, "access", "list", "db", "--project", "test-project", "--format", "table")
+	if err != nil {
+		t.Fatalf("access list failed: %v", err)
+	}
+	for _, expected := range []string{"alice@example.com", "ops@example.com"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, output)
+		}
+	}
+}
+
+// TestAccessListIncludeAncestorsThroughGcloud tests inherited folder and
+// organization roles, with an organization policy the caller cannot read
+func TestAccessListIncludeAncestorsThroughGcloud(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{}
+
+	stub := newGcloudStub(t)
+	stub.On(`{"bindings": [{"role": "roles/secretmanager.viewer", "members": ["user:alice@example.com"]}]}`,
+		"secrets", "get-iam-policy", "db")
+	stub.On(`[
+  {"id": "test-project", "type": "project"},
+  {"id": "123", "type": "folder"},
+  {"id": "456", "type": "organization"}
+]`, "projects", "get-ancestors", "test-project")
+	stub.On(`{"bindings": [
+  {"role": "roles/secretmanager.secretAccessor", "members": ["group:apps@example.com"]},
+  {"role": "roles/resourcemanager.folderViewer", "members": ["user:bob@example.com"]}
+]}`, "resource-manager", "folders", "get-iam-policy", "123")
+	stub.Fail("ERROR: (gcloud.organizations.get-iam-policy) [alice@example.com] does not have permission to access organizations instance [456] (or it may not exist): The caller does not have permission\n",
+		"organizations", "get-iam-policy", "456")
+
+	output, err := executeCommand(t, "access", "list", "db", "--project", "test-project", "--include-ancestors")
+	if err != nil {
+		t.Fatalf("access list failed: %v", err)
+	}
+	for _, expected := range []string{
+		"alice@example.com",
+		"--- Folder-Level Permissions (Folder: 123) ---",
+		"apps@example.com",
+		"Origin: folder 123",
+		"--- Organization-Level Permissions (Organization: 456) ---",
+		"No permission to read the IAM policy of organization 456 (requires resourcemanager.organizations.getIamPolicy)",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "bob@example.com") {
+		t.Errorf("Expected roles without Secret Manager access to be hidden:\n%s", output)
+	}
+
+	_, err = executeCommand(t, "access", "list", "db", "--include-ancestors", "--format", "json")
+	if err == nil || !strings.Contains(err.Error(), "--include-ancestors") {
+		t.Errorf("Expected --format to be rejected with --include-ancestors, got %v", err)
+	}
+}
+
+// TestAuditlogThroughGcloud runs auditlog end to end against the stub gcloud
+func TestAuditlogThroughGcloud(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{}
+
+	stub := newGcloudStub(t)
+	stub.On(`[
+  {
+    "timestamp": "2025-03-01T12:00:00Z",
+    "severity": "INFO",
+    "protoPayload": {
+      "authenticationInfo": {"principalEmail": "alice@example.com"},
+      "methodName": "google.cloud.secretmanager.v1.SecretManagerService.AccessSecretVersion",
+      "resourceName": "projects/test-project/secrets/db/versions/1"
+    }
+  }
+]`, "logging", "read", "--format", "json", "--project", "test-project")
+
+	output, err := executeCommand(t, "auditlog", "db", "--project", "test-project")
+	if err != nil {
+		t.Fatalf("auditlog failed: %v", err)
+	}
+	for _, expected := range []string{"alice@example.com", "ACCESS"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, output)
+		}
+	}
+
+	calls := stub.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("Expected 1 gcloud call, got %d: %v", len(calls), calls)
+	}
+	if filter := calls[0][2]; !strings.Contains(filter, "secretmanager.googleapis.com") || !strings.Contains(filter, "db") {
+		t.Errorf("Expected a Secret Manager filter for db, got %q", filter)
+	}
+}
+
+// TestAccessGrantConditionThroughGcloud runs access grant with an IAM
+// condition against the stub gcloud
+func TestAccessGrantConditionThroughGcloud(t *testing.T) {
+	stub := newGcloudStub(t)
+	stub.On("", "secrets", "add-iam-policy-binding", "db", "--member", "user:alice@example.com",
+		"--condition", `title=temp,expression=request.time < timestamp("2026-01-01T00:00:00Z")`)
+	stub.Fail("ERROR: (gcloud.secrets.add-iam-policy-binding) INVALID_ARGUMENT: Invalid Condition Expression.\n",
+		"secrets", "add-iam-policy-binding", "db", "--member", "user:bob@example.com")
+
+	output, err := executeCommand(t, "access", "grant", "db", "--project", "test-project", "--principal", "user:alice@example.com",
+		"--condition-title", "temp", "--condition-expression", `request.time < timestamp("2026-01-01T00:00:00Z")`)
+	if err != nil {
+		t.Fatalf("access grant failed: %v", err)
+	}
+	if !strings.Contains(output, "with condition 'temp'") {
+		t.Errorf("Expected the condition in the output, got %q", output)
+	}
+
+	_, err = executeCommand(t, "access", "grant", "db", "--project", "test-project", "--principal", "user:bob@example.com",
+		"--condition-title", "temp", "--condition-expression", "request.time <")
+	if err == nil || !strings.Contains(err.Error(), "Invalid Condition Expression") {
+		t.Errorf("Expected the gcloud error to be surfaced, got %v", err)
+	}
+
+	if _, err := executeCommand(t, "access", "grant", "db", "--project", "test-project", "--principal", "user:bob@example.com",
+		"--condition-title", "temp"); err == nil || !strings.Contains(err.Error(), "--condition-expression is required") {
+		t.Errorf("Expected a missing expression error, got %v", err)
+	}
+	if calls := stub.Calls(); len(calls) != 2 {
+		t.Errorf("Expected 2 gcloud calls, got %d: %v", len(calls), calls)
+	}
+}
+
+// TestAccessGrantMultiplePrincipalsThroughGcloud runs access grant with a
+// repeated --principal, one of which fails, against the stub gcloud
+func TestAccessGrantMultiplePrincipalsThroughGcloud(t *testing.T) {
+	stub := newGcloudStub(t)
+	stub.Fail("ERROR: (gcloud.secrets.add-iam-policy-binding) INVALID_ARGUMENT: User bob@example.com does not exist.\n",
+		"secrets", "add-iam-policy-binding", "db", "--member", "user:bob@example.com")
+	stub.On("", "secrets", "add-iam-policy-binding", "db")
+
+	output, err := executeCommand(t, "access", "grant", "db", "--project", "test-project",
+		"--principal", "user:alice@example.com", "--principal", "user:bob@example.com", "--principal", "not-a-principal",
+		"--principal", "group:devs@example.com")
+	if err == nil || !strings.Contains(err.Error(), "2 of 4 principals: user:bob@example.com, not-a-principal") {
+		t.Errorf("Expected the failed principals in the error, got %v", err)
+	}
+	for _, principal := range []string{"user:alice@example.com", "group:devs@example.com"} {
+		if !strings.Contains(output, "access granted to "+principal) {
+			t.Errorf("Expected a success line for %s, got %q", principal, output)
+		}
+	}
+
+	// The invalid principal is rejected before calling gcloud
+	calls := stub.Calls()
+	if len(calls) != 3 || calls[2][4] != "group:devs@example.com" {
+		t.Errorf("Expected gcloud calls for alice, bob, and devs, got %v", calls)
+	}
+
+	if _, err := executeCommand(t, "access", "grant", "--project-level", "--project", "test-project", "--force",
+		"--principal", "user:alice@example.com,group:devs@example.com"); err == nil || !strings.Contains(err.Error(), "one principal at a time") {
+		t.Errorf("Expected --project-level to reject several principals, got %v", err)
+	}
+}
+
+// TestAccessApplyThroughGcloud runs access apply on a CSV file against the
+// stub gcloud, with and without --dry-run
+func TestAccessApplyThroughGcloud(t *testing.T) {
+	stub := newGcloudStub(t)
+	stub.Fail("ERROR: (gcloud.secrets.remove-iam-policy-binding) NOT_FOUND: Policy binding not found.\n",
+		"secrets", "remove-iam-policy-binding", "api")
+	stub.On("", "secrets")
+
+	csvPath := filepath.Join(t.TempDir(), "access.csv")
+	rows := "secret,principal,role,action\n" +
+		"db,user:alice@example.com,roles/secretmanager.viewer,grant\n" +
+		"db,group:devs@example.com,,grant\n" +
+		"api,user:bob@example.com,,revoke\n" +
+		"db,user:carol@example.com,,revoke\n"
+	invalidRows := "db,bob@example.com,,grant\n" +
+		"db,user:dave@example.com,secretAccessor,grant\n"
+	if err := os.WriteFile(csvPath, []byte(rows+invalidRows), 0600); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	// An invalid row stops the whole file, before any change
+	for _, args := range [][]string{{"--dry-run"}, {}} {
+		output, err := executeCommand(t, append([]string{"access", "apply", csvPath, "--project", "test-project"}, args...)...)
+		if err == nil || !strings.Contains(err.Error(), "2 invalid row(s)") {
+			t.Errorf("%v: expected both invalid rows to be counted, got %v", args, err)
+		}
+		if !strings.Contains(output, "Error: Row 6:") || !strings.Contains(output, "Error: Row 7: invalid role 'secretAccessor'") || strings.Contains(output, "Would grant") {
+			t.Errorf("%v: unexpected output: %q", args, output)
+		}
+	}
+	if calls := stub.Calls(); len(calls) != 0 {
+		t.Fatalf("Expected no gcloud calls for a file with invalid rows, got %v", calls)
+	}
+
+	if err := os.WriteFile(csvPath, []byte(rows), 0600); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	output, err := executeCommand(t, "access", "apply", csvPath, "--project", "test-project", "--dry-run")
+	if err != nil || !strings.Contains(output, "Would grant: 2") || !strings.Contains(output, "Would revoke: 2") {
+		t.Errorf("Expected a dry-run summary, got %v: %q", err, output)
+	}
+	if calls := stub.Calls(); len(calls) != 0 {
+		t.Fatalf("Expected no gcloud calls for --dry-run, got %v", calls)
+	}
+
+	output, err = executeCommand(t, "access", "apply", csvPath, "--project", "test-project")
+	if err == nil || !strings.Contains(err.Error(), "1 row(s) failed") {
+		t.Errorf("Expected the gcloud failure to be counted, got %v", err)
+	}
+	if !strings.Contains(output, "Granted: 2") || !strings.Contains(output, "Revoked: 1") || !strings.Contains(output, "Error: Row 4 (api)") {
+		t.Errorf("Unexpected output: %q", output)
+	}
+	calls := stub.Calls()
+	if len(calls) != 4 || calls[0][1] != "add-iam-policy-binding" || calls[3][1] != "remove-iam-policy-binding" {
+		t.Errorf("Expected 4 gcloud calls in row order, got %v", calls)
+	}
+}
+
+// TestAccessApplyPublicPrincipalThroughGcloud checks that access apply
+// refuses grants to public principals unless --force is given
+func TestAccessApplyPublicPrincipalThroughGcloud(t *testing.T) {
+	stub := newGcloudStub(t)
+	stub.On("", "secrets")
+
+	csvPath := filepath.Join(t.TempDir(), "access.csv")
+	content := "secret,principal,role,action\n" +
+		"db,allUsers,,grant\n" +
+		"db,allAuthenticatedUsers,,revoke\n"
+	if err := os.WriteFile(csvPath, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	output, err := executeCommand(t, "access", "apply", csvPath, "--project", "test-project")
+	if err == nil || !strings.Contains(output, "refusing to grant access to allUsers without --force") {
+		t.Errorf("Expected the public grant to be refused, got %v: %q", err, output)
+	}
+	if calls := stub.Calls(); len(calls) != 0 {
+		t.Fatalf("Expected no gcloud calls without --force, got %v", calls)
+	}
+
+	if _, err := executeCommand(t, "access", "apply", csvPath, "--project", "test-project", "--force"); err != nil {
+		t.Fatalf("access apply --force failed: %v", err)
+	}
+	calls := stub.Calls()
+	if len(calls) != 2 || calls[0][1] != "add-iam-policy-binding" || calls[0][3] != "--member" || calls[0][4] != "allUsers" {
+		t.Errorf("Expected the public grant to run with --force, got %v", calls)
+	}
+}
+
+// TestAccessDryRunThroughGcloud checks that access grant and revoke with
+// --dry-run print the gcloud command without running it
+func TestAccessDryRunThroughGcloud(t *testing.T) {
+	stub := newGcloudStub(t)
+	t.Setenv("GSECUTIL_GCLOUD", "gcloud")
+
+	output, err := executeCommand(t, "access", "grant", "db", "--project", "test-project", "--dry-run",
+		"--principal", "user:alice@example.com", "--condition-title", "temp", "--condition-expression", "request.time < timestamp('2026-01-01T00:00:00Z')")
+	if err != nil {
+		t.Fatalf("access grant --dry-run failed: %v", err)
+	}
+	expected := `gcloud secrets add-iam-policy-binding db --member user:alice@example.com --role roles/secretmanager.secretAccessor ` +
+		`--condition 'title=temp,expression=request.time < timestamp('\''2026-01-01T00:00:00Z'\'')' --project test-project`
+	if !strings.Contains(output, expected) {
+		t.Errorf("Expected the gcloud command\n%s\nin the output, got %q", expected, output)
+	}
+
+	output, err = executeCommand(t, "access", "revoke", "db", "--project", "test-project", "--dry-run", "--principal", "group:devs@example.com")
+	if err != nil || !strings.Contains(output, "gcloud secrets remove-iam-policy-binding db --member group:devs@example.com") {
+		t.Errorf("Expected the revoke command, got %v: %q", err, output)
+	}
+
+	output, err = executeCommand(t, "access", "grant", "--project-level", "--project", "test-project", "--dry-run", "--principal", "group:sre@example.com")
+	if err != nil || !strings.Contains(output, "gcloud projects add-iam-policy-binding test-project --member group:sre@example.com --role roles/secretmanager.secretAccessor --condition None") {
+		t.Errorf("Expected the project-level command without a confirmation, got %v: %q", err, output)
+	}
+
+	if _, err := executeCommand(t, "access", "grant", "db", "--project", "test-project", "--dry-run", "--principal", "alice@example.com"); err == nil {
+		t.Error("Expected --dry-run to still validate the principal")
+	}
+	if calls := stub.Calls(); len(calls) != 0 {
+		t.Errorf("Expected no gcloud calls with --dry-run, got %v", calls)
+	}
+}
+
+// TestVersionsThroughGcloud runs the versions subcommands against the stub gcloud
+func TestVersionsThroughGcloud(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Prefix: "team-"}
+
+	stub := newGcloudStub(t)
+	stub.Fail("ERROR: (gcloud.secrets.versions.enable) NOT_FOUND: Secret Version [projects/123/secrets/team-db/versions/9] not found.\n",
+		"secrets", "versions", "enable", "9")
+	stub.On("", "secrets", "versions")
+
+	output, err := executeCommand(t, "versions", "disable", "db", "--version", "3", "--project", "test-project")
+	if err != nil || !strings.Contains(output, "Secret 'team-db': version 3 disabled") {
+		t.Errorf("versions disable = %v, %q", err, output)
+	}
+	output, err = executeCommand(t, "versions", "destroy", "db", "--version", "2", "--force", "--project", "test-project")
+	if err != nil || !strings.Contains(output, "version 2 destroyed") {
+		t.Errorf("versions destroy --force = %v, %q", err, output)
+	}
+
+	var notFound *NotFoundError
+	if _, err := executeCommand(t, "versions", "enable", "db", "--version", "9", "--project", "test-project"); !errors.As(err, &notFound) || notFound.Version != "9" {
+		t.Errorf("Expected a NotFoundError for version 9, got %v", err)
+	}
+	if _, err := executeCommand(t, "versions", "disable", "db", "--version", "latest", "--project", "test-project"); err == nil {
+		t.Error("Expected an error for a non-numeric version")
+	}
+
+	calls := stub.Calls()
+	expected := [][]string{
+		{"secrets", "versions", "disable", "3", "--secret", "team-db", "--quiet", "--project", "test-project"},
+		{"secrets", "versions", "destroy", "2", "--secret", "team-db", "--quiet", "--project", "test-project"},
+		{"secrets", "versions", "enable", "9", "--secret", "team-db", "--quiet", "--project", "test-project"},
+	}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("gcloud calls = %v, expected %v", calls, expected)
+	}
+
+	for input, expected := range map[string]bool{"y\n": true, "YES\n": true, "\n": false, "": false} {
+		confirmed, err := confirmVersionDestroy(bufio.NewReader(strings.NewReader(input)), "team-db", "2")
+		if err != nil || confirmed != expected {
+			t.Errorf("confirmVersionDestroy(%q) = %v, %v, expected %v", input, confirmed, err, expected)
+		}
+	}
+}
+
+// TestCreateOptionsThroughGcloud checks the --annotations, --ttl, and
+// --expire-time arguments that create passes to gcloud
+func TestCreateOptionsThroughGcloud(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Defaults: DefaultConfig{WarnOnDataFlag: new(bool)}}
+
+	stub := newGcloudStub(t)
+	stub.Fail("ERROR: (gcloud.secrets.describe) NOT_FOUND: Secret [projects/123/secrets/db] not found.\n", "secrets", "describe", "db")
+	stub.On("", "secrets", "create", "db")
+
+	if _, err := executeCommand(t, "create", "db", "--project", "test-project", "--data", "value",
+		"--annotation", "owner=backend", "--annotation", "tickets=OPS-1,OPS-2"); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if _, err := executeCommand(t, "create", "db", "--project", "test-project", "--data", "value", "--ttl", "90m"); err != nil {
+		t.Fatalf("create --ttl failed: %v", err)
+	}
+	if _, err := executeCommand(t, "create", "db", "--project", "test-project", "--data", "value", "--expire-time", "2099-01-01T00:00:00+09:00"); err != nil {
+		t.Fatalf("create --expire-time failed: %v", err)
+	}
+	for _, args := range [][]string{
+		{"--annotation", "owner"},
+		{"--ttl", "1h", "--expire-time", "2099-01-01T00:00:00Z"},
+	} {
+		if _, err := executeCommand(t, append([]string{"create", "db", "--project", "test-project", "--data", "value"}, args...)...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+
+	var creates [][]string
+	for _, call := range stub.Calls() {
+		if call[1] == "create" {
+			creates = append(creates, call)
+		}
+	}
+	expected := [][]string{
+		{"secrets", "create", "db", "--project", "test-project", "--annotations", "^;^owner=backend;tickets=OPS-1,OPS-2", "--data-file", "-"},
+		{"secrets", "create", "db", "--project", "test-project", "--ttl", "5400s", "--data-file", "-"},
+		{"secrets", "create", "db", "--project", "test-project", "--expire-time", "2099-01-01T00:00:00+09:00", "--data-file", "-"},
+	}
+	if !reflect.DeepEqual(creates, expected) {
+		t.Errorf("gcloud create calls = %v, expected %v", creates, expected)
+	}
+}
+
+// TestExportWithValuesFileModeThroughGcloud checks that an export holding
+// plaintext values is written readable only by the owner
+func TestExportWithValuesFileModeThroughGcloud(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{}
+
+	stub := newGcloudStub(t)
+	stub.On(`[{"name": "projects/123/secrets/db", "createTime": "2025-01-01T00:00:00Z"}]`, "secrets", "list")
+	stub.On("s3cret", "secrets", "versions", "access", "latest", "--secret", "db")
+
+	path := filepath.Join(t.TempDir(), "backup.csv")
+	if _, err := executeCommand(t, "export", path, "--with-values", "--project", "test-project"); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "s3cret") {
+		t.Fatalf("Expected the value in the export, got %q", data)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat export: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Export mode = %v, expected 0600", info.Mode().Perm())
+	}
+}
+
+// TestGetOutputFileThroughGcloud checks that get --output-file keeps the
+// trailing newline gcloud prints with the payload
+func TestGetOutputFileThroughGcloud(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{}
+
+	stub := newGcloudStub(t)
+	stub.On("-----BEGIN KEY-----\nabc\n-----END KEY-----\n", "secrets", "versions", "access", "latest", "--secret", "tls-key")
+
+	path := filepath.Join(t.TempDir(), "tls.key")
+	if _, err := executeCommand(t, "get", "tls-key", "--project", "test-project", "--output-file", path); err != nil {
+		t.Fatalf("get --output-file failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "-----BEGIN KEY-----\nabc\n-----END KEY-----\n" {
+		t.Errorf("Output file content = %q", data)
+	}
+}
+
+// TestUpdateVersionCheckBeforeLabelsThroughGcloud checks that update runs
+// the version-limit check before changing labels, so a failed check leaves
+// the labels untouched
+func TestUpdateVersionCheckBeforeLabelsThroughGcloud(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{}
+
+	stub := newGcloudStub(t)
+	stub.Fail("ERROR: (gcloud.secrets.versions.list) PERMISSION_DENIED: Permission denied.\n", "secrets", "versions", "list")
+	stub.On("", "secrets", "update", "db")
+
+	if _, err := executeCommand(t, "update", "db", "--project", "test-project", "--data", "rotated", "--update-labels", "env=prod"); err == nil {
+		t.Fatal("Expected the failed version check to be an error")
+	}
+	for _, call := range stub.Calls() {
+		if call[1] == "update" {
+			t.Errorf("Expected no label update after the failed version check, got %v", stub.Calls())
+		}
+	}
+}
+
+// TestListPrefixLimitThroughGcloud checks that every list format passes the
+// prefix filter to gcloud along with --limit, so the limit counts only
+// secrets within the prefix
+func TestListPrefixLimitThroughGcloud(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Prefix: "team-"}
+
+	stub := newGcloudStub(t)
+	stub.On(`[{"name": "projects/123/secrets/team-a", "createTime": "2025-01-01T00:00:00Z"}, {"name": "projects/123/secrets/team-b", "createTime": "2025-01-01T00:00:00Z"}]`,
+		"secrets", "list", "--filter", "name~'^projects/[^/]+/secrets/team-'", "--limit", "2")
+
+	for _, args := range [][]string{{}, {"--format", "json"}, {"--format", "yaml"}, {"--compact"}, {"--format", "csv"}} {
+		output, err := executeCommand(t, append([]string{"list", "--project", "test-project", "--limit", "2"}, args...)...)
+		if err != nil {
+			t.Errorf("list %v failed: %v", args, err)
+			continue
+		}
+		if !strings.Contains(output, "a") || !strings.Contains(output, "b") {
+			t.Errorf("list %v: expected both secrets, got %q", args, output)
+		}
+	}
+}
@@ -1,8 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
-	"os/exec"
+	"os"
 	"strings"
 	"time"
 
@@ -10,56 +11,231 @@ import (
 )
 
 var getCmd = &cobra.Command{
-	Use:   "get SECRET_NAME",
+	Use:   "get SECRET_NAME... | get --combine KEY=SECRET[.FIELD] ...",
 	Short: "Get a secret value from Google Secret Manager",
 	Long: `Retrieve a secret value from Google Secret Manager.
 
 By default, retrieves the latest (most recent) version of the secret.
 You can specify a specific version number to access older versions.
 
+With --combine, each argument is KEY=SECRET or KEY=SECRET.FIELD and the result
+is a single JSON object: KEY=SECRET stores the secret value as a string, and
+KEY=SECRET.FIELD.SUBFIELD parses the value as JSON and stores the element at
+that path (numeric segments index arrays). The latest version of each secret
+is read. A missing secret, invalid JSON, or missing field is an error. Use
+--output to write the object to a file (created with mode 0600) instead of
+stdout.
+
+With --format env, each argument is a secret name and the latest values are
+printed as NAME='value' lines that a shell can source. --env-prefix prepends a
+string to every name, --replace-dash turns dashes into underscores and --upper
+uppercases the result (so --env-prefix app- --replace-dash --upper turns
+db-pass into APP_DB_PASS). A name that is not a valid environment variable is
+an error, and secrets that map to the same name are reported on stderr.
+
+With more than one secret name and no --format env, the latest values are
+printed as NAME=value lines without quoting. --clipboard and the version and
+metadata flags need a single secret name.
+
+With --version-alias, the version is looked up in the secret's version
+aliases. When the alias is not set, each --fallback entry (an alias, a version
+number, or latest) is tried in order, and the one used is reported on stderr;
+it is an error only if none resolve. This suits blue/green rollouts where a
+'current' alias may not be set yet.
+
+--decode-base64 decodes a base64 value before it is printed or copied, and
+--json-field parses the value as JSON and keeps only the field at a dot path
+such as credentials.password (numeric segments index arrays). A string field
+is printed as is; any other field is printed as JSON. The two flags cannot be
+combined.
+
+--output-file writes the value to a file created with mode 0600 instead of
+printing it, so it never reaches the terminal or a shell pipeline. The payload
+is written byte for byte, after any --decode-base64 or --json-field, with no
+whitespace trimmed and no newline added. An existing file is only replaced
+with --force.
+
+Metadata shows the short version number (5, not the full resource name). With
+--show-metadata --format json or yaml, the metadata fields (secret, version,
+name, state, createTime, destroyTime, etag) and the value are printed as one
+object.
+
 Examples:
   gsecutil get my-secret                    # Get latest version
   gsecutil get my-secret --version 3        # Get specific version 3
   gsecutil get my-secret -v 1 --clipboard   # Get version 1 and copy to clipboard
-  gsecutil get my-secret --show-metadata    # Show version info along with value`,
-	Args: cobra.ExactArgs(1),
+  gsecutil get my-secret --show-metadata    # Show version info along with value
+  gsecutil get my-secret --show-metadata --format json  # Version info and value as one JSON object
+  gsecutil get my-secret --version-alias current --fallback latest  # Alias, or latest if unset
+  gsecutil get my-secret --metadata-only    # Show version info without accessing the value
+  gsecutil get tls-key --decode-base64      # Decode a base64 value
+  gsecutil get db-config --json-field credentials.password  # One field of a JSON value
+  gsecutil get tls-key --decode-base64 --output-file tls.key  # Write the value to a 0600 file
+  gsecutil get my-secret --metadata-only --format yaml  # Version info as YAML
+  gsecutil get my-secret --projects app-dev,app-prod --metadata-only  # Find which projects have it
+  gsecutil get --combine db_host=db-config.host db_password=db-password  # One JSON object from several secrets
+  gsecutil get --combine api=api-config.endpoints.0 token=api-token --output config.json
+  gsecutil get db-host db-port              # db-host=value and db-port=value lines
+  gsecutil get db-pass api-key --format env --env-prefix APP_ --replace-dash --upper > .env`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		// Checked for every mode, so a flag is never silently ignored
+		if format, _ := cmd.Flags().GetString("format"); format != "env" {
+			for _, flag := range []string{"env-prefix", "upper", "replace-dash"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--%s is only supported with --format env", flag)
+				}
+			}
+		}
+		if combine, _ := cmd.Flags().GetBool("combine"); !combine && cmd.Flags().Changed("output") {
+			return fmt.Errorf("--output is only supported with --combine")
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if projects, _ := cmd.Flags().GetString("projects"); projects != "" {
+			if clipboard, _ := cmd.Flags().GetBool("clipboard"); clipboard {
+				return fmt.Errorf("--clipboard cannot be used with --projects")
+			}
+			if combine, _ := cmd.Flags().GetBool("combine"); combine {
+				return fmt.Errorf("--combine cannot be used with --projects")
+			}
+			if format, _ := cmd.Flags().GetString("format"); format == "env" {
+				return fmt.Errorf("--format env cannot be used with --projects")
+			}
+			return runAcrossProjects(cmd, args, projects)
+		}
+		project, _ := cmd.Flags().GetString("project")
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
+		if combine, _ := cmd.Flags().GetBool("combine"); combine {
+			return runGetCombine(cmd, args, project)
+		}
+		if format, _ := cmd.Flags().GetString("format"); format == "env" {
+			return runGetEnv(cmd, args, project)
+		}
+		if len(args) > 1 {
+			return runGetMultiple(cmd, args, project)
+		}
 		userInputName := args[0]                           // What the user typed
 		secretName := AddPrefixToSecretName(userInputName) // Add prefix if configured
-		project, _ := cmd.Flags().GetString("project")
-		project = GetProject(project) // Use configuration-based project resolution
 		version, _ := cmd.Flags().GetString("version")
 		clipboard, _ := cmd.Flags().GetBool("clipboard")
 		showMetadata, _ := cmd.Flags().GetBool("show-metadata")
+		metadataOnly, _ := cmd.Flags().GetBool("metadata-only")
+		format, _ := cmd.Flags().GetString("format")
+		decodeBase64, _ := cmd.Flags().GetBool("decode-base64")
+		jsonField, _ := cmd.Flags().GetString("json-field")
+
+		var fieldPath []string
+		if cmd.Flags().Changed("json-field") {
+			if decodeBase64 {
+				return fmt.Errorf("--decode-base64 cannot be combined with --json-field")
+			}
+			if fieldPath, err = parseJSONFieldPath(jsonField); err != nil {
+				return err
+			}
+		}
 
 		// Determine version to use
 		versionToUse := version
 		if versionToUse == "" {
 			versionToUse = "latest"
 		}
+		if alias, _ := cmd.Flags().GetString("version-alias"); alias != "" {
+			if version != "" {
+				return fmt.Errorf("--version-alias cannot be combined with --version")
+			}
+			fallbacks, _ := cmd.Flags().GetStringSlice("fallback")
+			chain, err := parseVersionChain(alias, fallbacks)
+			if err != nil {
+				return err
+			}
+			if versionToUse, err = resolveVersionAlias(secretName, project, chain); err != nil {
+				return err
+			}
+		} else if cmd.Flags().Changed("fallback") {
+			return fmt.Errorf("--fallback requires --version-alias")
+		}
 
-		// Build gcloud command to get secret value
-		gcloudArgs := []string{"secrets", "versions", "access", versionToUse, "--secret", secretName}
-		if project != "" {
-			gcloudArgs = append(gcloudArgs, "--project", project)
+		if format != "" && !metadataOnly && !showMetadata {
+			return fmt.Errorf("--format is only supported with --metadata-only, --show-metadata, or as --format env")
+		}
+		if format != "" && format != "text" && format != "json" && format != "yaml" {
+			return fmt.Errorf("unsupported format '%s': use text, json, or yaml", format)
+		}
+		structured := format == "json" || format == "yaml"
+		if structured && clipboard {
+			return fmt.Errorf("--clipboard cannot be combined with --format %s", format)
 		}
 
-		// Execute gcloud command to get secret value
-		gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-		output, err := gcloudCmd.Output()
-		if err != nil {
-			if exitError, ok := err.(*exec.ExitError); ok {
-				return formatGcloudError(string(exitError.Stderr))
+		outputFile, _ := cmd.Flags().GetString("output-file")
+		if force, _ := cmd.Flags().GetBool("force"); outputFile == "" && force {
+			return fmt.Errorf("--force requires --output-file")
+		} else if outputFile != "" {
+			switch {
+			case clipboard:
+				return fmt.Errorf("--output-file cannot be combined with --clipboard")
+			case metadataOnly:
+				return fmt.Errorf("--output-file cannot be combined with --metadata-only")
+			case structured:
+				return fmt.Errorf("--output-file cannot be combined with --format %s", format)
+			}
+			// Check before reading the value, so a refusal never fetches it
+			if _, err := os.Stat(outputFile); err == nil && !force {
+				return fmt.Errorf("output file '%s' already exists. Use --force to overwrite", outputFile)
 			}
-			return fmt.Errorf("failed to execute gcloud command: %v", err)
 		}
 
-		secretValue := strings.TrimSpace(string(output))
+		// Metadata-only mode never accesses the secret value
+		if metadataOnly {
+			if clipboard || showMetadata {
+				return fmt.Errorf("--metadata-only cannot be combined with --clipboard or --show-metadata")
+			}
+			if decodeBase64 || fieldPath != nil {
+				return fmt.Errorf("--metadata-only cannot be combined with --decode-base64 or --json-field")
+			}
+			versionInfo, err := secretManager.GetVersion(secretName, versionToUse, project)
+			if err != nil {
+				return err
+			}
+			if structured {
+				return printStructuredOutput(newVersionMetadata(secretName, versionInfo), format)
+			}
+			displayVersionMetadata(secretName, versionInfo)
+			return nil
+		}
+
+		// A file gets the payload byte for byte, trailing newlines included
+		getValue := secretManager.GetValue
+		if outputFile != "" {
+			getValue = secretManager.GetRawValue
+		}
+		secretValue, err := getValue(secretName, versionToUse, project)
+		if err != nil {
+			return withUserInputName(err, userInputName)
+		}
+		if secretValue, err = transformGetValue(secretValue, decodeBase64, fieldPath); err != nil {
+			return fmt.Errorf("secret '%s' %w", secretName, err)
+		}
+
+		// Structured output needs the metadata, so a failed fetch is an error
+		if structured {
+			versionInfo, err := secretManager.GetVersion(secretName, versionToUse, project)
+			if err != nil {
+				return err
+			}
+			return printStructuredOutput(VersionValue{
+				VersionMetadata: newVersionMetadata(secretName, versionInfo),
+				Value:           secretValue,
+			}, format)
+		}
 
 		// Get metadata if requested
 		var versionInfo *SecretVersionInfo
 		if showMetadata {
-			versionInfo, err = getSecretVersionInfo(secretName, versionToUse, project)
+			versionInfo, err = secretManager.GetVersion(secretName, versionToUse, project)
 			if err != nil {
 				// Don't fail if metadata fetch fails, just warn
 				fmt.Printf("Warning: Failed to fetch version metadata: %v\n", err)
@@ -68,18 +244,17 @@ Examples:
 
 		// Display metadata first if requested
 		if showMetadata && versionInfo != nil {
-			fmt.Printf("Secret: %s\n", secretName)
-			fmt.Printf("Version: %s\n", versionInfo.Name)
-			fmt.Printf("State: %s\n", versionInfo.State)
-			fmt.Printf("Created: %s\n", versionInfo.CreateTime.Format(time.RFC3339))
-			if !versionInfo.DestroyTime.IsZero() {
-				fmt.Printf("Destroy Time: %s\n", versionInfo.DestroyTime.Format(time.RFC3339))
-			}
-			fmt.Printf("ETag: %s\n", versionInfo.Etag)
+			displayVersionMetadata(secretName, versionInfo)
 			fmt.Println("---")
 		}
 
-		if clipboard {
+		if outputFile != "" {
+			// Written as read, and readable only by the owner
+			if err := atomicWriteFile(outputFile, []byte(secretValue), 0600); err != nil {
+				return fmt.Errorf("failed to write output file: %w", err)
+			}
+			fmt.Printf("Secret value written to %s\n", outputFile)
+		} else if clipboard {
 			// Copy to clipboard
 			if err := copyToClipboard(secretValue); err != nil {
 				fmt.Printf("Secret Value: %s\n", secretValue)
@@ -99,9 +274,110 @@ Examples:
 	},
 }
 
+// parseJSONFieldPath splits a --json-field value into its path segments
+func parseJSONFieldPath(value string) ([]string, error) {
+	path := strings.Split(value, ".")
+	for _, segment := range path {
+		if segment == "" {
+			return nil, fmt.Errorf("invalid --json-field '%s': empty field name", value)
+		}
+	}
+	return path, nil
+}
+
+// transformGetValue applies --decode-base64 or --json-field to a secret
+// value. Errors read as a continuation of "secret 'NAME' ...".
+func transformGetValue(value string, decodeBase64 bool, fieldPath []string) (string, error) {
+	if decodeBase64 {
+		decoded, err := decodeBase64Value(value)
+		if err != nil {
+			return "", fmt.Errorf("is not valid base64: %v", err)
+		}
+		return decoded, nil
+	}
+	if fieldPath == nil {
+		return value, nil
+	}
+
+	field, err := extractJSONField(value, fieldPath)
+	if err != nil {
+		return "", err
+	}
+	if text, ok := field.(string); ok {
+		return text, nil
+	}
+	data, err := json.MarshalIndent(field, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("field could not be encoded as JSON: %v", err)
+	}
+	return string(data), nil
+}
+
+// VersionMetadata is the structured form of version metadata printed by get.
+// Version is the short version number; Name is the full resource name.
+type VersionMetadata struct {
+	Secret      string     `json:"secret" yaml:"secret"`
+	Version     string     `json:"version" yaml:"version"`
+	Name        string     `json:"name" yaml:"name"`
+	State       string     `json:"state" yaml:"state"`
+	CreateTime  time.Time  `json:"createTime" yaml:"createTime"`
+	DestroyTime *time.Time `json:"destroyTime,omitempty" yaml:"destroyTime,omitempty"`
+	Etag        string     `json:"etag" yaml:"etag"`
+}
+
+// VersionValue is the output of 'get --show-metadata --format json|yaml': the
+// version metadata fields followed by the value
+type VersionValue struct {
+	VersionMetadata `yaml:",inline"`
+	Value           string `json:"value" yaml:"value"`
+}
+
+// newVersionMetadata converts gcloud version info into the output record
+func newVersionMetadata(secretName string, versionInfo *SecretVersionInfo) VersionMetadata {
+	metadata := VersionMetadata{
+		Secret:     secretName,
+		Version:    extractVersionNumber(versionInfo.Name),
+		Name:       versionInfo.Name,
+		State:      versionInfo.State,
+		CreateTime: versionInfo.CreateTime,
+		Etag:       versionInfo.Etag,
+	}
+	if !versionInfo.DestroyTime.IsZero() {
+		destroyTime := versionInfo.DestroyTime
+		metadata.DestroyTime = &destroyTime
+	}
+	return metadata
+}
+
+// displayVersionMetadata prints version metadata as text lines
+func displayVersionMetadata(secretName string, versionInfo *SecretVersionInfo) {
+	fmt.Printf("Secret: %s\n", secretName)
+	fmt.Printf("Version: %s\n", extractVersionNumber(versionInfo.Name))
+	fmt.Printf("State: %s\n", versionInfo.State)
+	fmt.Printf("Created: %s\n", versionInfo.CreateTime.Format(time.RFC3339))
+	if !versionInfo.DestroyTime.IsZero() {
+		fmt.Printf("Destroy Time: %s\n", versionInfo.DestroyTime.Format(time.RFC3339))
+	}
+	fmt.Printf("ETag: %s\n", versionInfo.Etag)
+}
+
 func init() {
 	rootCmd.AddCommand(getCmd)
 	getCmd.Flags().StringP("version", "v", "", "Version of the secret to retrieve (default: latest)")
+	getCmd.Flags().String("version-alias", "", "Read the version this alias points to (see versionAliases in describe)")
+	getCmd.Flags().StringSlice("fallback", nil, "Versions to try in order when --version-alias is not set: aliases, version numbers, or latest (comma-separated or repeated)")
 	getCmd.Flags().BoolP("clipboard", "c", false, "Copy secret value to clipboard")
 	getCmd.Flags().BoolP("show-metadata", "m", false, "Show version metadata (version, created time, state)")
+	getCmd.Flags().Bool("metadata-only", false, "Show version metadata without accessing the secret value")
+	getCmd.Flags().Bool("decode-base64", false, "Decode the secret value from base64 before printing or copying it")
+	getCmd.Flags().String("output-file", "", "Write the secret value to this file (mode 0600) instead of printing it")
+	getCmd.Flags().BoolP("force", "f", false, "Overwrite an existing --output-file")
+	getCmd.Flags().String("json-field", "", "Parse the secret value as JSON and print only the field at this dot path (e.g. credentials.password)")
+	getCmd.Flags().String("format", "", "Output format for --metadata-only or --show-metadata: text (default), json, or yaml; or env for NAME='value' lines")
+	getCmd.Flags().String("env-prefix", "", "Prefix for variable names with --format env (e.g. APP_)")
+	getCmd.Flags().Bool("upper", false, "Uppercase variable names with --format env")
+	getCmd.Flags().Bool("replace-dash", false, "Replace dashes with underscores in variable names with --format env")
+	getCmd.Flags().Bool("combine", false, "Combine several secrets or JSON fields (KEY=SECRET[.FIELD] arguments) into one JSON object")
+	getCmd.Flags().StringP("output", "o", "", "Write the --combine result to this file instead of stdout")
+	addProjectsFlag(getCmd)
 }
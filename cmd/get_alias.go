@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// versionAliasPattern matches the alias names Secret Manager accepts: a letter
+// followed by letters, digits, hyphens, or underscores, at most 63 characters
+var versionAliasPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]{0,62}$`)
+
+// versionNumberPattern matches a plain version number
+var versionNumberPattern = regexp.MustCompile(`^[0-9]+$`)
+
+// validateVersionAlias checks a --version-alias or alias --fallback name
+func validateVersionAlias(alias string) error {
+	if alias == "latest" {
+		return fmt.Errorf("invalid version alias 'latest': it is reserved for the newest version (use --version latest)")
+	}
+	if !versionAliasPattern.MatchString(alias) {
+		return fmt.Errorf("invalid version alias '%s': must start with a letter and contain only letters, digits, hyphens, and underscores (at most 63 characters)", alias)
+	}
+	return nil
+}
+
+// parseVersionChain builds the resolution chain of 'get --version-alias':
+// the alias followed by each --fallback entry, which is an alias, a version
+// number, or latest
+func parseVersionChain(alias string, fallbacks []string) ([]string, error) {
+	if err := validateVersionAlias(alias); err != nil {
+		return nil, err
+	}
+	chain := []string{alias}
+	for _, fallback := range fallbacks {
+		fallback = strings.TrimSpace(fallback)
+		if fallback == "" {
+			return nil, fmt.Errorf("--fallback entries cannot be empty")
+		}
+		if fallback != "latest" && !versionNumberPattern.MatchString(fallback) {
+			if err := validateVersionAlias(fallback); err != nil {
+				return nil, fmt.Errorf("invalid --fallback: %w", err)
+			}
+		}
+		chain = append(chain, fallback)
+	}
+	return chain, nil
+}
+
+// resolveVersionChain returns the version to read for the first chain entry
+// that resolves: aliases resolve when the secret defines them, version numbers
+// and latest always do. The returned entry names which one was used.
+func resolveVersionChain(chain []string, aliases map[string]string) (version, entry string, err error) {
+	for _, candidate := range chain {
+		if candidate == "latest" || versionNumberPattern.MatchString(candidate) {
+			return candidate, candidate, nil
+		}
+		if number, ok := aliases[candidate]; ok {
+			return number, candidate, nil
+		}
+	}
+	return "", "", fmt.Errorf("none of the version aliases %s is set on the secret", strings.Join(chain, ", "))
+}
+
+// resolveVersionAlias reads the version aliases of a secret and resolves the
+// chain against them. Taking a fallback is reported on stderr.
+func resolveVersionAlias(secretName, project string, chain []string) (string, error) {
+	secretInfo, err := secretManager.Describe(secretName, project)
+	if err != nil {
+		return "", err
+	}
+
+	version, entry, err := resolveVersionChain(chain, secretInfo.VersionAliases)
+	if err != nil {
+		return "", fmt.Errorf("secret '%s': %w", secretName, err)
+	}
+	if entry != chain[0] {
+		fmt.Fprintf(os.Stderr, "Note: version alias '%s' is not set on secret '%s'; using fallback '%s'\n", chain[0], secretName, entry)
+	}
+	return version, nil
+}
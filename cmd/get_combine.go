@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// combineSpec is one KEY=SECRET[.FIELD...] argument of 'get --combine'
+type combineSpec struct {
+	Key    string   // key in the combined object
+	Secret string   // secret name as given by the user
+	Path   []string // JSON field path inside the secret value, empty for the whole value
+}
+
+// parseCombineSpecs parses the --combine arguments. Secret names cannot
+// contain dots, so everything after the first dot is the field path.
+func parseCombineSpecs(args []string) ([]combineSpec, error) {
+	specs := make([]combineSpec, 0, len(args))
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		key, source, ok := strings.Cut(arg, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || source == "" {
+			return nil, fmt.Errorf("invalid --combine argument '%s' (use KEY=SECRET or KEY=SECRET.FIELD)", arg)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("invalid --combine argument '%s': key '%s' is used more than once", arg, key)
+		}
+		seen[key] = true
+
+		parts := strings.Split(source, ".")
+		for _, part := range parts {
+			if part == "" {
+				return nil, fmt.Errorf("invalid --combine argument '%s': empty secret or field name", arg)
+			}
+		}
+		specs = append(specs, combineSpec{Key: key, Secret: parts[0], Path: parts[1:]})
+	}
+	return specs, nil
+}
+
+// combineSecrets builds the combined object. Each secret is read once with
+// fetch, however many keys refer to it. Keys without a field path hold the
+// value as a string; keys with one hold the JSON value found at that path.
+func combineSecrets(specs []combineSpec, fetch func(secretName string) (string, error)) (map[string]interface{}, error) {
+	values := make(map[string]string)
+	combined := make(map[string]interface{}, len(specs))
+	for _, spec := range specs {
+		value, ok := values[spec.Secret]
+		if !ok {
+			var err error
+			if value, err = fetch(spec.Secret); err != nil {
+				return nil, fmt.Errorf("failed to read secret '%s' for key '%s': %w", spec.Secret, spec.Key, err)
+			}
+			values[spec.Secret] = value
+		}
+
+		if len(spec.Path) == 0 {
+			combined[spec.Key] = value
+			continue
+		}
+		field, err := extractJSONField(value, spec.Path)
+		if err != nil {
+			return nil, fmt.Errorf("key '%s': secret '%s' %w", spec.Key, spec.Secret, err)
+		}
+		combined[spec.Key] = field
+	}
+	return combined, nil
+}
+
+// extractJSONField parses value as JSON and returns the element at path.
+// Path segments index objects by key and arrays by number.
+func extractJSONField(value string, path []string) (interface{}, error) {
+	var current interface{}
+	if err := json.Unmarshal([]byte(value), &current); err != nil {
+		return nil, fmt.Errorf("is not valid JSON: %v", err)
+	}
+
+	for i, segment := range path {
+		fieldPath := strings.Join(path[:i+1], ".")
+		switch node := current.(type) {
+		case map[string]interface{}:
+			next, ok := node[segment]
+			if !ok {
+				return nil, fmt.Errorf("has no field '%s'", fieldPath)
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, fmt.Errorf("has no field '%s' (array of %d elements)", fieldPath, len(node))
+			}
+			current = node[index]
+		default:
+			return nil, fmt.Errorf("has no field '%s' (parent is not an object or array)", fieldPath)
+		}
+	}
+	return current, nil
+}
+
+// runGetCombine handles 'get --combine': it reads the latest version of each
+// secret and prints the combined object as JSON, or writes it to --output
+func runGetCombine(cmd *cobra.Command, args []string, project string) error {
+	for _, flag := range []string{"version", "version-alias", "fallback", "clipboard", "show-metadata", "metadata-only", "format", "decode-base64", "json-field", "output-file", "force"} {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--combine cannot be combined with --%s", flag)
+		}
+	}
+
+	specs, err := parseCombineSpecs(args)
+	if err != nil {
+		return err
+	}
+
+	combined, err := combineSecrets(specs, func(secretName string) (string, error) {
+		return secretManager.GetValue(AddPrefixToSecretName(secretName), "latest", project)
+	})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(combined, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON output: %w", err)
+	}
+	data = append(data, '\n')
+
+	outputPath, _ := cmd.Flags().GetString("output")
+	if outputPath == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+	// The file holds secret values, so only the owner may read it
+	if err := atomicWriteFile(outputPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	fmt.Printf("Combined %d keys into %s\n", len(specs), outputPath)
+	return nil
+}
+
+// accessSecretValue reads one version of a secret with surrounding whitespace
+// trimmed, returning a NotFoundError when the secret or version does not exist
+func accessSecretValue(secretName, version, project string) (string, error) {
+	value, err := accessSecretPayload(secretName, version, project)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(value), nil
+}
+
+// accessSecretPayload reads one version of a secret exactly as stored
+func accessSecretPayload(secretName, version, project string) (string, error) {
+	gcloudArgs := []string{"secrets", "versions", "access", version, "--secret", secretName}
+	if project != "" {
+		gcloudArgs = append(gcloudArgs, "--project", project)
+	}
+
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
+	output, err := gcloudCmd.Output()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			if typed := classifyGcloudFailure(string(exitError.Stderr), secretName, ""); typed != nil {
+				return "", typed
+			}
+			return "", formatGcloudError(string(exitError.Stderr))
+		}
+		return "", fmt.Errorf("failed to execute gcloud command: %v", err)
+	}
+	return string(output), nil
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParseCombineSpecs tests parsing of KEY=SECRET[.FIELD] arguments
+func TestParseCombineSpecs(t *testing.T) {
+	specs, err := parseCombineSpecs([]string{"host=db-config.primary.host", "password=db-password"})
+	if err != nil {
+		t.Fatalf("parseCombineSpecs() failed: %v", err)
+	}
+	expected := []combineSpec{
+		{Key: "host", Secret: "db-config", Path: []string{"primary", "host"}},
+		{Key: "password", Secret: "db-password", Path: []string{}},
+	}
+	if !reflect.DeepEqual(specs, expected) {
+		t.Errorf("parseCombineSpecs() = %+v, expected %+v", specs, expected)
+	}
+
+	for _, arg := range []string{"db-password", "=db-password", "key=", "key=db..host", "key=db.", "key=.host"} {
+		if _, err := parseCombineSpecs([]string{arg}); err == nil {
+			t.Errorf("Expected parseCombineSpecs(%q) to fail", arg)
+		}
+	}
+	if _, err := parseCombineSpecs([]string{"a=one", "a=two"}); err == nil {
+		t.Error("Expected error for a repeated key")
+	}
+}
+
+// TestCombineSecrets tests assembling values and JSON fields into one object
+func TestCombineSecrets(t *testing.T) {
+	values := map[string]string{
+		"db-config":   `{"primary": {"host": "db.internal", "port": 5432}, "replicas": ["r1", "r2"]}`,
+		"db-password": "s3cret",
+	}
+	fetched := map[string]int{}
+	fetch := func(secretName string) (string, error) {
+		fetched[secretName]++
+		value, ok := values[secretName]
+		if !ok {
+			return "", &NotFoundError{Secret: secretName}
+		}
+		return value, nil
+	}
+
+	specs, _ := parseCombineSpecs([]string{"host=db-config.primary.host", "port=db-config.primary.port", "replica=db-config.replicas.1", "password=db-password"})
+	combined, err := combineSecrets(specs, fetch)
+	if err != nil {
+		t.Fatalf("combineSecrets() failed: %v", err)
+	}
+	expected := map[string]interface{}{"host": "db.internal", "port": float64(5432), "replica": "r2", "password": "s3cret"}
+	if !reflect.DeepEqual(combined, expected) {
+		t.Errorf("combineSecrets() = %v, expected %v", combined, expected)
+	}
+	if fetched["db-config"] != 1 {
+		t.Errorf("Expected db-config to be read once, got %d", fetched["db-config"])
+	}
+
+	tests := []struct {
+		arg         string
+		expectError string
+	}{
+		{"x=missing", "not found"},
+		{"x=db-config.primary.user", "has no field 'primary.user'"},
+		{"x=db-config.replicas.5", "has no field 'replicas.5'"},
+		{"x=db-config.primary.host.name", "parent is not an object or array"},
+		{"x=db-password.field", "is not valid JSON"},
+	}
+	for _, tt := range tests {
+		specs, _ := parseCombineSpecs([]string{tt.arg})
+		_, err := combineSecrets(specs, fetch)
+		if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+			t.Errorf("combineSecrets(%s) error = %v, expected it to contain %q", tt.arg, err, tt.expectError)
+		}
+	}
+
+	specs, _ = parseCombineSpecs([]string{"x=missing"})
+	if _, err := combineSecrets(specs, fetch); exitCodeFor(err) != exitCodeNotFound {
+		t.Errorf("Expected a missing secret to keep the not-found exit code, got %v", err)
+	}
+	if _, err := combineSecrets(specs, func(string) (string, error) { return "", errors.New("boom") }); err == nil {
+		t.Error("Expected fetch errors to be returned")
+	}
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// envVarNamePattern matches names a POSIX shell accepts as variable names
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// envNameOptions controls how 'get --format env' turns secret names into variable names
+type envNameOptions struct {
+	Prefix      string // prepended to every name, e.g. APP_
+	Upper       bool   // uppercase the result
+	ReplaceDash bool   // replace dashes with underscores
+}
+
+// envVarName returns the variable name for a secret (as given by the user,
+// without the configured prefix). The transforms apply to the whole name,
+// including --env-prefix.
+func envVarName(secretName string, opts envNameOptions) (string, error) {
+	name := opts.Prefix + secretName
+	if opts.ReplaceDash {
+		name = strings.ReplaceAll(name, "-", "_")
+	}
+	if opts.Upper {
+		name = strings.ToUpper(name)
+	}
+	if !envVarNamePattern.MatchString(name) {
+		hint := ""
+		if strings.Contains(name, "-") {
+			hint = " (use --replace-dash to turn dashes into underscores)"
+		}
+		return "", fmt.Errorf("secret '%s' maps to '%s', which is not a valid environment variable name%s", secretName, name, hint)
+	}
+	return name, nil
+}
+
+// envVarNames maps each secret to its variable name. It fails on the first
+// invalid name and returns a warning for every name that more than one secret
+// maps to.
+func envVarNames(secretNames []string, opts envNameOptions) ([]string, []string, error) {
+	names := make([]string, len(secretNames))
+	sources := make(map[string][]string)
+	var order []string
+	for i, secretName := range secretNames {
+		name, err := envVarName(secretName, opts)
+		if err != nil {
+			return nil, nil, err
+		}
+		names[i] = name
+		if sources[name] == nil {
+			order = append(order, name)
+		}
+		sources[name] = append(sources[name], secretName)
+	}
+
+	var warnings []string
+	for _, name := range order {
+		if len(sources[name]) > 1 {
+			warnings = append(warnings, fmt.Sprintf("secrets %s all map to %s; the last one wins when the output is sourced",
+				strings.Join(sources[name], ", "), name))
+		}
+	}
+	return names, warnings, nil
+}
+
+// shellQuote single-quotes value so a POSIX shell reads it back unchanged
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// runGetEnv handles 'get --format env': it reads the latest version of each
+// secret and prints NAME='value' lines that can be sourced by a shell
+func runGetEnv(cmd *cobra.Command, args []string, project string) error {
+	for _, flag := range []string{"version", "version-alias", "fallback", "clipboard", "show-metadata", "metadata-only", "decode-base64", "json-field", "output-file", "force"} {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--format env cannot be combined with --%s", flag)
+		}
+	}
+
+	var opts envNameOptions
+	opts.Prefix, _ = cmd.Flags().GetString("env-prefix")
+	opts.Upper, _ = cmd.Flags().GetBool("upper")
+	opts.ReplaceDash, _ = cmd.Flags().GetBool("replace-dash")
+
+	// Validate every name before reading any secret
+	names, warnings, err := envVarNames(args, opts)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	values, err := readLatestValues(args, project)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatEnvLines(names, values))
+	return nil
+}
+
+// runGetMultiple handles 'get' with more than one secret name: it reads the
+// latest version of each and prints NAME=value lines, unquoted. Values are
+// printed as stored, so --format env should be used for values that need
+// quoting or contain newlines.
+func runGetMultiple(cmd *cobra.Command, args []string, project string) error {
+	if clipboard, _ := cmd.Flags().GetBool("clipboard"); clipboard {
+		return fmt.Errorf("--clipboard cannot be used with more than one secret name")
+	}
+	for _, flag := range []string{"version", "version-alias", "fallback", "show-metadata", "metadata-only", "format", "decode-base64", "json-field", "output-file", "force"} {
+		if cmd.Flags().Changed(flag) {
+			return fmt.Errorf("--%s is only supported with a single secret name", flag)
+		}
+	}
+
+	values, err := readLatestValues(args, project)
+	if err != nil {
+		return err
+	}
+	lines := make([]string, len(args))
+	for i, value := range values {
+		lines[i] = args[i] + "=" + value
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+	return nil
+}
+
+// readLatestValues reads the latest value of each secret (as given by the
+// user, without the configured prefix), failing on the first that cannot be
+// read so that no partial output is printed
+func readLatestValues(secretNames []string, project string) ([]string, error) {
+	values := make([]string, len(secretNames))
+	for i, secretName := range secretNames {
+		value, err := secretManager.GetValue(AddPrefixToSecretName(secretName), "latest", project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret '%s': %w", secretName, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
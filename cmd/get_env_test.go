@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestEnvVarName tests turning secret names into environment variable names
+func TestEnvVarName(t *testing.T) {
+	tests := []struct {
+		name        string
+		secretName  string
+		opts        envNameOptions
+		expected    string
+		expectError bool
+	}{
+		{"Name kept as is", "db_pass", envNameOptions{}, "db_pass", false},
+		{"Prefix, dash and upper", "db-pass", envNameOptions{Prefix: "APP_", Upper: true, ReplaceDash: true}, "APP_DB_PASS", false},
+		{"Transforms apply to the prefix", "db-pass", envNameOptions{Prefix: "app-", Upper: true, ReplaceDash: true}, "APP_DB_PASS", false},
+		{"Dash without --replace-dash", "app-db-pass", envNameOptions{Upper: true}, "", true},
+		{"Leading digit", "1password", envNameOptions{}, "", true},
+		{"Invalid prefix", "token", envNameOptions{Prefix: "my.app_"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := envVarName(tt.secretName, tt.opts)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %q", tt.secretName, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("envVarName() failed: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("envVarName() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+
+	_, err := envVarName("app-db-pass", envNameOptions{})
+	if err == nil || !strings.Contains(err.Error(), "--replace-dash") {
+		t.Errorf("Expected a --replace-dash hint, got %v", err)
+	}
+}
+
+// TestEnvVarNamesCollisions tests that secrets mapping to the same name are reported
+func TestEnvVarNamesCollisions(t *testing.T) {
+	opts := envNameOptions{Upper: true, ReplaceDash: true}
+	names, warnings, err := envVarNames([]string{"db-pass", "api-key", "db_pass", "DB-PASS"}, opts)
+	if err != nil {
+		t.Fatalf("envVarNames() failed: %v", err)
+	}
+	if expected := []string{"DB_PASS", "API_KEY", "DB_PASS", "DB_PASS"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("envVarNames() names = %v, expected %v", names, expected)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "db-pass, db_pass, DB-PASS") || !strings.Contains(warnings[0], "DB_PASS") {
+		t.Errorf("Expected one collision warning for DB_PASS, got %v", warnings)
+	}
+
+	if _, warnings, _ := envVarNames([]string{"a", "b"}, opts); len(warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", warnings)
+	}
+}
+
+// TestShellQuote tests that values survive being sourced by a shell
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"plain":         "'plain'",
+		"with space":    "'with space'",
+		"it's":          `'it'\''s'`,
+		"$HOME `id` \\": "'$HOME `id` \\'",
+		"":              "''",
+	}
+	for value, expected := range tests {
+		if got := shellQuote(value); got != expected {
+			t.Errorf("shellQuote(%q) = %s, expected %s", value, got, expected)
+		}
+	}
+}
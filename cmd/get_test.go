@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestNewVersionMetadata tests conversion of version info into the JSON record
+func TestNewVersionMetadata(t *testing.T) {
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	destroyed := created.Add(24 * time.Hour)
+
+	tests := []struct {
+		name            string
+		info            SecretVersionInfo
+		expectVersion   string
+		expectDestroyed bool
+	}{
+		{
+			name: "Enabled version omits destroy time",
+			info: SecretVersionInfo{
+				Name:       "projects/p/secrets/s/versions/2",
+				State:      "ENABLED",
+				CreateTime: created,
+				Etag:       "\"abc\"",
+			},
+			expectVersion:   "2",
+			expectDestroyed: false,
+		},
+		{
+			name: "Destroyed version includes destroy time",
+			info: SecretVersionInfo{
+				Name:        "projects/p/secrets/s/versions/1",
+				State:       "DESTROYED",
+				CreateTime:  created,
+				DestroyTime: destroyed,
+			},
+			expectVersion:   "1",
+			expectDestroyed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata := newVersionMetadata("s", &tt.info)
+			if metadata.Secret != "s" || metadata.Name != tt.info.Name || metadata.State != tt.info.State {
+				t.Errorf("newVersionMetadata() = %+v, fields not copied from %+v", metadata, tt.info)
+			}
+			if metadata.Version != tt.expectVersion {
+				t.Errorf("newVersionMetadata().Version = %q, expected %q", metadata.Version, tt.expectVersion)
+			}
+
+			jsonOutput, err := json.Marshal(metadata)
+			if err != nil {
+				t.Fatalf("Failed to marshal metadata: %v", err)
+			}
+			if got := strings.Contains(string(jsonOutput), "destroyTime"); got != tt.expectDestroyed {
+				t.Errorf("destroyTime present = %v, expected %v: %s", got, tt.expectDestroyed, jsonOutput)
+			}
+		})
+	}
+}
+
+// TestVersionMetadataYAMLMatchesJSON tests that YAML output uses the same keys as JSON output
+func TestVersionMetadataYAMLMatchesJSON(t *testing.T) {
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	metadata := newVersionMetadata("s", &SecretVersionInfo{
+		Name:        "projects/p/secrets/s/versions/1",
+		State:       "DESTROYED",
+		CreateTime:  created,
+		DestroyTime: created.Add(time.Hour),
+		Etag:        "\"abc\"",
+	})
+
+	jsonOutput := captureStdout(func() {
+		if err := printStructuredOutput(metadata, "json"); err != nil {
+			t.Fatalf("JSON output failed: %v", err)
+		}
+	})
+	yamlOutput := captureStdout(func() {
+		if err := printStructuredOutput(metadata, "yaml"); err != nil {
+			t.Fatalf("YAML output failed: %v", err)
+		}
+	})
+
+	var fromJSON, fromYAML map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonOutput), &fromJSON); err != nil {
+		t.Fatalf("Failed to parse JSON output: %v", err)
+	}
+	if err := yaml.Unmarshal([]byte(yamlOutput), &fromYAML); err != nil {
+		t.Fatalf("Failed to parse YAML output: %v", err)
+	}
+
+	if len(fromJSON) != len(fromYAML) {
+		t.Errorf("JSON has %d keys, YAML has %d:\n%s\n%s", len(fromJSON), len(fromYAML), jsonOutput, yamlOutput)
+	}
+	for key := range fromJSON {
+		if _, ok := fromYAML[key]; !ok {
+			t.Errorf("YAML output missing key %q:\n%s", key, yamlOutput)
+		}
+	}
+}
+
+// TestDisplayVersionMetadata tests that the text output shows the short version number
+func TestDisplayVersionMetadata(t *testing.T) {
+	output := captureStdout(func() {
+		displayVersionMetadata("s", &SecretVersionInfo{
+			Name:       "projects/p/secrets/s/versions/5",
+			State:      "ENABLED",
+			CreateTime: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+			Etag:       "\"abc\"",
+		})
+	})
+	if !strings.Contains(output, "Version: 5\n") {
+		t.Errorf("Expected short version number, got:\n%s", output)
+	}
+	if strings.Contains(output, "projects/p/secrets") {
+		t.Errorf("Expected no resource path in output, got:\n%s", output)
+	}
+}
+
+// TestVersionValueJSON tests that --show-metadata JSON output is one flat object
+func TestVersionValueJSON(t *testing.T) {
+	value := VersionValue{
+		VersionMetadata: newVersionMetadata("s", &SecretVersionInfo{
+			Name:       "projects/p/secrets/s/versions/5",
+			State:      "ENABLED",
+			CreateTime: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+			Etag:       "\"abc\"",
+		}),
+		Value: "hunter2",
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		t.Run(format, func(t *testing.T) {
+			output := captureStdout(func() {
+				if err := printStructuredOutput(value, format); err != nil {
+					t.Fatalf("%s output failed: %v", format, err)
+				}
+			})
+			var fields map[string]interface{}
+			var err error
+			if format == "json" {
+				err = json.Unmarshal([]byte(output), &fields)
+			} else {
+				err = yaml.Unmarshal([]byte(output), &fields)
+			}
+			if err != nil {
+				t.Fatalf("Failed to parse %s output: %v", format, err)
+			}
+			for key, expected := range map[string]string{"secret": "s", "version": "5", "state": "ENABLED", "etag": "\"abc\"", "value": "hunter2"} {
+				if got := fmt.Sprint(fields[key]); got != expected {
+					t.Errorf("%s = %q, expected %q:\n%s", key, got, expected, output)
+				}
+			}
+			if _, ok := fields["createTime"]; !ok {
+				t.Errorf("Expected createTime in output:\n%s", output)
+			}
+		})
+	}
+}
+
+// TestParseVersionChain tests validation of --version-alias and --fallback
+func TestParseVersionChain(t *testing.T) {
+	chain, err := parseVersionChain("current", []string{"previous", " 3 ", "latest"})
+	if err != nil {
+		t.Fatalf("parseVersionChain() failed: %v", err)
+	}
+	if strings.Join(chain, ",") != "current,previous,3,latest" {
+		t.Errorf("parseVersionChain() = %v", chain)
+	}
+
+	invalid := []struct {
+		alias     string
+		fallbacks []string
+	}{
+		{"latest", nil},
+		{"1st", nil},
+		{"blue green", nil},
+		{strings.Repeat("a", 64), nil},
+		{"current", []string{""}},
+		{"current", []string{"bad.alias"}},
+	}
+	for _, tt := range invalid {
+		if _, err := parseVersionChain(tt.alias, tt.fallbacks); err == nil {
+			t.Errorf("Expected parseVersionChain(%q, %v) to fail", tt.alias, tt.fallbacks)
+		}
+	}
+}
+
+// TestResolveVersionChain tests falling back through aliases, numbers, and latest
+func TestResolveVersionChain(t *testing.T) {
+	aliases := map[string]string{"current": "7", "previous": "6"}
+	tests := []struct {
+		name            string
+		chain           []string
+		expectedVersion string
+		expectedEntry   string
+		expectError     bool
+	}{
+		{"Alias set", []string{"current", "latest"}, "7", "current", false},
+		{"Falls back to next alias", []string{"next", "previous", "latest"}, "6", "previous", false},
+		{"Falls back to latest", []string{"next", "latest"}, "latest", "latest", false},
+		{"Falls back to a version number", []string{"next", "2"}, "2", "2", false},
+		{"Nothing resolves", []string{"next", "canary"}, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, entry, err := resolveVersionChain(tt.chain, aliases)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error, got version %q", version)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveVersionChain() failed: %v", err)
+			}
+			if version != tt.expectedVersion || entry != tt.expectedEntry {
+				t.Errorf("resolveVersionChain() = %q, %q, expected %q, %q", version, entry, tt.expectedVersion, tt.expectedEntry)
+			}
+		})
+	}
+}
+
+// TestTransformGetValue tests --decode-base64 and --json-field on a secret value
+func TestTransformGetValue(t *testing.T) {
+	document := `{"credentials": {"user": "app", "password": "s3cret", "port": 5432}, "hosts": ["a", "b"]}`
+	tests := []struct {
+		name         string
+		value        string
+		decodeBase64 bool
+		jsonField    string
+		expected     string
+		expectError  bool
+	}{
+		{name: "Unchanged", value: "plain", expected: "plain"},
+		{name: "Base64", value: "aGVsbG8gd29ybGQ=", decodeBase64: true, expected: "hello world"},
+		{name: "Wrapped base64", value: "aGVsbG8g\nd29ybGQ=", decodeBase64: true, expected: "hello world"},
+		{name: "Invalid base64", value: "not base64!", decodeBase64: true, expectError: true},
+		{name: "String field", value: document, jsonField: "credentials.password", expected: "s3cret"},
+		{name: "Number field", value: document, jsonField: "credentials.port", expected: "5432"},
+		{name: "Array element", value: document, jsonField: "hosts.1", expected: "b"},
+		{name: "Object field", value: document, jsonField: "hosts", expected: "[\n  \"a\",\n  \"b\"\n]"},
+		{name: "Missing field", value: document, jsonField: "credentials.token", expectError: true},
+		{name: "Not JSON", value: "plain", jsonField: "password", expectError: true},
+		{name: "Empty segment", value: document, jsonField: "credentials..password", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path []string
+			var err error
+			if tt.jsonField != "" {
+				path, err = parseJSONFieldPath(tt.jsonField)
+			}
+			result := ""
+			if err == nil {
+				result, err = transformGetValue(tt.value, tt.decodeBase64, path)
+			}
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("transformGetValue() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
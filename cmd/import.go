@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
 	"encoding/csv"
 	"fmt"
 	"io"
@@ -8,6 +11,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -22,28 +26,65 @@ var importCmd = &cobra.Command{
 The CSV file should have a header row with column names. Required columns:
 - name: Secret name
 - value: Secret value (required for creation)
+  Use the header 'value:base64' instead to store base64-encoded values,
+  which are decoded before being stored (useful for binary secrets).
 
 Optional columns:
 - title: Secret title (stored in config)
 - label:*: Labels to apply (e.g., label:env, label:team)
+- version: Version the value was exported from ('export --version-column').
+  Secret Manager assigns version numbers, so this is informational: it is
+  validated and shown in the output, and the value becomes a new version.
 - Any other columns are treated as config attributes
 
+Files with a .json extension are read as JSON manifests written by
+'export --format json'; they are processed exactly like the equivalent CSV.
+
 The CSV format supports Excel-style multi-line cells (cells containing newlines
 are properly quoted and escaped).
 
+Rows with an empty value (or files without a value column) never create or
+update secret values unless --allow-empty-value is given; titles and attributes
+are still written with --update-config.
+
 By default, existing secrets are skipped. Use --update to update existing secrets
 or --upsert to create new secrets and update existing ones.
 
-The --update-config flag will update the configuration file with titles and
-attributes from the CSV.
+Names are checked against the Secret Manager naming rules (letters, digits,
+hyphens, and underscores, at most 255 characters) before calling gcloud.
 
-When a prefix is configured, all CSV names must include the prefix. Names that
-do not match the configured prefix are skipped to prevent cross-environment
-pollution.`,
+--on-error decides what happens when a row fails (an invalid secret name,
+base64 value, or version, or a failed create or update):
+  continue  process every row, then exit with an error if any row failed (default)
+  abort     stop at the first failed row and exit with an error
+  skip-row  process every row and count failed rows as skipped (exit status 0)
+Rows skipped for other reasons (existing secrets, names outside the prefix,
+empty values) never count as failures.
+
+The --update-config flag will update the configuration file with titles and
+attributes from the CSV. By default the loaded configuration file is rewritten;
+use --config-output to write the updated configuration to a different file.
+
+When a prefix is configured, all CSV names must include the prefix. Unlike
+create and get, import never adds the prefix to names: names that do not match
+the configured prefix are skipped to prevent cross-environment pollution. Use
+--no-prefix to import every name exactly as written in the CSV; the prefix is
+then neither required nor added, and is only stripped from names recorded in
+the configuration file.
+
+--summary-only drops the line printed for each secret (created, updated,
+skipped, or would be processed) and prints only warnings, errors, and the
+final summary.`,
 	Example: `  gsecutil import secrets.csv
   gsecutil import secrets.csv --update
   gsecutil import secrets.csv --upsert
-  gsecutil import secrets.csv --dry-run`,
+  gsecutil import secrets.csv --dry-run
+  gsecutil import binary-secrets.csv --value-base64
+  gsecutil import secrets.json --dry-run
+  gsecutil import secrets.csv --update-config --config-output team-config.yaml
+  gsecutil import shared-secrets.csv --no-prefix
+  gsecutil import secrets.csv --upsert --on-error abort
+  gsecutil import secrets.csv --upsert --summary-only`,
 	Args: cobra.ExactArgs(1),
 	RunE: runImport,
 }
@@ -54,27 +95,66 @@ func init() {
 	importCmd.Flags().Bool("upsert", false, "Create or update secrets (upsert)")
 	importCmd.Flags().Bool("dry-run", false, "Show what would be done without making changes")
 	importCmd.Flags().Bool("update-config", false, "Update configuration file with metadata from CSV")
+	importCmd.Flags().String("config-output", "", "Write the updated configuration to this file instead of the loaded one (requires --update-config)")
+	importCmd.Flags().Bool("allow-empty-value", false, "Store empty values instead of skipping them")
+	importCmd.Flags().Bool("no-prefix", false, "Use CSV names exactly as written, without requiring the configured prefix")
+	importCmd.Flags().Bool("value-base64", false, "Decode the value column from base64 before storing (same as a 'value:base64' header)")
+	importCmd.Flags().String("on-error", importOnErrorContinue, "What to do when a row fails: continue, abort, or skip-row")
+	importCmd.Flags().Bool("summary-only", false, "Print only warnings, errors, and the final summary, without a line per secret")
+}
+
+// Policies accepted by import --on-error
+const (
+	importOnErrorContinue = "continue"
+	importOnErrorAbort    = "abort"
+	importOnErrorSkipRow  = "skip-row"
+)
+
+// validateImportOnError checks an --on-error value
+func validateImportOnError(policy string) error {
+	switch policy {
+	case importOnErrorContinue, importOnErrorAbort, importOnErrorSkipRow:
+		return nil
+	}
+	return fmt.Errorf("invalid --on-error value '%s' (use continue, abort, or skip-row)", policy)
 }
 
 func runImport(cmd *cobra.Command, args []string) error {
 	project, _ := cmd.Flags().GetString("project")
-	project = GetProject(project)
+	project, err := resolveProject(project)
+	if err != nil {
+		return err
+	}
 	prefix := GetPrefix()
 	importUpdate, _ := cmd.Flags().GetBool("update")
 	importUpsert, _ := cmd.Flags().GetBool("upsert")
 	importDryRun, _ := cmd.Flags().GetBool("dry-run")
 	importUpdateConfig, _ := cmd.Flags().GetBool("update-config")
+	importValueBase64, _ := cmd.Flags().GetBool("value-base64")
+	importConfigOutput, _ := cmd.Flags().GetString("config-output")
+	importAllowEmptyValue, _ := cmd.Flags().GetBool("allow-empty-value")
+	importNoPrefix, _ := cmd.Flags().GetBool("no-prefix")
+	importOnError, _ := cmd.Flags().GetString("on-error")
+	summaryOnly, _ := cmd.Flags().GetBool("summary-only")
+	progress := progressPrinter{suppressed: summaryOnly}
+
+	if err := validateImportOnError(importOnError); err != nil {
+		return err
+	}
+	if importConfigOutput != "" && !importUpdateConfig {
+		return fmt.Errorf("--config-output requires --update-config")
+	}
 
 	csvFile := args[0]
 
-	// Read CSV file
-	records, header, err := readCsvFile(csvFile)
+	// Read CSV file (or JSON manifest)
+	records, header, err := readImportFile(csvFile)
 	if err != nil {
 		return err
 	}
 
 	if len(records) == 0 {
-		fmt.Println("No records found in CSV file")
+		fmt.Println("No records found in import file")
 		return nil
 	}
 
@@ -83,9 +163,15 @@ func runImport(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	decodeValues := importValueBase64 || isBase64ValueColumn(header, valueIdx)
+	versionIdx := findVersionColumn(header)
 
-	// Get existing secrets
-	existingSecrets, err := getExistingSecretNames(project, prefix)
+	// Get existing secrets; literal names may fall outside the prefix
+	existingPrefix := prefix
+	if importNoPrefix {
+		existingPrefix = ""
+	}
+	existingSecrets, err := secretManager.ListNames(project, existingPrefix)
 	if err != nil {
 		return fmt.Errorf("failed to get existing secrets: %w", err)
 	}
@@ -102,7 +188,13 @@ func runImport(cmd *cobra.Command, args []string) error {
 
 	// Process records
 	stats := &importStats{}
+	abortedRow := 0
 	for i, record := range records {
+		if abortedRow > 0 {
+			stats.notProcessed = len(records) - i
+			break
+		}
+
 		if len(record) != len(header) {
 			fmt.Printf("Warning: Row %d has %d columns, expected %d. Skipping.\n", i+2, len(record), len(header))
 			stats.skipped++
@@ -116,17 +208,55 @@ func runImport(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
-		resolvedName, bareName, skip, skipReason := resolveImportSecretName(userInputName, prefix)
+		var resolvedName, bareName, skipReason string
+		var skip bool
+		if importNoPrefix {
+			resolvedName, bareName = resolveLiteralImportSecretName(userInputName, prefix)
+		} else {
+			resolvedName, bareName, skip, skipReason = resolveImportSecretName(userInputName, prefix)
+		}
 		if skip {
 			fmt.Printf("Warning: Row %d skipped: %s\n", i+2, skipReason)
 			stats.skipped++
 			continue
 		}
+		if err := validateSecretName(resolvedName); err != nil {
+			fmt.Printf("Error: Row %d: %v\n", i+2, err)
+			if stats.recordFailure(importOnError) {
+				abortedRow = i + 2
+			}
+			continue
+		}
 
 		value := ""
 		if valueIdx >= 0 {
 			value = record[valueIdx]
 		}
+		if decodeValues {
+			decoded, err := decodeBase64Value(value)
+			if err != nil {
+				fmt.Printf("Error: Row %d (%s) has an invalid base64 value: %v\n", i+2, resolvedName, err)
+				if stats.recordFailure(importOnError) {
+					abortedRow = i + 2
+				}
+				continue
+			}
+			value = decoded
+		}
+		version := ""
+		if versionIdx >= 0 {
+			if version, err = parseImportVersion(record[versionIdx]); err != nil {
+				fmt.Printf("Error: Row %d (%s): %v\n", i+2, resolvedName, err)
+				if stats.recordFailure(importOnError) {
+					abortedRow = i + 2
+				}
+				continue
+			}
+		}
+		versionNote := ""
+		if version != "" {
+			versionNote = fmt.Sprintf(" (exported from version %s)", version)
+		}
 		exists := existingSecrets[resolvedName]
 
 		// Determine action
@@ -137,13 +267,13 @@ func runImport(cmd *cobra.Command, args []string) error {
 			} else if importUpdate {
 				action = "update"
 			} else {
-				fmt.Printf("Secret '%s' already exists. Skipping. (Use --update or --upsert to update)\n", resolvedName)
+				progress.Printf("Secret '%s' already exists. Skipping. (Use --update or --upsert to update)\n", resolvedName)
 				stats.skipped++
 				continue
 			}
 		} else {
 			if importUpdate {
-				fmt.Printf("Secret '%s' does not exist. Skipping. (Use --upsert to create)\n", resolvedName)
+				progress.Printf("Secret '%s' does not exist. Skipping. (Use --upsert to create)\n", resolvedName)
 				stats.skipped++
 				continue
 			} else {
@@ -159,17 +289,26 @@ func runImport(cmd *cobra.Command, args []string) error {
 			updateConfigWithMetadata(config, bareName, title, attributes)
 		}
 
+		// Never store an empty value (e.g. a metadata-only CSV) unless explicitly allowed
+		if checkEmptySecretValue(value, importAllowEmptyValue) != nil {
+			progress.Printf("Secret '%s' has an empty value. Skipping %s. (Use --allow-empty-value to store an empty value)\n", resolvedName, action)
+			stats.skipped++
+			continue
+		}
+
 		// Perform action
 		if importDryRun {
-			fmt.Printf("[DRY-RUN] Would %s secret: %s\n", action, resolvedName)
+			progress.Printf("[DRY-RUN] Would %s secret: %s%s\n", action, resolvedName, versionNote)
 			stats.processed++
 		} else {
 			if err := performSecretAction(action, resolvedName, value, labels, project); err != nil {
 				fmt.Printf("Error %sing secret '%s': %v\n", action, resolvedName, err)
-				stats.failed++
+				if stats.recordFailure(importOnError) {
+					abortedRow = i + 2
+				}
 			} else {
 				actionDone := map[string]string{"create": "Created", "update": "Updated"}[action]
-				fmt.Printf("%s secret: %s\n", actionDone, resolvedName)
+				progress.Printf("%s secret: %s%s\n", actionDone, resolvedName, versionNote)
 				if action == "create" {
 					stats.created++
 				} else {
@@ -180,35 +319,95 @@ func runImport(cmd *cobra.Command, args []string) error {
 	}
 
 	// Save config if updated
+	configOutputPath := importConfigOutput
+	if configOutputPath == "" {
+		configOutputPath = resolveConfigSavePath()
+	}
+	configSaved := false
 	if importUpdateConfig && config != nil && !importDryRun {
-		if err := saveConfig(config); err != nil {
+		if err := saveConfigTo(config, configOutputPath); err != nil {
 			fmt.Printf("Warning: Failed to save configuration file: %v\n", err)
 		} else {
-			fmt.Println("Configuration file updated with metadata from CSV")
+			configSaved = true
+			progress.Printf("Configuration file %s updated with metadata from CSV\n", configOutputPath)
 		}
 	}
 
 	// Print summary
-	fmt.Println()
+	progress.Printf("\n")
 	fmt.Println("Import Summary:")
 	if importDryRun {
 		fmt.Printf("  Would process: %d\n", stats.processed)
+		if stats.failed > 0 {
+			fmt.Printf("  Failed: %d\n", stats.failed)
+		}
 	} else {
 		fmt.Printf("  Created: %d\n", stats.created)
 		fmt.Printf("  Updated: %d\n", stats.updated)
 		fmt.Printf("  Failed: %d\n", stats.failed)
 	}
 	fmt.Printf("  Skipped: %d\n", stats.skipped)
+	if abortedRow > 0 {
+		fmt.Printf("  Not processed: %d\n", stats.notProcessed)
+	}
+	if importUpdateConfig {
+		if importDryRun {
+			fmt.Printf("  Config: %s (would be updated)\n", configOutputPath)
+		} else if configSaved {
+			fmt.Printf("  Config: %s\n", configOutputPath)
+		}
+	}
 
-	return nil
+	return importOutcome(stats, abortedRow)
 }
 
 type importStats struct {
-	created   int
-	updated   int
-	failed    int
-	skipped   int
-	processed int
+	created      int
+	updated      int
+	failed       int
+	skipped      int
+	processed    int
+	notProcessed int
+}
+
+// recordFailure counts a failed row under the --on-error policy and reports
+// whether the import must stop. skip-row counts the row as skipped instead.
+func (s *importStats) recordFailure(policy string) bool {
+	if policy == importOnErrorSkipRow {
+		s.skipped++
+		return false
+	}
+	s.failed++
+	return policy == importOnErrorAbort
+}
+
+// importOutcome returns the error an import ends with: aborting at a row and
+// failed rows are errors, so scripts see a non-zero exit status
+func importOutcome(stats *importStats, abortedRow int) error {
+	if abortedRow > 0 {
+		return fmt.Errorf("import aborted at row %d (--on-error abort); %d row(s) not processed", abortedRow, stats.notProcessed)
+	}
+	if stats.failed > 0 {
+		return fmt.Errorf("%d row(s) failed to import", stats.failed)
+	}
+	return nil
+}
+
+// readImportFile reads an import file, treating files with a .json extension
+// as JSON manifests produced by 'export --format json' and everything else as CSV
+func readImportFile(filename string) ([][]string, []string, error) {
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read JSON manifest: %w", err)
+		}
+		header, records, err := parseManifest(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		return records, header, nil
+	}
+	return readCsvFile(filename)
 }
 
 func readCsvFile(filename string) ([][]string, []string, error) {
@@ -218,7 +417,20 @@ func readCsvFile(filename string) ([][]string, []string, error) {
 	}
 	defer file.Close()
 
-	reader := csv.NewReader(file)
+	return readCsvData(file)
+}
+
+// readCsvData reads a CSV header and records from r. A leading provenance
+// line written by 'export --with-header-comment' is skipped.
+func readCsvData(r io.Reader) ([][]string, []string, error) {
+	buffered := bufio.NewReader(r)
+	if first, err := buffered.Peek(len(exportHeaderCommentPrefix)); err == nil && string(first) == exportHeaderCommentPrefix {
+		if _, err := buffered.ReadString('\n'); err != nil && err != io.EOF {
+			return nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+		}
+	}
+
+	reader := csv.NewReader(buffered)
 	// Enable support for multi-line fields (Excel format)
 	reader.LazyQuotes = true
 	reader.TrimLeadingSpace = true
@@ -279,9 +491,15 @@ func validateHeader(header []string) (nameIdx, valueIdx int, err error) {
 	// Find required columns
 	for i, col := range header {
 		col = strings.ToLower(strings.TrimSpace(col))
+		if col == redactedValueColumn {
+			return -1, -1, fmt.Errorf("CSV contains a '%s' column from a redacted export and cannot be imported", redactedValueColumn)
+		}
 		if col == "name" {
 			nameIdx = i
-		} else if col == "value" {
+		} else if col == "value" || col == base64ValueColumn {
+			if valueIdx != -1 {
+				return -1, -1, fmt.Errorf("CSV header cannot contain both 'value' and '%s' columns", base64ValueColumn)
+			}
 			valueIdx = i
 		}
 	}
@@ -293,39 +511,109 @@ func validateHeader(header []string) (nameIdx, valueIdx int, err error) {
 	return nameIdx, valueIdx, nil
 }
 
+// versionColumn is the header of the informational version number column
+// written by 'export --version-column'
+const versionColumn = "version"
+
+// findVersionColumn returns the index of the version column, or -1
+func findVersionColumn(header []string) int {
+	for i, col := range header {
+		if strings.ToLower(strings.TrimSpace(col)) == versionColumn {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseImportVersion validates a version cell: empty, or a positive version number
+func parseImportVersion(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	if number, err := strconv.Atoi(value); err != nil || number < 1 {
+		return "", fmt.Errorf("version '%s' is not a version number", value)
+	}
+	return value, nil
+}
+
+// base64ValueColumn is the header name for a value column holding base64-encoded data
+const base64ValueColumn = "value:base64"
+
+// isBase64ValueColumn reports whether the value column is declared as base64-encoded
+func isBase64ValueColumn(header []string, valueIdx int) bool {
+	if valueIdx < 0 || valueIdx >= len(header) {
+		return false
+	}
+	return strings.ToLower(strings.TrimSpace(header[valueIdx])) == base64ValueColumn
+}
+
+// decodeBase64Value decodes a base64-encoded CSV value. Whitespace (including
+// line breaks from wrapped multi-line cells) is ignored.
+func decodeBase64Value(value string) (string, error) {
+	cleaned := strings.Join(strings.Fields(value), "")
+	decoded, err := base64.StdEncoding.DecodeString(cleaned)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
+
+// getExistingSecretNames returns the IDs of the secrets in a project. With a
+// prefix, only secrets starting with it are listed, and gcloud filters them
+// server-side so imports scoped to a prefix do not page through the whole
+// project. The output is read line by line as gcloud produces it.
 func getExistingSecretNames(project, prefix string) (map[string]bool, error) {
 	gcloudArgs := []string{"secrets", "list", "--format", "value(name)"}
+	if filter := gcloudPrefixFilter("", prefix); filter != "" {
+		gcloudArgs = append(gcloudArgs, "--filter", filter)
+	}
 	if project != "" {
 		gcloudArgs = append(gcloudArgs, "--project", project)
 	}
 
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-	output, err := gcloudCmd.Output()
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
+	var stderr bytes.Buffer
+	gcloudCmd.Stderr = &stderr
+	stdout, err := gcloudCmd.StdoutPipe()
 	if err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
-			return nil, formatGcloudError(string(exitError.Stderr))
+		return nil, fmt.Errorf("failed to execute gcloud command: %v", err)
+	}
+	if err := gcloudCmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to execute gcloud command: %v", err)
+	}
+
+	secrets, scanErr := scanSecretNames(stdout, prefix)
+	if err := gcloudCmd.Wait(); err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return nil, formatGcloudError(stderr.String())
 		}
 		return nil, fmt.Errorf("failed to execute gcloud command: %v", err)
 	}
+	if scanErr != nil {
+		return nil, fmt.Errorf("failed to read secret list: %w", scanErr)
+	}
+	return secrets, nil
+}
 
+// scanSecretNames reads secret resource names, one per line, into a set of
+// secret IDs, skipping those without prefix
+func scanSecretNames(r io.Reader, prefix string) (map[string]bool, error) {
 	secrets := make(map[string]bool)
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		name := strings.TrimSpace(line)
-		if name != "" {
-			// Extract just the secret name from full path
-			parts := strings.Split(name, "/")
-			if len(parts) > 0 {
-				name = parts[len(parts)-1]
-			}
-			if prefix != "" && !strings.HasPrefix(name, prefix) {
-				continue
-			}
-			secrets[name] = true
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		name := strings.TrimSpace(scanner.Text())
+		if name == "" {
+			continue
 		}
+		// Extract just the secret name from full path
+		name = name[strings.LastIndex(name, "/")+1:]
+		if prefix != "" && !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		secrets[name] = true
 	}
-
-	return secrets, nil
+	return secrets, scanner.Err()
 }
 
 func resolveImportSecretName(userInputName, prefix string) (resolvedName, bareName string, skip bool, skipReason string) {
@@ -345,7 +633,17 @@ func resolveImportSecretName(userInputName, prefix string) (resolvedName, bareNa
 		return userInputName, bareName, false, ""
 	}
 
-	return "", "", true, fmt.Sprintf("name '%s' does not match configured prefix '%s'", userInputName, prefix)
+	return "", "", true, fmt.Sprintf("name '%s' does not match configured prefix '%s' (import does not add the prefix; use --no-prefix to import names as-is)", userInputName, prefix)
+}
+
+// resolveLiteralImportSecretName resolves a name for 'import --no-prefix': the
+// CSV name is the secret name, and the prefix is only stripped for the config entry
+func resolveLiteralImportSecretName(userInputName, prefix string) (resolvedName, bareName string) {
+	bareName = strings.TrimPrefix(userInputName, prefix)
+	if bareName == "" {
+		bareName = userInputName
+	}
+	return userInputName, bareName
 }
 
 func extractColumnsData(header, record []string, nameIdx, valueIdx int) (map[string]string, string, map[string]string) {
@@ -371,6 +669,9 @@ func extractColumnsData(header, record []string, nameIdx, valueIdx int) (map[str
 			labels[labelKey] = value
 		} else if colLower == "title" {
 			title = value
+		} else if colLower == versionColumn {
+			// Informational only; handled by the import loop
+			continue
 		} else {
 			// Other columns are treated as attributes
 			attributes[col] = value
@@ -390,52 +691,24 @@ func performSecretAction(action, name, value string, labels map[string]string, p
 }
 
 func createSecretFromImport(name, value string, labels map[string]string, project string) error {
-	gcloudArgs := []string{"secrets", "create", name}
-
-	if project != "" {
-		gcloudArgs = append(gcloudArgs, "--project", project)
-	}
-
-	// Add labels
+	options := SecretCreateOptions{Labels: make([]string, 0, len(labels))}
 	for key, val := range labels {
-		gcloudArgs = append(gcloudArgs, "--labels", fmt.Sprintf("%s=%s", key, val))
+		options.Labels = append(options.Labels, fmt.Sprintf("%s=%s", key, val))
 	}
-
-	gcloudArgs = append(gcloudArgs, "--data-file", "-")
-
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-	gcloudCmd.Stdin = strings.NewReader(value)
-
-	output, err := gcloudCmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("%s", string(output))
-	}
-
-	return nil
+	sort.Strings(options.Labels)
+	return secretManager.Create(name, project, value, options)
 }
 
 func updateSecretFromImport(name, value string, project string) error {
-	gcloudArgs := []string{"secrets", "versions", "add", name}
-
-	if project != "" {
-		gcloudArgs = append(gcloudArgs, "--project", project)
-	}
-
-	gcloudArgs = append(gcloudArgs, "--data-file", "-")
-
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-	gcloudCmd.Stdin = strings.NewReader(value)
-
-	output, err := gcloudCmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("%s", string(output))
-	}
-
-	return nil
+	return secretManager.AddVersion(name, project, value)
 }
 
 func loadOrCreateConfig() (*Config, error) {
-	config, err := LoadConfig("")
+	if configDisabled {
+		return nil, errConfigDisabled
+	}
+	// Reload the file that was loaded (or --config), which saveConfig writes back to
+	config, err := LoadConfig(configFilePath)
 	if err != nil {
 		// If config doesn't exist, create new one
 		config = &Config{
@@ -480,20 +753,35 @@ func updateConfigWithMetadata(config *Config, name, title string, attributes map
 }
 
 func saveConfig(config *Config) error {
-	// Use the path of the loaded config file, or default path if not loaded
-	// This ensures we write to the same file that was read
-	configPath := configFilePath
-	if configPath == "" {
-		// No config was loaded yet, use default path
-		configPath = getDefaultConfigPath()
+	return saveConfigTo(config, resolveConfigSavePath())
+}
+
+// resolveConfigSavePath returns the path of the loaded config file, or the
+// default path if none was loaded. This ensures we write to the same file that was read.
+func resolveConfigSavePath() string {
+	if configFilePath != "" {
+		return configFilePath
 	}
+	return getDefaultConfigPath()
+}
 
+// saveConfigTo writes the configuration as YAML to the given path
+func saveConfigTo(config *Config, configPath string) error {
 	// Create directory if it doesn't exist
 	configDir := filepath.Dir(configPath)
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
 	}
 
+	// yaml.Marshal panics on an inline key that duplicates a struct field
+	for _, cred := range config.Credentials {
+		for _, field := range reservedCredentialFields {
+			if _, exists := cred.Attributes[field]; exists {
+				return fmt.Errorf("credential '%s' has an attribute named '%s', which is reserved", cred.Name, field)
+			}
+		}
+	}
+
 	// Marshal to YAML
 	yamlData, err := yaml.Marshal(config)
 	if err != nil {
@@ -501,7 +789,7 @@ func saveConfig(config *Config) error {
 	}
 
 	// Write to file
-	if err := os.WriteFile(configPath, yamlData, 0644); err != nil {
+	if err := atomicWriteFile(configPath, yamlData, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
 
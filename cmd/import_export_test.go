@@ -4,7 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 // TestValidateHeader tests CSV header validation with duplicate detection
@@ -75,6 +77,25 @@ func TestValidateHeader(t *testing.T) {
 			expectError: true,
 			errorMsg:    "duplicate column names: 'label:env'",
 		},
+		{
+			name:        "Base64 value column",
+			header:      []string{"name", "title", "value:base64"},
+			expectError: false,
+			nameIdx:     0,
+			valueIdx:    2,
+		},
+		{
+			name:        "Redacted export cannot be imported",
+			header:      []string{"name", "value:redacted", "title"},
+			expectError: true,
+			errorMsg:    "redacted export",
+		},
+		{
+			name:        "Both value and base64 value columns",
+			header:      []string{"name", "value", "Value:Base64"},
+			expectError: true,
+			errorMsg:    "cannot contain both 'value' and 'value:base64'",
+		},
 	}
 
 	for _, tt := range tests {
@@ -182,6 +203,18 @@ func TestExtractColumnsData(t *testing.T) {
 				"Owner": "alice",
 			},
 		},
+		{
+			name:           "Base64 value column is not an attribute",
+			header:         []string{"name", "value:base64", "owner"},
+			record:         []string{"test-secret", "c2VjcmV0", "alice"},
+			nameIdx:        0,
+			valueIdx:       1,
+			expectedLabels: map[string]string{},
+			expectedTitle:  "",
+			expectedAttrs: map[string]string{
+				"owner": "alice",
+			},
+		},
 		{
 			name:           "No value column",
 			header:         []string{"name", "title", "owner"},
@@ -215,6 +248,65 @@ func TestExtractColumnsData(t *testing.T) {
 	}
 }
 
+// TestIsBase64ValueColumn tests detection of the value:base64 header
+func TestIsBase64ValueColumn(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   []string
+		valueIdx int
+		expected bool
+	}{
+		{"Plain value column", []string{"name", "value"}, 1, false},
+		{"Base64 value column", []string{"name", "value:base64"}, 1, true},
+		{"Mixed case with spaces", []string{"name", " Value:Base64 "}, 1, true},
+		{"No value column", []string{"name", "title"}, -1, false},
+		{"Index out of range", []string{"name"}, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isBase64ValueColumn(tt.header, tt.valueIdx); got != tt.expected {
+				t.Errorf("isBase64ValueColumn(%v, %d) = %v, expected %v", tt.header, tt.valueIdx, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestDecodeBase64Value tests decoding of base64-encoded import values
+func TestDecodeBase64Value(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "Simple text", value: "c2VjcmV0LXZhbHVl", expected: "secret-value"},
+		{name: "Binary data", value: "AAH/", expected: "\x00\x01\xff"},
+		{name: "Wrapped multi-line cell", value: "c2VjcmV0\nLXZhbHVl\n", expected: "secret-value"},
+		{name: "Empty value", value: "", expected: ""},
+		{name: "Invalid characters", value: "not base64!", wantErr: true},
+		{name: "Bad padding", value: "c2VjcmV0LXZhbHVl=", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeBase64Value(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error for %q but got none", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("decodeBase64Value(%q) = %q, expected %q", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
 // TestUpdateConfigWithMetadata tests updating config with metadata from CSV
 func TestUpdateConfigWithMetadata(t *testing.T) {
 	tests := []struct {
@@ -404,6 +496,23 @@ secret2,value2,Title 2,staging,bob`,
 			expectedRecords: 0,
 			expectError:     true,
 		},
+		{
+			name: "CSV with export header comment",
+			csvContent: `# exported by gsecutil v1.2.0 on 2025-06-01T12:00:00Z from project my-project, 2 secrets
+name,value,title
+secret1,value1,Title 1
+secret2,value2,Title 2`,
+			expectedHeader:  []string{"name", "value", "title"},
+			expectedRecords: 2,
+			expectError:     false,
+		},
+		{
+			name:            "Export header comment only",
+			csvContent:      "# exported by gsecutil v1.2.0 on 2025-06-01T12:00:00Z from project my-project, 0 secrets\n",
+			expectedHeader:  nil,
+			expectedRecords: 0,
+			expectError:     true,
+		},
 		{
 			name:            "CSV with header only",
 			csvContent:      `name,value,title`,
@@ -446,6 +555,20 @@ secret2,value2,Title 2,staging,bob`,
 	}
 }
 
+// TestExportHeaderComment tests the provenance line of export --with-header-comment
+func TestExportHeaderComment(t *testing.T) {
+	now := time.Date(2025, 6, 1, 21, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	expected := "# exported by gsecutil v1.2.0 on 2025-06-01T12:00:00Z from project my-project, 12 secrets"
+	for _, version := range []string{"1.2.0", "v1.2.0"} {
+		if comment := exportHeaderComment(version, now, "my-project", 12); comment != expected {
+			t.Errorf("exportHeaderComment(%q) = %q, expected %q", version, comment, expected)
+		}
+	}
+	if comment := exportHeaderComment("", now, "p", 1); !strings.HasPrefix(comment, exportHeaderCommentPrefix+" vdev ") {
+		t.Errorf("Expected a dev version without a build version, got %q", comment)
+	}
+}
+
 // TestResolveImportSecretName tests the import secret name resolution with prefix validation
 func TestResolveImportSecretName(t *testing.T) {
 	tests := []struct {
@@ -549,6 +672,34 @@ func TestResolveImportSecretName(t *testing.T) {
 	}
 }
 
+// TestResolveLiteralImportSecretName tests name resolution for import --no-prefix
+func TestResolveLiteralImportSecretName(t *testing.T) {
+	tests := []struct {
+		name         string
+		prefix       string
+		userInput    string
+		expectedRes  string
+		expectedBare string
+	}{
+		{name: "prefixed name kept, prefix stripped for config", prefix: "dev-", userInput: "dev-my-secret", expectedRes: "dev-my-secret", expectedBare: "my-secret"},
+		{name: "unprefixed name not prefixed", prefix: "dev-", userInput: "shared-secret", expectedRes: "shared-secret", expectedBare: "shared-secret"},
+		{name: "no prefix configured", prefix: "", userInput: "my-secret", expectedRes: "my-secret", expectedBare: "my-secret"},
+		{name: "name equals prefix", prefix: "dev-", userInput: "dev-", expectedRes: "dev-", expectedBare: "dev-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, bare := resolveLiteralImportSecretName(tt.userInput, tt.prefix)
+			if resolved != tt.expectedRes {
+				t.Errorf("Expected resolved=%q, got %q", tt.expectedRes, resolved)
+			}
+			if bare != tt.expectedBare {
+				t.Errorf("Expected bare=%q, got %q", tt.expectedBare, bare)
+			}
+		})
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
 		(len(s) > 0 && len(substr) > 0 && stringContains(s, substr)))
@@ -610,7 +761,7 @@ func TestPrepareCsvRecords(t *testing.T) {
 			defer func() { globalConfig = originalConfig }()
 			globalConfig = &Config{Credentials: []CredentialInfo{}}
 
-			records := prepareCsvRecords(tt.secrets, tt.withValues, "test-project")
+			records := prepareCsvRecords(tt.secrets, tt.withValues, false, false, "test-project")
 
 			if len(records) == 0 {
 				t.Error("Expected at least header row")
@@ -627,6 +778,85 @@ func TestPrepareCsvRecords(t *testing.T) {
 	}
 }
 
+// TestRedactSecretValue tests the fingerprint placeholder used by export --redact
+func TestRedactSecretValue(t *testing.T) {
+	redacted := redactSecretValue("super-secret")
+	if !strings.HasPrefix(redacted, "sha256:") {
+		t.Errorf("Expected sha256: prefix, got %q", redacted)
+	}
+	if strings.Contains(redacted, "super-secret") {
+		t.Errorf("Redacted value leaks the original: %q", redacted)
+	}
+	if len(redacted) != len("sha256:")+16 {
+		t.Errorf("Expected 16 hex chars after prefix, got %q", redacted)
+	}
+	if redactSecretValue("super-secret") != redacted {
+		t.Error("Expected redaction to be deterministic")
+	}
+	if redactSecretValue("other-secret") == redacted {
+		t.Error("Expected different values to have different fingerprints")
+	}
+	if got := redactSecretValue(secretValueErrorPlaceholder); got != secretValueErrorPlaceholder {
+		t.Errorf("Expected error placeholder to pass through, got %q", got)
+	}
+}
+
+// TestPrepareCsvRecordsRedactHeader tests the value column header of redacted exports
+func TestPrepareCsvRecordsRedactHeader(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Credentials: []CredentialInfo{}}
+
+	records := prepareCsvRecords([]SecretInfo{}, true, true, false, "test-project")
+	expected := []string{"name", "value:redacted", "title"}
+	if !reflect.DeepEqual(records[0], expected) {
+		t.Errorf("Header = %v, expected %v", records[0], expected)
+	}
+}
+
+// TestPrepareCsvRecordsVersionHeader tests the position of the version column
+func TestPrepareCsvRecordsVersionHeader(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Credentials: []CredentialInfo{}}
+
+	records := prepareCsvRecords([]SecretInfo{}, true, false, true, "test-project")
+	expected := []string{"name", "value", versionColumn, "title"}
+	if !reflect.DeepEqual(records[0], expected) {
+		t.Errorf("Header = %v, expected %v", records[0], expected)
+	}
+}
+
+// TestParseImportVersion tests validation of the informational version column
+func TestParseImportVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    string
+		expectError bool
+	}{
+		{name: "empty", value: "", expected: ""},
+		{name: "number", value: " 12 ", expected: "12"},
+		{name: "alias", value: "latest", expectError: true},
+		{name: "zero", value: "0", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseImportVersion(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("parseImportVersion(%q) expected error", tt.value)
+				}
+				return
+			}
+			if err != nil || result != tt.expected {
+				t.Errorf("parseImportVersion(%q) = %q, %v; expected %q", tt.value, result, err, tt.expected)
+			}
+		})
+	}
+}
+
 // TestLoadOrCreateConfig tests config loading/creation
 func TestLoadOrCreateConfig(t *testing.T) {
 	// Save original config
@@ -696,3 +926,198 @@ func TestSaveConfig(t *testing.T) {
 		t.Errorf("Config file was not created at expected path: %s", expectedPath)
 	}
 }
+
+// TestSaveConfigToOverridePath tests writing config to a path other than the loaded one
+func TestSaveConfigToOverridePath(t *testing.T) {
+	tempDir := t.TempDir()
+	loadedPath := filepath.Join(tempDir, "personal.yaml")
+	outputPath := filepath.Join(tempDir, "team", "team.yaml")
+
+	originalContent := "project: personal-project\n"
+	if err := os.WriteFile(loadedPath, []byte(originalContent), 0644); err != nil {
+		t.Fatalf("Failed to write loaded config: %v", err)
+	}
+
+	originalConfigPath := configFilePath
+	defer func() { configFilePath = originalConfigPath }()
+	configFilePath = loadedPath
+
+	if got := resolveConfigSavePath(); got != loadedPath {
+		t.Errorf("resolveConfigSavePath() = %q, expected %q", got, loadedPath)
+	}
+
+	config := &Config{
+		Credentials: []CredentialInfo{{Name: "shared-secret", Title: "Shared Secret"}},
+	}
+	if err := saveConfigTo(config, outputPath); err != nil {
+		t.Fatalf("saveConfigTo() failed: %v", err)
+	}
+
+	written, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("Output config was not written: %v", err)
+	}
+	if !strings.Contains(string(written), "shared-secret") {
+		t.Errorf("Output config missing credential: %s", written)
+	}
+
+	loaded, err := os.ReadFile(loadedPath)
+	if err != nil {
+		t.Fatalf("Failed to read loaded config: %v", err)
+	}
+	if string(loaded) != originalContent {
+		t.Errorf("Loaded config was modified: %s", loaded)
+	}
+}
+
+// TestParseExportColumns tests validation of the --columns list
+func TestParseExportColumns(t *testing.T) {
+	columns, err := parseExportColumns(" name , label:env,owner ")
+	if err != nil {
+		t.Fatalf("parseExportColumns() failed: %v", err)
+	}
+	if !reflect.DeepEqual(columns, []string{"name", "label:env", "owner"}) {
+		t.Errorf("Unexpected columns: %v", columns)
+	}
+
+	for _, value := range []string{"", "name,,title", "name,title,name", "title,label:env"} {
+		if _, err := parseExportColumns(value); err == nil {
+			t.Errorf("Expected parseExportColumns(%q) to fail", value)
+		}
+	}
+}
+
+// TestSelectExportColumns tests that --columns fixes the header regardless of the data
+func TestSelectExportColumns(t *testing.T) {
+	records := [][]string{
+		{"name", "title", "label:env", "owner"},
+		{"db", "Database", "prod", "alice"},
+		{"api", "", "", "bob"},
+	}
+	known := map[string]bool{"owner": true, "rotation_days": true}
+
+	selected, err := selectExportColumns(records, []string{"name", "owner", "label:team", "rotation_days", "title"}, known)
+	if err != nil {
+		t.Fatalf("selectExportColumns() failed: %v", err)
+	}
+	expected := [][]string{
+		{"name", "owner", "label:team", "rotation_days", "title"},
+		{"db", "alice", "", "", "Database"},
+		{"api", "bob", "", "", ""},
+	}
+	if !reflect.DeepEqual(selected, expected) {
+		t.Errorf("selectExportColumns() = %v, expected %v", selected, expected)
+	}
+
+	tests := []struct {
+		column      string
+		expectError string
+	}{
+		{"value", "--with-values"},
+		{redactedValueColumn, "--redact"},
+		{versionColumn, "--version-column"},
+		{"label:", "unknown column"},
+		{"team", "unknown column"},
+	}
+	for _, tt := range tests {
+		_, err := selectExportColumns(records, []string{"name", tt.column}, known)
+		if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+			t.Errorf("selectExportColumns(%s) error = %v, expected it to mention %q", tt.column, err, tt.expectError)
+		}
+	}
+}
+
+// TestImportOnErrorPolicy tests how --on-error counts failed rows and ends the import
+func TestImportOnErrorPolicy(t *testing.T) {
+	tests := []struct {
+		policy        string
+		expectAbort   bool
+		expectFailed  int
+		expectSkipped int
+		expectError   bool
+	}{
+		{policy: importOnErrorContinue, expectFailed: 1, expectError: true},
+		{policy: importOnErrorAbort, expectAbort: true, expectFailed: 1, expectError: true},
+		{policy: importOnErrorSkipRow, expectSkipped: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			if err := validateImportOnError(tt.policy); err != nil {
+				t.Fatalf("validateImportOnError(%s) failed: %v", tt.policy, err)
+			}
+			stats := &importStats{}
+			abort := stats.recordFailure(tt.policy)
+			if abort != tt.expectAbort || stats.failed != tt.expectFailed || stats.skipped != tt.expectSkipped {
+				t.Errorf("recordFailure() = %v with %+v", abort, stats)
+			}
+			abortedRow := 0
+			if abort {
+				abortedRow = 3
+			}
+			if err := importOutcome(stats, abortedRow); (err != nil) != tt.expectError {
+				t.Errorf("importOutcome() error = %v, expectError %v", err, tt.expectError)
+			}
+		})
+	}
+
+	if err := validateImportOnError("ignore"); err == nil {
+		t.Error("Expected error for unknown --on-error policy")
+	}
+	if err := importOutcome(&importStats{created: 2, skipped: 1}, 0); err != nil {
+		t.Errorf("Expected no error without failures, got %v", err)
+	}
+}
+
+// TestParseChangedSince tests the accepted --changed-since forms
+func TestParseChangedSince(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"2025-06-01T08:30:00Z", time.Date(2025, 6, 1, 8, 30, 0, 0, time.UTC)},
+		{"2025-06-01", time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"24h", time.Date(2025, 6, 9, 12, 0, 0, 0, time.UTC)},
+		{" 90m ", time.Date(2025, 6, 10, 10, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := parseChangedSince(tt.value, now)
+		if err != nil {
+			t.Errorf("parseChangedSince(%q) failed: %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.expected) {
+			t.Errorf("parseChangedSince(%q) = %v, expected %v", tt.value, got, tt.expected)
+		}
+	}
+
+	for _, value := range []string{"", "yesterday", "-24h", "2025-13-01"} {
+		if _, err := parseChangedSince(value, now); err == nil {
+			t.Errorf("Expected parseChangedSince(%q) to fail", value)
+		}
+	}
+}
+
+// TestSecretsChangedSince tests selecting secrets by latest version time
+func TestSecretsChangedSince(t *testing.T) {
+	cutoff := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	secrets := []SecretInfo{
+		{Name: "projects/p/secrets/old", LatestVersionTime: cutoff.Add(-time.Hour)},
+		{Name: "projects/p/secrets/new", LatestVersionTime: cutoff.Add(time.Hour)},
+		{Name: "projects/p/secrets/at-cutoff", LatestVersionTime: cutoff},
+		{Name: "projects/p/secrets/unknown"},
+	}
+
+	changed, unknown := secretsChangedSince(secrets, cutoff)
+	var names []string
+	for _, secret := range changed {
+		names = append(names, extractSecretName(secret.Name))
+	}
+	if expected := []string{"new", "unknown"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("secretsChangedSince() = %v, expected %v", names, expected)
+	}
+	if !reflect.DeepEqual(unknown, []string{"unknown"}) {
+		t.Errorf("Expected 'unknown' to be reported, got %v", unknown)
+	}
+}
@@ -3,13 +3,16 @@ package cmd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var listCmd = &cobra.Command{
@@ -26,63 +29,375 @@ Built-in Secret Manager fields are always preserved and shown.
 Examples:
   gsecutil list                             # List secrets with default attributes from config
   gsecutil list --show-labels               # List secrets with labels
-  gsecutil list --format json               # Raw JSON output
+  gsecutil list --format json               # Full gcloud records as JSON
+  gsecutil list --format markdown --show title,owner  # Inventory table for a wiki page
+  gsecutil list --fields name,owner,created --format csv  # Chosen columns, in order
   gsecutil list --filter "labels.env=prod"  # Filter by Secret Manager labels
+  gsecutil list --label env=prod --label team  # Labeled env=prod and having a team label
+  gsecutil list --filter-not "env=prod"     # Exclude secrets labeled env=prod
   gsecutil list --attr-filter "environment=prod"  # Filter by config attributes
   gsecutil list --show "title,owner,environment"  # Show: NAME + custom attributes + LABELS + CREATED
-  gsecutil list --principal user:alice@example.com  # List secrets accessible by a principal`,
+  gsecutil list --sort-attr owner --show owner  # Group secrets by their config owner
+  gsecutil list --principal user:alice@example.com  # List secrets accessible by a principal
+  gsecutil list --health                    # Flag secrets with operational issues
+  gsecutil list --only-unhealthy --format json  # Unhealthy secrets as JSON (for CI)
+  gsecutil list --format json --with-config  # Live state and config entry per secret
+  gsecutil list --compact                   # One line per secret: name [labels] (created)
+  gsecutil list --watch --interval 1m       # Re-render every minute, highlighting changes
+  gsecutil list --projects app-dev,app-prod  # List each project under its own header
+
+Health checks (--health) report: no-enabled-versions, single-version (no rollback
+target), public-access (allUsers/allAuthenticatedUsers binding), missing-title
+(no config title, only when the config defines credentials), and stale (latest
+version older than --stale-days).
+
+--label is a shorthand for label filters: --label env=prod matches secrets
+whose env label is prod, and --label env matches secrets that have an env label
+with any value. Repeated --label conditions are ANDed together and with
+--filter when both are given.
+
+--sort-attr orders the table (or json/yaml output) by the value of a
+configuration file attribute such as owner or environment instead of by name,
+and combines with --attr-filter. Secrets without the attribute, including
+those without a config entry, are listed last; ties are ordered by name.
+
+With --format json or yaml, each secret is printed as a record with a stable
+schema that does not depend on the gcloud version: {"name", "shortName",
+"createTime", "labels", "annotations"}, where name is the full resource name,
+shortName the secret ID, and labels and annotations are always objects.
+Records are filtered by the configured prefix, --filter-not, and --attr-filter
+and sorted by name like the table output. Add --raw-gcloud to print the full
+gcloud records instead, with every field gcloud returns. Other gcloud formats (csv, value, table(...))
+are passed through to gcloud with the prefix filter and name ordering applied.
+
+--format markdown prints the same columns as the table as a GitHub-flavored
+Markdown table, with | in values escaped.
+
+--fields chooses the columns and their order, replacing --show and the
+--show-* flags. It accepts the built-in fields name, labels, created, updated,
+and size, plus title and the attributes used in the configuration file. It
+applies to the table, --format markdown, and --format csv, whose header row
+holds the field names.
+
+With --with-config (requires --format json or yaml), each secret is printed as
+{"name", "live", "config"}: "live" holds the Secret Manager name, labels,
+annotations, creation time, and etag; "config" holds the title and attributes
+from the configuration file, or null when the secret has no config entry.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if projects, _ := cmd.Flags().GetString("projects"); projects != "" {
+			if watch, _ := cmd.Flags().GetBool("watch"); watch {
+				return fmt.Errorf("--watch cannot be combined with --projects")
+			}
+			return runAcrossProjects(cmd, args, projects)
+		}
 		project, _ := cmd.Flags().GetString("project")
 		filter, _ := cmd.Flags().GetString("filter")
+		labelConditions, _ := cmd.Flags().GetStringArray("label")
+		filterNot, _ := cmd.Flags().GetString("filter-not")
 		format, _ := cmd.Flags().GetString("format")
 		limit, _ := cmd.Flags().GetInt("limit")
 		showLabels, _ := cmd.Flags().GetBool("show-labels")
 		principal, _ := cmd.Flags().GetString("principal")
 		attrFilter, _ := cmd.Flags().GetString("attr-filter")
+		sortAttr, _ := cmd.Flags().GetString("sort-attr")
 		showAttributes, _ := cmd.Flags().GetString("show")
 		// Also check --show-attributes for backward compatibility during transition
 		if showAttributes == "" {
 			showAttributes, _ = cmd.Flags().GetString("show-attributes")
 		}
 		showUpdated, _ := cmd.Flags().GetBool("show-updated")
+		showSize, _ := cmd.Flags().GetBool("show-size")
+		health, _ := cmd.Flags().GetBool("health")
+		onlyUnhealthy, _ := cmd.Flags().GetBool("only-unhealthy")
+		staleDays, _ := cmd.Flags().GetInt("stale-days")
+		withConfig, _ := cmd.Flags().GetBool("with-config")
+		compact, _ := cmd.Flags().GetBool("compact")
+		rawGcloud, _ := cmd.Flags().GetBool("raw-gcloud")
+		watch, _ := cmd.Flags().GetBool("watch")
+		interval, _ := cmd.Flags().GetDuration("interval")
 
 		// Use configuration-based project resolution
-		project = GetProject(project)
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
+
+		if filter, err = labelFilterExpression(filter, labelConditions); err != nil {
+			return err
+		}
+		exclusions, err := parseLabelExclusions(filterNot)
+		if err != nil {
+			return err
+		}
+		structured := format == "json" || format == "yaml"
+		markdown := format == markdownFormat
+
+		var fields []secretListField
+		if cmd.Flags().Changed("fields") {
+			fieldsValue, _ := cmd.Flags().GetString("fields")
+			if fields, err = parseSecretListFields(fieldsValue); err != nil {
+				return err
+			}
+			for _, flag := range []string{"show", "show-attributes", "show-labels", "show-updated", "show-size", "compact", "health", "only-unhealthy", "principal", "with-config"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--fields cannot be combined with --%s", flag)
+				}
+			}
+			if format != "" && format != "table" && format != "csv" && !markdown {
+				return fmt.Errorf("--fields supports --format table, csv, or markdown, got %q", format)
+			}
+		}
+		// The table, Markdown, and --fields CSV output are rendered here rather than by gcloud
+		ownTable := format == "" || format == "table" || markdown || (format == "csv" && len(fields) > 0)
+		view := secretListView{
+			ShowAttributes: showAttributes,
+			ShowLabels:     showLabels,
+			ShowUpdated:    showUpdated,
+			ShowSize:       showSize,
+			Fields:         fields,
+		}
+		if format != "table" {
+			view.Format = format
+		}
+
+		if len(exclusions) > 0 && (principal != "" || (!ownTable && !structured)) {
+			return fmt.Errorf("--filter-not cannot be combined with --principal or custom --format output")
+		}
+
+		if cmd.Flags().Changed("sort-attr") {
+			if sortAttr == "" {
+				return fmt.Errorf("--sort-attr requires an attribute name")
+			}
+			if withConfig || compact || watch || health || onlyUnhealthy || principal != "" || (!ownTable && !structured) {
+				return fmt.Errorf("--sort-attr cannot be combined with --with-config, --compact, --watch, --health, --only-unhealthy, --principal, or custom --format output")
+			}
+		}
+
+		if rawGcloud && (!structured || withConfig || health || onlyUnhealthy || principal != "") {
+			return fmt.Errorf("--raw-gcloud requires --format json or yaml and cannot be combined with --with-config, --health, --only-unhealthy, or --principal")
+		}
+
+		// gcloud filters by the prefix, so --limit counts only secrets within
+		// it in every output format
+		filter = gcloudPrefixFilter(filter, GetPrefix())
+
+		// Merged live + config records for reconciliation tooling
+		if withConfig {
+			if format != "json" && format != "yaml" {
+				return fmt.Errorf("--with-config requires --format json or yaml")
+			}
+			if health || onlyUnhealthy || principal != "" {
+				return fmt.Errorf("--with-config cannot be combined with --health, --only-unhealthy, or --principal")
+			}
+			return listSecretsMergedWithConfig(project, filter, exclusions, limit, attrFilter, format)
+		}
+
+		// One line per secret, without column alignment
+		if compact {
+			if (format != "" && format != "table") || health || onlyUnhealthy || principal != "" || showUpdated || showSize {
+				return fmt.Errorf("--compact cannot be combined with --format, --health, --only-unhealthy, --principal, --show-updated, or --show-size")
+			}
+			return listSecretsCompact(project, filter, exclusions, limit, attrFilter)
+		}
+
+		// Re-render the table periodically until interrupted
+		if watch {
+			if (format != "" && format != "table") || health || onlyUnhealthy || principal != "" || compact {
+				return fmt.Errorf("--watch cannot be combined with --format, --health, --only-unhealthy, --principal, or --compact")
+			}
+			if interval < time.Second {
+				return fmt.Errorf("--interval must be at least 1s, got %s", interval)
+			}
+			if !term.IsTerminal(int(os.Stdout.Fd())) {
+				return fmt.Errorf("--watch requires an interactive terminal")
+			}
+			return watchSecretList(project, filter, exclusions, limit, attrFilter, view, interval)
+		}
+
+		// Health mode runs its own checks and supports table or json output
+		if health || onlyUnhealthy {
+			if format != "" && format != "table" && format != "json" && format != "yaml" {
+				return fmt.Errorf("--health supports --format table, json, or yaml, got %q", format)
+			}
+			for _, flag := range []string{"show", "show-attributes", "show-labels", "show-updated", "show-size", "principal"} {
+				if cmd.Flags().Changed(flag) {
+					return fmt.Errorf("--health cannot be combined with --%s", flag)
+				}
+			}
+			return listSecretsHealth(project, filter, exclusions, limit, attrFilter, format, onlyUnhealthy, staleDays)
+		}
 
 		// If principal is specified, list secrets accessible by that principal
 		if principal != "" {
-			return listSecretsForPrincipal(principal, project, showLabels, showUpdated)
+			if markdown {
+				return fmt.Errorf("--format markdown cannot be combined with --principal")
+			}
+			return listSecretsForPrincipal(principal, project, showLabels, showUpdated, showSize)
 		}
 
-		// If user specified a custom format, use the original gcloud passthrough approach
-		if format != "" && format != "table" {
+		// JSON and YAML are filtered and sorted like the table output
+		if structured {
+			return listSecretsStructured(project, filter, exclusions, limit, attrFilter, sortAttr, format, rawGcloud)
+		}
+
+		// Other gcloud formats (csv, value, table(...)) are rendered by gcloud itself
+		if !ownTable {
 			return runOriginalGcloudList(project, filter, format, limit)
 		}
 
 		// Handle configuration-based filtering
 		if attrFilter != "" {
-			return listSecretsWithConfigFiltering(project, filter, limit, attrFilter, showAttributes, showLabels, showUpdated)
+			return listSecretsWithConfigFiltering(project, filter, exclusions, limit, attrFilter, sortAttr, view)
 		}
 
 		// Enhanced list with potential config attributes
-		return listSecretsWithConfigAttributes(project, filter, limit, showAttributes, showLabels, showUpdated)
+		return listSecretsWithConfigAttributes(project, filter, exclusions, limit, sortAttr, view)
 	},
 }
 
-// runOriginalGcloudList runs the original gcloud list command for custom formats
-func runOriginalGcloudList(project, filter, format string, limit int) error {
-	// Inject prefix filter so custom formats respect the configured prefix
-	if prefix := GetPrefix(); prefix != "" {
-		prefixFilter := fmt.Sprintf("name:'/secrets/%s'", prefix)
-		if filter != "" {
-			filter = fmt.Sprintf("(%s) AND %s", filter, prefixFilter)
+// listSecretsStructured prints each secret as a ListedSecret record, or with
+// raw as the full gcloud record, in JSON or YAML, after the same prefix
+// filtering, label exclusions, attribute filtering, and sorting as the table output
+func listSecretsStructured(project, filter string, exclusions []labelExclusion, limit int, attrFilter, sortAttr, format string, raw bool) error {
+	output, err := runGcloudSecretsList(project, filter, limit)
+	if err != nil {
+		return err
+	}
+	allowed, err := credentialNamesMatchingAttributes(attrFilter)
+	if err != nil {
+		return err
+	}
+
+	records, err := selectListedSecretRecords(output, exclusions, allowed, sortAttr, raw)
+	if err != nil {
+		return err
+	}
+	return printStructuredOutput(records, format)
+}
+
+// ListedSecret is the record printed by 'list --format json|yaml'. Its schema
+// is gsecutil's own, so it stays the same when gcloud's output changes.
+type ListedSecret struct {
+	Name        string            `json:"name" yaml:"name"`
+	ShortName   string            `json:"shortName" yaml:"shortName"`
+	CreateTime  time.Time         `json:"createTime" yaml:"createTime"`
+	Labels      map[string]string `json:"labels" yaml:"labels"`
+	Annotations map[string]string `json:"annotations" yaml:"annotations"`
+}
+
+// newListedSecret converts a secret to its stable list record
+func newListedSecret(secret SecretInfo) ListedSecret {
+	listed := ListedSecret{
+		Name:        secret.Name,
+		ShortName:   extractSecretName(secret.Name),
+		CreateTime:  secret.CreateTime.UTC(),
+		Labels:      secret.Labels,
+		Annotations: secret.Annotations,
+	}
+	// Always emit objects so consumers never have to handle null
+	if listed.Labels == nil {
+		listed.Labels = map[string]string{}
+	}
+	if listed.Annotations == nil {
+		listed.Annotations = map[string]string{}
+	}
+	return listed
+}
+
+// selectListedSecretRecords decodes a 'gcloud secrets list' JSON array and
+// returns the records of the secrets the table output would show, sorted by
+// name, as ListedSecret values. With raw, records are kept as decoded so no
+// gcloud field is lost on re-encoding.
+func selectListedSecretRecords(output []byte, exclusions []labelExclusion, allowed map[string]bool, sortAttr string, raw bool) ([]interface{}, error) {
+	var rawRecords []json.RawMessage
+	if err := json.Unmarshal(output, &rawRecords); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets list: %w", err)
+	}
+
+	prefix := GetPrefix()
+	var secrets []SecretInfo
+	recordsByName := make(map[string]interface{}, len(rawRecords))
+	for _, rawRecord := range rawRecords {
+		var secret SecretInfo
+		var record interface{}
+		if err := json.Unmarshal(rawRecord, &secret); err != nil {
+			return nil, fmt.Errorf("failed to parse secrets list: %w", err)
+		}
+		if err := json.Unmarshal(rawRecord, &record); err != nil {
+			return nil, fmt.Errorf("failed to parse secrets list: %w", err)
+		}
+
+		secretName := extractSecretName(secret.Name)
+		if !FilterSecretsByPrefix(secretName) {
+			continue
+		}
+		if allowed != nil && !allowed[strings.TrimPrefix(secretName, prefix)] {
+			continue
+		}
+		secrets = append(secrets, secret)
+		recordsByName[secret.Name] = record
+	}
+	secrets = excludeSecretsByLabels(secrets, exclusions)
+	sortSecrets(secrets)
+	sortSecretsByAttribute(secrets, sortAttr)
+
+	// Always emit an array so consumers never have to handle null
+	records := make([]interface{}, 0, len(secrets))
+	for _, secret := range secrets {
+		if raw {
+			records = append(records, recordsByName[secret.Name])
 		} else {
-			filter = prefixFilter
+			records = append(records, newListedSecret(secret))
 		}
 	}
+	return records, nil
+}
 
+// labelFilterExpression translates --label conditions into gcloud filter
+// terms: "key=value" matches the label value and a bare "key" matches any
+// value. The terms are ANDed together and with filter, if any.
+func labelFilterExpression(filter string, labels []string) (string, error) {
+	var terms []string
+	for _, label := range labels {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(label), "=")
+		key = strings.TrimPrefix(strings.TrimSpace(key), "labels.")
+		if key == "" {
+			return "", fmt.Errorf("invalid --label '%s': expected key=value or key", label)
+		}
+		if hasValue {
+			terms = append(terms, fmt.Sprintf("labels.%s=%s", key, strings.TrimSpace(value)))
+		} else {
+			terms = append(terms, fmt.Sprintf("labels.%s:*", key))
+		}
+	}
+	if len(terms) == 0 {
+		return filter, nil
+	}
+	if filter != "" {
+		terms = append([]string{"(" + filter + ")"}, terms...)
+	}
+	return strings.Join(terms, " AND "), nil
+}
+
+// gcloudPrefixFilter returns a gcloud --filter expression matching secret IDs
+// that start with prefix, combined with the user's filter if any
+func gcloudPrefixFilter(filter, prefix string) string {
+	if prefix == "" {
+		return filter
+	}
+	prefixFilter := fmt.Sprintf("name~'^projects/[^/]+/secrets/%s'", regexp.QuoteMeta(prefix))
+	if filter == "" {
+		return prefixFilter
+	}
+	return fmt.Sprintf("(%s) AND %s", filter, prefixFilter)
+}
+
+// runOriginalGcloudList runs the original gcloud list command for custom
+// formats, with name ordering applied by gcloud. filter already includes the
+// configured prefix.
+func runOriginalGcloudList(project, filter, format string, limit int) error {
 	// Build gcloud command
-	gcloudArgs := []string{"secrets", "list"}
+	gcloudArgs := []string{"secrets", "list", "--sort-by", "name"}
 
 	if project != "" {
 		gcloudArgs = append(gcloudArgs, "--project", project)
@@ -101,7 +416,7 @@ func runOriginalGcloudList(project, filter, format string, limit int) error {
 	}
 
 	// Execute gcloud command
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
 	output, err := gcloudCmd.Output()
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
@@ -115,8 +430,8 @@ func runOriginalGcloudList(project, filter, format string, limit int) error {
 }
 
 // listSecretsWithLabels lists secrets with enhanced formatting including labels
-func listSecretsWithLabels(project, filter string, limit int, showLabels, showUpdated bool) error {
-	secrets, err := fetchSecrets(project, filter, limit)
+func listSecretsWithLabels(project, filter string, limit int, showLabels, showUpdated, showSize bool) error {
+	secrets, err := secretManager.List(project, filter, limit)
 	if err != nil {
 		return err
 	}
@@ -132,112 +447,212 @@ func listSecretsWithLabels(project, filter string, limit int, showLabels, showUp
 	if showUpdated {
 		enrichSecretsWithVersionTimes(secrets, project)
 	}
+	if showSize {
+		enrichSecretsWithValueSizes(secrets, project)
+	}
 
 	// Display secrets
 	if showLabels {
-		displaySecretsWithLabels(secrets, showUpdated)
+		displaySecretsWithLabels(secrets, showUpdated, showSize)
 	} else {
-		displaySecretsSimple(secrets, showUpdated)
+		displaySecretsSimple(secrets, showUpdated, showSize)
 	}
 
 	return nil
 }
 
 // displaySecretsWithLabels displays secrets in a table format with labels
-func displaySecretsWithLabels(secrets []SecretInfo, showUpdated bool) {
-	prefix := GetPrefix()
-	// Calculate column widths using terminal display width (wide chars = 2 cols)
-	maxNameWidth := 4    // "NAME"
-	maxLabelsWidth := 6  // "LABELS"
-	maxCreatedWidth := 7 // "CREATED"
-	maxUpdatedWidth := 7 // "UPDATED"
+func displaySecretsWithLabels(secrets []SecretInfo, showUpdated, showSize bool) {
+	printTextTable(secretTableColumns(secrets, nil, true, showUpdated, showSize))
+}
 
-	for _, secret := range secrets {
-		name := strings.TrimPrefix(extractSecretName(secret.Name), prefix)
-		if w := displayWidth(name); w > maxNameWidth {
-			maxNameWidth = w
-		}
-		labelsStr := formatLabels(secret.Labels)
-		if w := displayWidth(labelsStr); w > maxLabelsWidth {
-			maxLabelsWidth = w
-		}
-		createdStr := secret.CreateTime.UTC().Format(datetimeFormat)
-		if w := displayWidth(createdStr); w > maxCreatedWidth {
-			maxCreatedWidth = w
-		}
-		if showUpdated {
-			if w := displayWidth(formatUpdateTime(secret.LatestVersionTime)); w > maxUpdatedWidth {
-				maxUpdatedWidth = w
-			}
-		}
+// displaySecretsSimple displays secrets without labels (similar to original gcloud output)
+func displaySecretsSimple(secrets []SecretInfo, showUpdated, showSize bool) {
+	printTextTable(secretTableColumns(secrets, nil, false, showUpdated, showSize))
+}
+
+// secretListField is a column of the list table: a built-in field or a
+// configuration file attribute
+type secretListField struct {
+	Name      string
+	Attribute bool
+}
+
+// secretListBuiltinFields are the built-in --fields names, in table order
+var secretListBuiltinFields = []string{"name", "labels", "created", "updated", "size"}
+
+// secretListFieldHeaders are the table headers of the built-in fields
+var secretListFieldHeaders = map[string]string{
+	"name":    "NAME",
+	"labels":  "LABELS",
+	"created": "CREATED (UTC)",
+	"updated": "UPDATED (UTC)",
+	"size":    "SIZE",
+}
+
+func (f secretListField) header() string {
+	if f.Attribute {
+		return strings.ToUpper(f.Name)
 	}
+	return secretListFieldHeaders[f.Name]
+}
+
+func (f secretListField) value(secret SecretInfo, secretName string, cred *CredentialInfo) string {
+	if f.Attribute {
+		return GetAttributeValue(cred, f.Name)
+	}
+	switch f.Name {
+	case "name":
+		return secretName
+	case "labels":
+		return formatLabels(secret.Labels)
+	case "created":
+		return secret.CreateTime.UTC().Format(datetimeFormat)
+	case "updated":
+		return formatUpdateTime(secret.LatestVersionTime)
+	case "size":
+		return formatValueSize(secret.ValueSize)
+	}
+	return ""
+}
 
-	// Print header
-	header := padRight("NAME", maxNameWidth) + "  " + padRight("LABELS", maxLabelsWidth) + "  " + padRight("CREATED (UTC)", maxCreatedWidth)
-	sep := strings.Repeat("-", maxNameWidth) + "  " + strings.Repeat("-", maxLabelsWidth) + "  " + strings.Repeat("-", maxCreatedWidth)
+// defaultSecretListFields returns the columns shown without --fields: NAME,
+// then the config attributes, then the built-in fields that are enabled
+func defaultSecretListFields(attributes []string, showLabels, showUpdated, showSize bool) []secretListField {
+	fields := []secretListField{{Name: "name"}}
+	for _, attr := range attributes {
+		fields = append(fields, secretListField{Name: attr, Attribute: true})
+	}
+	if showLabels {
+		fields = append(fields, secretListField{Name: "labels"})
+	}
+	fields = append(fields, secretListField{Name: "created"})
 	if showUpdated {
-		header += "  " + padRight("UPDATED (UTC)", maxUpdatedWidth)
-		sep += "  " + strings.Repeat("-", maxUpdatedWidth)
+		fields = append(fields, secretListField{Name: "updated"})
+	}
+	if showSize {
+		fields = append(fields, secretListField{Name: "size"})
 	}
-	fmt.Println(header)
-	fmt.Println(sep)
+	return fields
+}
 
-	// Print secrets
-	for _, secret := range secrets {
-		name := strings.TrimPrefix(extractSecretName(secret.Name), prefix)
-		labelsStr := formatLabels(secret.Labels)
-		createdStr := secret.CreateTime.UTC().Format(datetimeFormat)
-		row := padRight(name, maxNameWidth) + "  " + padRight(labelsStr, maxLabelsWidth) + "  " + padRight(createdStr, maxCreatedWidth)
-		if showUpdated {
-			row += "  " + padRight(formatUpdateTime(secret.LatestVersionTime), maxUpdatedWidth)
+// knownAttributeNames returns the config attributes --fields accepts: title
+// and every attribute used by a credential or in list.attributes
+func knownAttributeNames() []string {
+	seen := map[string]bool{"title": true}
+	for _, cred := range GetConfig().Credentials {
+		for attr := range cred.Attributes {
+			seen[attr] = true
 		}
-		fmt.Println(row)
 	}
+	for _, attr := range GetListAttributes() {
+		seen[attr] = true
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
-// displaySecretsSimple displays secrets without labels (similar to original gcloud output)
-func displaySecretsSimple(secrets []SecretInfo, showUpdated bool) {
-	prefix := GetPrefix()
-	maxNameWidth := 4    // "NAME"
-	maxCreatedWidth := 7 // "CREATED"
-	maxUpdatedWidth := 7 // "UPDATED"
+// parseSecretListFields parses --fields: a comma-separated list of built-in
+// fields and config attributes, in display order
+func parseSecretListFields(value string) ([]secretListField, error) {
+	builtin := make(map[string]bool)
+	for _, name := range secretListBuiltinFields {
+		builtin[name] = true
+	}
+	attributes := knownAttributeNames()
+	known := make(map[string]bool)
+	for _, name := range attributes {
+		known[name] = true
+	}
 
-	for _, secret := range secrets {
-		name := strings.TrimPrefix(extractSecretName(secret.Name), prefix)
-		if w := displayWidth(name); w > maxNameWidth {
-			maxNameWidth = w
+	var fields []secretListField
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
 		}
-		createdStr := secret.CreateTime.UTC().Format(datetimeFormat)
-		if w := displayWidth(createdStr); w > maxCreatedWidth {
-			maxCreatedWidth = w
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate field '%s' in --fields", name)
 		}
-		if showUpdated {
-			if w := displayWidth(formatUpdateTime(secret.LatestVersionTime)); w > maxUpdatedWidth {
-				maxUpdatedWidth = w
-			}
+		seen[name] = true
+		switch {
+		case builtin[strings.ToLower(name)]:
+			fields = append(fields, secretListField{Name: strings.ToLower(name)})
+		case known[name]:
+			fields = append(fields, secretListField{Name: name, Attribute: true})
+		default:
+			return nil, fmt.Errorf("unknown field '%s' (valid fields: %s; config attributes: %s)",
+				name, strings.Join(secretListBuiltinFields, ", "), strings.Join(attributes, ", "))
 		}
 	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("--fields requires at least one field")
+	}
+	return fields, nil
+}
 
-	// Print header
-	header := padRight("NAME", maxNameWidth) + "  " + padRight("CREATED (UTC)", maxCreatedWidth)
-	sep := strings.Repeat("-", maxNameWidth) + "  " + strings.Repeat("-", maxCreatedWidth)
-	if showUpdated {
-		header += "  " + padRight("UPDATED (UTC)", maxUpdatedWidth)
-		sep += "  " + strings.Repeat("-", maxUpdatedWidth)
+// secretTableColumns returns the header and rows of the default list table
+// (see defaultSecretListFields)
+func secretTableColumns(secrets []SecretInfo, attributes []string, showLabels, showUpdated, showSize bool) ([]string, [][]string) {
+	return secretFieldColumns(secrets, defaultSecretListFields(attributes, showLabels, showUpdated, showSize))
+}
+
+// secretFieldColumns returns the header and rows of the list table with the
+// given columns. The text, Markdown, and CSV tables all use it.
+func secretFieldColumns(secrets []SecretInfo, fields []secretListField) ([]string, [][]string) {
+	header := make([]string, len(fields))
+	needsConfig := false
+	for i, field := range fields {
+		header[i] = field.header()
+		needsConfig = needsConfig || field.Attribute
 	}
-	fmt.Println(header)
-	fmt.Println(sep)
 
-	// Print secrets
+	prefix := GetPrefix()
+	rows := make([][]string, 0, len(secrets))
 	for _, secret := range secrets {
-		name := strings.TrimPrefix(extractSecretName(secret.Name), prefix)
-		createdStr := secret.CreateTime.UTC().Format(datetimeFormat)
-		row := padRight(name, maxNameWidth) + "  " + padRight(createdStr, maxCreatedWidth)
-		if showUpdated {
-			row += "  " + padRight(formatUpdateTime(secret.LatestVersionTime), maxUpdatedWidth)
+		secretName := strings.TrimPrefix(extractSecretName(secret.Name), prefix)
+		var cred *CredentialInfo
+		if needsConfig {
+			cred = GetCredentialInfo(secretName) // config stores bare names
+		}
+		row := make([]string, len(fields))
+		for i, field := range fields {
+			row[i] = field.value(secret, secretName, cred)
 		}
-		fmt.Println(row)
+		rows = append(rows, row)
+	}
+	return header, rows
+}
+
+// secretListView selects the columns and output format of the list table
+type secretListView struct {
+	ShowAttributes string // --show; empty uses the config list attributes
+	ShowLabels     bool
+	ShowUpdated    bool
+	ShowSize       bool
+	Fields         []secretListField // --fields, replacing the columns above
+	Format         string            // "" for the text table, markdownFormat, or "csv" with Fields
+}
+
+// columns returns the fields of the table, in order
+func (v secretListView) columns() []secretListField {
+	if len(v.Fields) > 0 {
+		return v.Fields
+	}
+	var attributes []string
+	if v.ShowAttributes != "" {
+		// CLI parameter overrides everything
+		attributes = ParseShowAttributes(v.ShowAttributes)
+	} else {
+		// Use config file settings
+		attributes = GetListAttributes()
 	}
+	return defaultSecretListFields(attributes, v.ShowLabels, v.ShowUpdated, v.ShowSize)
 }
 
 // enrichSecretsWithVersionTimes fetches the latest version createTime for each secret
@@ -261,6 +676,35 @@ func enrichSecretsWithVersionTimes(secrets []SecretInfo, project string) {
 	wg.Wait()
 }
 
+// enrichSecretsWithValueSizes reads the latest version payload of each secret
+// concurrently and stores its size in ValueSize. Unreadable secrets show "-".
+func enrichSecretsWithValueSizes(secrets []SecretInfo, project string) {
+	const maxConcurrency = 10
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i := range secrets {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			name := extractSecretName(secrets[idx].Name)
+			if size, err := getSecretValueSize(name, "latest", project); err == nil {
+				secrets[idx].ValueSize = &size
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// formatValueSize formats a value size for display, returning "-" if unknown
+func formatValueSize(size *int) string {
+	if size == nil {
+		return "-"
+	}
+	return formatByteSize(*size)
+}
+
 // datetimeFormat is the standard format for displaying timestamps in list output
 const datetimeFormat = "2006-01-02 15:04"
 
@@ -294,15 +738,85 @@ func formatLabels(labels map[string]string) string {
 	return strings.Join(labelPairs, ",")
 }
 
+// credentialNamesMatchingAttributes returns the bare names of config entries
+// matching an --attr-filter expression, or nil when attrFilter is empty
+func credentialNamesMatchingAttributes(attrFilter string) (map[string]bool, error) {
+	if attrFilter == "" {
+		return nil, nil
+	}
+	filters, err := ParseFilterAttributes(attrFilter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attr-filter: %w", err)
+	}
+	allowed := make(map[string]bool)
+	for _, cred := range FilterCredentialsByAttributes(filters) {
+		allowed[cred.Name] = true
+	}
+	return allowed, nil
+}
+
+// selectSecretsForList fetches the secrets within the configured prefix that
+// pass the label exclusions and attribute filter, sorted by name
+func selectSecretsForList(project, filter string, exclusions []labelExclusion, limit int, attrFilter string) ([]SecretInfo, error) {
+	secrets, err := secretManager.List(project, filter, limit)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := credentialNamesMatchingAttributes(attrFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := GetPrefix()
+	var filtered []SecretInfo
+	for _, secret := range secrets {
+		secretName := extractSecretName(secret.Name)
+		if !FilterSecretsByPrefix(secretName) {
+			continue
+		}
+		if allowed != nil && !allowed[strings.TrimPrefix(secretName, prefix)] {
+			continue
+		}
+		filtered = append(filtered, secret)
+	}
+	filtered = excludeSecretsByLabels(filtered, exclusions)
+	sortSecrets(filtered)
+	return filtered, nil
+}
+
+// listSecretsCompact prints one unaligned line per secret for dashboards and grep
+func listSecretsCompact(project, filter string, exclusions []labelExclusion, limit int, attrFilter string) error {
+	secrets, err := selectSecretsForList(project, filter, exclusions, limit, attrFilter)
+	if err != nil {
+		return err
+	}
+
+	prefix := GetPrefix()
+	for _, secret := range secrets {
+		fmt.Println(formatCompactSecret(secret, prefix))
+	}
+	return nil
+}
+
+// formatCompactSecret formats a secret as "name [labels] (created)"; the label
+// part is omitted when the secret has no labels
+func formatCompactSecret(secret SecretInfo, prefix string) string {
+	line := strings.TrimPrefix(extractSecretName(secret.Name), prefix)
+	if len(secret.Labels) > 0 {
+		line += " [" + formatLabels(secret.Labels) + "]"
+	}
+	return line + " (" + secret.CreateTime.UTC().Format(datetimeFormat) + ")"
+}
+
 // listSecretsForPrincipal lists all secrets that a principal has access to
-func listSecretsForPrincipal(principal, project string, showLabels, showUpdated bool) error {
+func listSecretsForPrincipal(principal, project string, showLabels, showUpdated, showSize bool) error {
 	// Validate the principal format
 	if err := validatePrincipalFormat(principal); err != nil {
 		return err
 	}
 
 	// Get all secrets in the project
-	allSecrets, err := fetchSecrets(project, "", 0)
+	allSecrets, err := secretManager.List(project, "", 0)
 	if err != nil {
 		return err
 	}
@@ -339,14 +853,17 @@ func listSecretsForPrincipal(principal, project string, showLabels, showUpdated
 	if showUpdated {
 		enrichSecretsWithVersionTimes(accessibleSecrets, project)
 	}
+	if showSize {
+		enrichSecretsWithValueSizes(accessibleSecrets, project)
+	}
 
 	fmt.Printf("Secrets accessible by '%s':\n\n", principal)
 
 	// Display accessible secrets
 	if showLabels {
-		displaySecretsWithLabels(accessibleSecrets, showUpdated)
+		displaySecretsWithLabels(accessibleSecrets, showUpdated, showSize)
 	} else {
-		displaySecretsSimple(accessibleSecrets, showUpdated)
+		displaySecretsSimple(accessibleSecrets, showUpdated, showSize)
 	}
 
 	return nil
@@ -374,26 +891,12 @@ func checkPrincipalAccess(secretName, principal, project string) (bool, error) {
 
 // checkSecretLevelAccess checks if a principal has secret-level access
 func checkSecretLevelAccess(secretName, principal, project string) (bool, error) {
-	// Get IAM policy for the secret
-	gcloudArgs := []string{"secrets", "get-iam-policy", secretName, "--format", "json"}
-	if project != "" {
-		gcloudArgs = append(gcloudArgs, "--project", project)
-	}
-
-	// Execute gcloud command
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-	output, err := gcloudCmd.Output()
+	policy, err := secretManager.GetIamPolicy(secretName, project)
 	if err != nil {
 		// If we can't get the policy, assume no access
 		return false, nil
 	}
 
-	// Parse JSON response
-	var policy IAMPolicy
-	if err := json.Unmarshal(output, &policy); err != nil {
-		return false, err
-	}
-
 	// Check if the principal is in any of the bindings
 	for _, binding := range policy.Bindings {
 		for _, member := range binding.Members {
@@ -413,23 +916,12 @@ func checkProjectLevelAccess(principal, project string) (bool, error) {
 		return false, missingProjectIDError()
 	}
 
-	// Get project IAM policy
-	gcloudArgs := []string{"projects", "get-iam-policy", projectID, "--format", "json"}
-
-	// Execute gcloud command
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-	output, err := gcloudCmd.Output()
+	policy, err := secretManager.GetProjectIamPolicy(projectID)
 	if err != nil {
 		// If we can't get the project policy, assume no access
 		return false, nil
 	}
 
-	// Parse JSON response
-	var policy IAMPolicy
-	if err := json.Unmarshal(output, &policy); err != nil {
-		return false, err
-	}
-
 	// Define roles that provide Secret Manager access
 	secretManagerRoles := map[string]bool{
 		"roles/secretmanager.admin":                true,
@@ -465,53 +957,34 @@ func validatePrincipalFormat(principal string) error {
 		}
 	}
 
-	return fmt.Errorf("invalid principal format: %s\nValid formats: user:email@domain.com, group:group@domain.com, serviceAccount:sa@project.iam.gserviceaccount.com, domain:domain.com, allUsers, allAuthenticatedUsers", principal)
+	// Federated identities need a resource path after the scheme
+	if federated, ok := parseFederatedPrincipal(principal); ok && strings.Contains(federated.Path, "/") {
+		return nil
+	}
+
+	return fmt.Errorf("invalid principal format: %s\nValid formats: user:email@domain.com, group:group@domain.com, serviceAccount:sa@project.iam.gserviceaccount.com, domain:domain.com, allUsers, allAuthenticatedUsers, principal://iam.googleapis.com/..., principalSet://iam.googleapis.com/...", principal)
 }
 
 // listSecretsWithConfigAttributes lists secrets with configuration-based attribute display
-func listSecretsWithConfigAttributes(project, filter string, limit int, showAttributes string, showLabels, showUpdated bool) error {
+func listSecretsWithConfigAttributes(project, filter string, exclusions []labelExclusion, limit int, sortAttr string, view secretListView) error {
 	// Get secrets first
-	gcloudArgs := []string{"secrets", "list", "--format", "json"}
-
-	if project != "" {
-		gcloudArgs = append(gcloudArgs, "--project", project)
-	}
-
-	if filter != "" {
-		gcloudArgs = append(gcloudArgs, "--filter", filter)
-	}
-
-	if limit > 0 {
-		gcloudArgs = append(gcloudArgs, "--limit", fmt.Sprintf("%d", limit))
-	}
-
-	// Execute gcloud command
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-	output, err := gcloudCmd.Output()
+	secrets, err := secretManager.List(project, filter, limit)
 	if err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
-			return formatGcloudError(string(exitError.Stderr))
-		}
-		return fmt.Errorf("failed to execute gcloud command: %v", err)
-	}
-
-	// Parse JSON response
-	var secrets []SecretInfo
-	if err := json.Unmarshal(output, &secrets); err != nil {
-		return fmt.Errorf("failed to parse secrets list: %w", err)
+		return err
 	}
 
 	// Filter by prefix if configured
-	if prefix := GetPrefix(); prefix != "" {
+	if GetPrefix() != "" {
 		var filteredSecrets []SecretInfo
 		for _, secret := range secrets {
 			secretName := extractSecretName(secret.Name)
-			if strings.HasPrefix(secretName, prefix) {
+			if FilterSecretsByPrefix(secretName) {
 				filteredSecrets = append(filteredSecrets, secret)
 			}
 		}
 		secrets = filteredSecrets
 	}
+	secrets = excludeSecretsByLabels(secrets, exclusions)
 
 	if len(secrets) == 0 {
 		fmt.Println("No secrets found.")
@@ -522,35 +995,44 @@ func listSecretsWithConfigAttributes(project, filter string, limit int, showAttr
 	sort.Slice(secrets, func(i, j int) bool {
 		return secrets[i].Name < secrets[j].Name
 	})
+	sortSecretsByAttribute(secrets, sortAttr)
 
-	if showUpdated {
-		enrichSecretsWithVersionTimes(secrets, project)
-	}
+	return displayEnhancedSecretList(secrets, project, view)
+}
 
-	// Determine which attributes to show
-	var attributes []string
-	if showAttributes != "" {
-		// CLI parameter overrides everything
-		attributes = ParseShowAttributes(showAttributes)
-	} else {
-		// Use config file settings
-		attributes = GetListAttributes()
+// displayEnhancedSecretList fetches the UPDATED and SIZE columns when the
+// view shows them and prints the table in the view's format
+func displayEnhancedSecretList(secrets []SecretInfo, project string, view secretListView) error {
+	fields := view.columns()
+	for _, field := range fields {
+		switch {
+		case field.Attribute:
+		case field.Name == "updated":
+			enrichSecretsWithVersionTimes(secrets, project)
+		case field.Name == "size":
+			enrichSecretsWithValueSizes(secrets, project)
+		}
 	}
 
-	// Display secrets with or without config attributes
-	if len(attributes) > 0 {
-		displaySecretsWithConfigAttributes(secrets, attributes, showLabels, showUpdated)
-	} else if showLabels {
-		displaySecretsWithLabels(secrets, showUpdated)
-	} else {
-		displaySecretsSimple(secrets, showUpdated)
+	header, rows := secretFieldColumns(secrets, fields)
+	switch view.Format {
+	case markdownFormat:
+		printMarkdownTable(header, rows)
+	case "csv":
+		// CSV headers are the field names, which scripts can rely on
+		names := make([]string, len(fields))
+		for i, field := range fields {
+			names[i] = field.Name
+		}
+		return printCsvTable(names, rows)
+	default:
+		printTextTable(header, rows)
 	}
-
 	return nil
 }
 
 // listSecretsWithConfigFiltering
-func listSecretsWithConfigFiltering(project, filter string, limit int, filterAttributes, showAttributes string, showLabels, showUpdated bool) error {
+func listSecretsWithConfigFiltering(project, filter string, exclusions []labelExclusion, limit int, filterAttributes, sortAttr string, view secretListView) error {
 	// Parse filter attributes
 	filters, err := ParseFilterAttributes(filterAttributes)
 	if err != nil {
@@ -565,35 +1047,11 @@ func listSecretsWithConfigFiltering(project, filter string, limit int, filterAtt
 	}
 
 	// Get all secrets to match against filtered credentials
-	gcloudArgs := []string{"secrets", "list", "--format", "json"}
-
-	if project != "" {
-		gcloudArgs = append(gcloudArgs, "--project", project)
-	}
-
-	if filter != "" {
-		gcloudArgs = append(gcloudArgs, "--filter", filter)
-	}
-
-	if limit > 0 {
-		gcloudArgs = append(gcloudArgs, "--limit", fmt.Sprintf("%d", limit))
-	}
-
-	// Execute gcloud command
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-	output, err := gcloudCmd.Output()
+	allSecrets, err := secretManager.List(project, filter, limit)
 	if err != nil {
-		if exitError, ok := err.(*exec.ExitError); ok {
-			return formatGcloudError(string(exitError.Stderr))
-		}
-		return fmt.Errorf("failed to execute gcloud command: %v", err)
-	}
-
-	// Parse JSON response
-	var allSecrets []SecretInfo
-	if err := json.Unmarshal(output, &allSecrets); err != nil {
-		return fmt.Errorf("failed to parse secrets list: %w", err)
+		return err
 	}
+	allSecrets = excludeSecretsByLabels(allSecrets, exclusions)
 
 	// Match secrets with filtered credentials
 	var matchingSecrets []SecretInfo
@@ -617,153 +1075,41 @@ func listSecretsWithConfigFiltering(project, filter string, limit int, filterAtt
 	sort.Slice(matchingSecrets, func(i, j int) bool {
 		return matchingSecrets[i].Name < matchingSecrets[j].Name
 	})
+	sortSecretsByAttribute(matchingSecrets, sortAttr)
 
-	if showUpdated {
-		enrichSecretsWithVersionTimes(matchingSecrets, project)
-	}
-
-	// Determine which attributes to show
-	var attributes []string
-	if showAttributes != "" {
-		// CLI parameter overrides everything
-		attributes = ParseShowAttributes(showAttributes)
-	} else {
-		// Use config file settings
-		attributes = GetListAttributes()
-	}
-
-	// Display filtered secrets with config attributes
-	if len(attributes) > 0 {
-		displaySecretsWithConfigAttributes(matchingSecrets, attributes, showLabels, showUpdated)
-	} else if showLabels {
-		displaySecretsWithLabels(matchingSecrets, showUpdated)
-	} else {
-		displaySecretsSimple(matchingSecrets, showUpdated)
-	}
-
-	return nil
+	return displayEnhancedSecretList(matchingSecrets, project, view)
 }
 
 // displaySecretsWithConfigAttributes displays secrets with configuration-based attributes
 // Custom attributes are inserted after NAME, LABELS is shown only if showLabels is true
-func displaySecretsWithConfigAttributes(secrets []SecretInfo, attributes []string, showLabels, showUpdated bool) {
-	// Calculate column widths for built-in fields
-	maxNameWidth := 4    // "NAME"
-	maxLabelsWidth := 6  // "LABELS"
-	maxCreatedWidth := 7 // "CREATED"
-	maxUpdatedWidth := 7 // "UPDATED"
-	attributeWidths := make([]int, len(attributes))
-
-	// Initialize attribute widths with header names (display width)
-	for i, attr := range attributes {
-		attributeWidths[i] = displayWidth(strings.ToUpper(attr))
-	}
-
-	// Calculate widths based on content using terminal display width
-	prefix := GetPrefix()
-	for _, secret := range secrets {
-		secretName := strings.TrimPrefix(extractSecretName(secret.Name), prefix)
-		if w := displayWidth(secretName); w > maxNameWidth {
-			maxNameWidth = w
-		}
-
-		// Calculate labels width
-		if showLabels {
-			labelsStr := formatLabels(secret.Labels)
-			if w := displayWidth(labelsStr); w > maxLabelsWidth {
-				maxLabelsWidth = w
-			}
-		}
-
-		// Calculate created width
-		createdStr := secret.CreateTime.UTC().Format(datetimeFormat)
-		if w := displayWidth(createdStr); w > maxCreatedWidth {
-			maxCreatedWidth = w
-		}
-
-		if showUpdated {
-			if w := displayWidth(formatUpdateTime(secret.LatestVersionTime)); w > maxUpdatedWidth {
-				maxUpdatedWidth = w
-			}
-		}
-
-		cred := GetCredentialInfo(secretName) // secretName is already bare after TrimPrefix above
-		for i, attr := range attributes {
-			value := GetAttributeValue(cred, attr)
-			if w := displayWidth(value); w > attributeWidths[i] {
-				attributeWidths[i] = w
-			}
-		}
-	}
-
-	// Print header: NAME + custom attributes + built-in fields
-	header := padRight("NAME", maxNameWidth)
-	for i, attr := range attributes {
-		header += "  " + padRight(strings.ToUpper(attr), attributeWidths[i])
-	}
-	if showLabels {
-		header += "  " + padRight("LABELS", maxLabelsWidth)
-	}
-	header += "  " + padRight("CREATED (UTC)", maxCreatedWidth)
-	if showUpdated {
-		header += "  " + padRight("UPDATED (UTC)", maxUpdatedWidth)
-	}
-	fmt.Println(header)
-
-	// Print separator
-	separator := strings.Repeat("-", maxNameWidth)
-	for _, width := range attributeWidths {
-		separator += "  " + strings.Repeat("-", width)
-	}
-	if showLabels {
-		separator += "  " + strings.Repeat("-", maxLabelsWidth)
-	}
-	separator += "  " + strings.Repeat("-", maxCreatedWidth)
-	if showUpdated {
-		separator += "  " + strings.Repeat("-", maxUpdatedWidth)
-	}
-	fmt.Println(separator)
-
-	// Print secrets: NAME + custom attributes + built-in fields
-	for _, secret := range secrets {
-		secretName := strings.TrimPrefix(extractSecretName(secret.Name), prefix)
-
-		cred := GetCredentialInfo(secretName) // bare name
-
-		// Start with NAME
-		row := padRight(secretName, maxNameWidth)
-
-		// Add custom attributes
-		for i, attr := range attributes {
-			value := GetAttributeValue(cred, attr)
-			row += "  " + padRight(value, attributeWidths[i])
-		}
-
-		// Add labels if requested
-		createdStr := secret.CreateTime.UTC().Format(datetimeFormat)
-		if showLabels {
-			labelsStr := formatLabels(secret.Labels)
-			row += "  " + padRight(labelsStr, maxLabelsWidth)
-		}
-		row += "  " + padRight(createdStr, maxCreatedWidth)
-		if showUpdated {
-			row += "  " + padRight(formatUpdateTime(secret.LatestVersionTime), maxUpdatedWidth)
-		}
-
-		fmt.Println(row)
-	}
+func displaySecretsWithConfigAttributes(secrets []SecretInfo, attributes []string, showLabels, showUpdated, showSize bool) {
+	printTextTable(secretTableColumns(secrets, attributes, showLabels, showUpdated, showSize))
 }
 
 func init() {
 	rootCmd.AddCommand(listCmd)
 	listCmd.Flags().String("filter", "", "Filter expression to apply to Secret Manager labels")
+	listCmd.Flags().StringArray("label", nil, "Only list secrets with this label, as key=value or key for any value (repeatable, ANDed with --filter)")
+	listCmd.Flags().String("filter-not", "", "Exclude secrets with matching labels (format: key=value,key2 - a bare key matches any value)")
 	listCmd.Flags().String("attr-filter", "", "Filter by configuration file attributes (format: key=value,key2=value2)")
+	listCmd.Flags().String("sort-attr", "", "Sort by the value of a configuration file attribute (e.g., owner); secrets without it are listed last")
+	listCmd.Flags().String("fields", "", "Comma-separated columns to show, in order: name, labels, created, updated, size, or config attributes")
 	listCmd.Flags().String("show", "", "Comma-separated list of attributes to display from configuration file (inserted after NAME, before built-in fields)")
 	listCmd.Flags().String("show-attributes", "", "(Alias for --show) Comma-separated list of attributes to display from configuration file")
 	listCmd.Flags().MarkHidden("show-attributes") // Hide from help but keep for compatibility
-	listCmd.Flags().String("format", "", "Output format (e.g., table, json, yaml) - custom formats bypass attribute display")
+	listCmd.Flags().String("format", "", "Output format (e.g., table, json, yaml, markdown) - custom formats bypass attribute display")
+	listCmd.Flags().Bool("raw-gcloud", false, "With --format json or yaml, print gcloud's full records instead of gsecutil's stable schema")
+	listCmd.Flags().Bool("with-config", false, "With --format json or yaml, output live secret state and config entry side by side")
 	listCmd.Flags().Int("limit", 0, "Maximum number of secrets to list (0 for no limit)")
 	listCmd.Flags().Bool("show-labels", false, "Show labels in output")
+	listCmd.Flags().Bool("compact", false, "Print each secret on one unaligned line: name [labels] (created)")
+	listCmd.Flags().Bool("watch", false, "Re-render the list every --interval until Ctrl-C, showing secrets added or removed since the previous poll")
+	listCmd.Flags().Duration("interval", 30*time.Second, "Polling interval for --watch")
 	listCmd.Flags().String("principal", "", "List secrets accessible by this principal (format: user:email@domain.com, group:group@domain.com, etc.)")
 	listCmd.Flags().Bool("show-updated", false, "Show UPDATED column (fetches latest version time per secret; slower for large lists)")
+	listCmd.Flags().Bool("show-size", false, "Show SIZE column with the latest version's value size (slower, accesses each value)")
+	listCmd.Flags().Bool("health", false, "Show a HEALTH column flagging problematic secrets (fetches versions and IAM policy per secret)")
+	listCmd.Flags().Bool("only-unhealthy", false, "Show only secrets with health issues (implies --health)")
+	listCmd.Flags().Int("stale-days", 365, "Flag secrets whose latest version is older than this many days as stale (0 to disable)")
+	addProjectsFlag(listCmd)
 }
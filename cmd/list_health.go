@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Health issue codes reported by list --health
+const (
+	healthNoEnabledVersions = "no-enabled-versions"
+	healthSingleVersion     = "single-version"
+	healthPublicAccess      = "public-access"
+	healthMissingTitle      = "missing-title"
+	healthStale             = "stale"
+	healthCheckFailed       = "check-failed"
+)
+
+// SecretHealth holds the result of the health checks for a single secret
+type SecretHealth struct {
+	Name              string     `json:"name" yaml:"name"`
+	Healthy           bool       `json:"healthy" yaml:"healthy"`
+	Issues            []string   `json:"issues" yaml:"issues"`
+	EnabledVersions   int        `json:"enabledVersions" yaml:"enabledVersions"`
+	TotalVersions     int        `json:"totalVersions" yaml:"totalVersions"`
+	LatestVersionTime *time.Time `json:"latestVersionTime,omitempty" yaml:"latestVersionTime,omitempty"`
+}
+
+// evaluateSecretHealth runs the health checks against already-fetched data.
+// policy may be nil when the IAM policy could not be retrieved, and cred is
+// nil when the secret has no config entry. The title check only applies when
+// the config defines credentials at all.
+func evaluateSecretHealth(name string, versions []SecretVersionInfo, policy *IAMPolicy, cred *CredentialInfo, checkTitle bool, staleAfter time.Duration, now time.Time) SecretHealth {
+	health := SecretHealth{Name: name, Issues: []string{}}
+
+	var latest time.Time
+	rollbackCandidates := 0
+	for _, v := range versions {
+		health.TotalVersions++
+		if v.State == "ENABLED" {
+			health.EnabledVersions++
+		}
+		if v.State != "DESTROYED" {
+			rollbackCandidates++
+		}
+		if v.CreateTime.After(latest) {
+			latest = v.CreateTime
+		}
+	}
+	if !latest.IsZero() {
+		health.LatestVersionTime = &latest
+	}
+
+	if health.EnabledVersions == 0 {
+		health.Issues = append(health.Issues, healthNoEnabledVersions)
+	}
+	if rollbackCandidates == 1 {
+		health.Issues = append(health.Issues, healthSingleVersion)
+	}
+	if policy != nil && hasPublicBinding(*policy) {
+		health.Issues = append(health.Issues, healthPublicAccess)
+	}
+	if checkTitle && (cred == nil || cred.Title == "") {
+		health.Issues = append(health.Issues, healthMissingTitle)
+	}
+	if staleAfter > 0 && !latest.IsZero() && now.Sub(latest) > staleAfter {
+		health.Issues = append(health.Issues, healthStale)
+	}
+
+	health.Healthy = len(health.Issues) == 0
+	return health
+}
+
+// hasPublicBinding reports whether any binding grants access to allUsers or allAuthenticatedUsers
+func hasPublicBinding(policy IAMPolicy) bool {
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			if member == "allUsers" || member == "allAuthenticatedUsers" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkSecretsHealth fetches versions and IAM policies for each secret
+// concurrently and evaluates their health
+func checkSecretsHealth(secrets []SecretInfo, project string, staleAfter time.Duration) []SecretHealth {
+	const maxConcurrency = 10
+	prefix := GetPrefix()
+	checkTitle := HasCredentialsConfig()
+	now := time.Now()
+
+	results := make([]SecretHealth, len(secrets))
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i := range secrets {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			secretName := extractSecretName(secrets[idx].Name)
+			bareName := strings.TrimPrefix(secretName, prefix)
+
+			versions, err := secretManager.ListVersions(secretName, project)
+			if err != nil {
+				results[idx] = SecretHealth{Name: bareName, Issues: []string{healthCheckFailed}}
+				return
+			}
+			// A missing policy only disables the public-access check
+			policy, _ := secretManager.GetIamPolicy(secretName, project)
+
+			results[idx] = evaluateSecretHealth(bareName, versions, policy, GetCredentialInfo(bareName), checkTitle, staleAfter, now)
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// listSecretsHealth lists secrets annotated with health check results
+func listSecretsHealth(project, filter string, exclusions []labelExclusion, limit int, attrFilter, format string, onlyUnhealthy bool, staleDays int) error {
+	filtered, err := selectSecretsForList(project, filter, exclusions, limit, attrFilter)
+	if err != nil {
+		return err
+	}
+
+	staleAfter := time.Duration(staleDays) * 24 * time.Hour
+	results := checkSecretsHealth(filtered, project, staleAfter)
+
+	if onlyUnhealthy {
+		var unhealthy []SecretHealth
+		for _, r := range results {
+			if !r.Healthy {
+				unhealthy = append(unhealthy, r)
+			}
+		}
+		results = unhealthy
+	}
+
+	if format == "json" || format == "yaml" {
+		if results == nil {
+			results = []SecretHealth{}
+		}
+		return printStructuredOutput(results, format)
+	}
+
+	if len(results) == 0 {
+		if onlyUnhealthy {
+			fmt.Println("No unhealthy secrets found.")
+		} else {
+			fmt.Println("No secrets found.")
+		}
+		return nil
+	}
+
+	displaySecretsHealth(results)
+	return nil
+}
+
+// displaySecretsHealth prints health results as an aligned table
+func displaySecretsHealth(results []SecretHealth) {
+	maxNameWidth := 4     // "NAME"
+	maxVersionsWidth := 8 // "VERSIONS"
+	maxUpdatedWidth := 13 // "UPDATED (UTC)"
+
+	for _, r := range results {
+		if w := displayWidth(r.Name); w > maxNameWidth {
+			maxNameWidth = w
+		}
+		if w := displayWidth(formatHealthVersions(r)); w > maxVersionsWidth {
+			maxVersionsWidth = w
+		}
+	}
+
+	header := padRight("NAME", maxNameWidth) + "  " + padRight("VERSIONS", maxVersionsWidth) + "  " + padRight("UPDATED (UTC)", maxUpdatedWidth) + "  HEALTH"
+	sep := strings.Repeat("-", maxNameWidth) + "  " + strings.Repeat("-", maxVersionsWidth) + "  " + strings.Repeat("-", maxUpdatedWidth) + "  " + strings.Repeat("-", 6)
+	fmt.Println(header)
+	fmt.Println(sep)
+
+	unhealthy := 0
+	for _, r := range results {
+		updated := "-"
+		if r.LatestVersionTime != nil {
+			updated = formatUpdateTime(*r.LatestVersionTime)
+		}
+		fmt.Println(padRight(r.Name, maxNameWidth) + "  " + padRight(formatHealthVersions(r), maxVersionsWidth) + "  " + padRight(updated, maxUpdatedWidth) + "  " + formatHealthIssues(r))
+		if !r.Healthy {
+			unhealthy++
+		}
+	}
+
+	fmt.Printf("\n%d of %d secrets have health issues\n", unhealthy, len(results))
+}
+
+// formatHealthVersions formats the enabled/total version counts
+func formatHealthVersions(r SecretHealth) string {
+	return fmt.Sprintf("%d/%d", r.EnabledVersions, r.TotalVersions)
+}
+
+// formatHealthIssues summarizes health issues for the HEALTH column
+func formatHealthIssues(r SecretHealth) string {
+	if r.Healthy {
+		return "OK"
+	}
+	return strings.Join(r.Issues, ",")
+}
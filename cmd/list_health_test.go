@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestEvaluateSecretHealth tests the individual health checks used by list --health
+func TestEvaluateSecretHealth(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	recent := now.AddDate(0, 0, -10)
+	old := now.AddDate(-2, 0, 0)
+	staleAfter := 365 * 24 * time.Hour
+
+	twoEnabled := []SecretVersionInfo{
+		{Name: "projects/p/secrets/s/versions/1", State: "ENABLED", CreateTime: recent.Add(-time.Hour)},
+		{Name: "projects/p/secrets/s/versions/2", State: "ENABLED", CreateTime: recent},
+	}
+	titled := &CredentialInfo{Name: "s", Title: "Some Secret"}
+
+	tests := []struct {
+		name       string
+		versions   []SecretVersionInfo
+		policy     *IAMPolicy
+		cred       *CredentialInfo
+		checkTitle bool
+		expected   []string
+	}{
+		{
+			name:       "Healthy secret",
+			versions:   twoEnabled,
+			policy:     &IAMPolicy{},
+			cred:       titled,
+			checkTitle: true,
+			expected:   []string{},
+		},
+		{
+			name: "No enabled versions",
+			versions: []SecretVersionInfo{
+				{State: "DISABLED", CreateTime: recent.Add(-time.Hour)},
+				{State: "DESTROYED", CreateTime: recent},
+			},
+			cred:     titled,
+			expected: []string{healthNoEnabledVersions, healthSingleVersion},
+		},
+		{
+			name:     "No versions at all",
+			versions: nil,
+			expected: []string{healthNoEnabledVersions},
+		},
+		{
+			name: "Single version",
+			versions: []SecretVersionInfo{
+				{State: "ENABLED", CreateTime: recent},
+			},
+			expected: []string{healthSingleVersion},
+		},
+		{
+			name: "Disabled version still counts as rollback target",
+			versions: []SecretVersionInfo{
+				{State: "DISABLED", CreateTime: recent.Add(-time.Hour)},
+				{State: "ENABLED", CreateTime: recent},
+			},
+			expected: []string{},
+		},
+		{
+			name:     "Public binding",
+			versions: twoEnabled,
+			policy: &IAMPolicy{Bindings: []Binding{
+				{Role: "roles/secretmanager.secretAccessor", Members: []string{"allUsers"}},
+			}},
+			expected: []string{healthPublicAccess},
+		},
+		{
+			name:     "All authenticated users binding",
+			versions: twoEnabled,
+			policy: &IAMPolicy{Bindings: []Binding{
+				{Role: "roles/secretmanager.viewer", Members: []string{"user:a@example.com", "allAuthenticatedUsers"}},
+			}},
+			expected: []string{healthPublicAccess},
+		},
+		{
+			name:       "Missing config entry",
+			versions:   twoEnabled,
+			cred:       nil,
+			checkTitle: true,
+			expected:   []string{healthMissingTitle},
+		},
+		{
+			name:       "Config entry without title",
+			versions:   twoEnabled,
+			cred:       &CredentialInfo{Name: "s"},
+			checkTitle: true,
+			expected:   []string{healthMissingTitle},
+		},
+		{
+			name:       "Title check disabled without credentials config",
+			versions:   twoEnabled,
+			cred:       nil,
+			checkTitle: false,
+			expected:   []string{},
+		},
+		{
+			name: "Stale latest version",
+			versions: []SecretVersionInfo{
+				{State: "ENABLED", CreateTime: old.Add(-time.Hour)},
+				{State: "ENABLED", CreateTime: old},
+			},
+			expected: []string{healthStale},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := evaluateSecretHealth("s", tt.versions, tt.policy, tt.cred, tt.checkTitle, staleAfter, now)
+			if !reflect.DeepEqual(health.Issues, tt.expected) {
+				t.Errorf("Issues = %v, expected %v", health.Issues, tt.expected)
+			}
+			if health.Healthy != (len(tt.expected) == 0) {
+				t.Errorf("Healthy = %v, expected %v", health.Healthy, len(tt.expected) == 0)
+			}
+		})
+	}
+}
+
+// TestEvaluateSecretHealthCounts tests version counting and latest time tracking
+func TestEvaluateSecretHealthCounts(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	latest := now.AddDate(0, 0, -1)
+	versions := []SecretVersionInfo{
+		{State: "ENABLED", CreateTime: now.AddDate(0, 0, -3)},
+		{State: "ENABLED", CreateTime: latest},
+		{State: "DISABLED", CreateTime: now.AddDate(0, 0, -2)},
+	}
+
+	health := evaluateSecretHealth("s", versions, nil, nil, false, 0, now)
+	if health.EnabledVersions != 2 || health.TotalVersions != 3 {
+		t.Errorf("Counts = %d/%d, expected 2/3", health.EnabledVersions, health.TotalVersions)
+	}
+	if health.LatestVersionTime == nil || !health.LatestVersionTime.Equal(latest) {
+		t.Errorf("LatestVersionTime = %v, expected %v", health.LatestVersionTime, latest)
+	}
+	if got := formatHealthVersions(health); got != "2/3" {
+		t.Errorf("formatHealthVersions() = %q, expected %q", got, "2/3")
+	}
+	if got := formatHealthIssues(health); got != "OK" {
+		t.Errorf("formatHealthIssues() = %q, expected %q", got, "OK")
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+)
+
+// MergedSecretRecord is one secret in 'list --with-config' output, with the
+// live Secret Manager state and the configuration file entry side by side
+type MergedSecretRecord struct {
+	Name   string             `json:"name" yaml:"name"`
+	Live   LiveSecretState    `json:"live" yaml:"live"`
+	Config *ConfigSecretState `json:"config" yaml:"config"`
+}
+
+// LiveSecretState is the part of a secret reported by Secret Manager
+type LiveSecretState struct {
+	Name        string            `json:"name" yaml:"name"`
+	CreateTime  time.Time         `json:"createTime" yaml:"createTime"`
+	Labels      map[string]string `json:"labels" yaml:"labels"`
+	Annotations map[string]string `json:"annotations" yaml:"annotations"`
+	Etag        string            `json:"etag" yaml:"etag"`
+}
+
+// ConfigSecretState is the part of a secret recorded in the configuration file
+type ConfigSecretState struct {
+	Title      string                 `json:"title" yaml:"title"`
+	Attributes map[string]interface{} `json:"attributes" yaml:"attributes"`
+}
+
+// newMergedSecretRecord combines a listed secret with its config entry (nil when absent)
+func newMergedSecretRecord(secret SecretInfo, cred *CredentialInfo) MergedSecretRecord {
+	record := MergedSecretRecord{
+		Name: strings.TrimPrefix(extractSecretName(secret.Name), GetPrefix()),
+		Live: LiveSecretState{
+			Name:        secret.Name,
+			CreateTime:  secret.CreateTime,
+			Labels:      secret.Labels,
+			Annotations: secret.Annotations,
+			Etag:        secret.Etag,
+		},
+	}
+	// Always emit objects so consumers never have to handle null maps
+	if record.Live.Labels == nil {
+		record.Live.Labels = map[string]string{}
+	}
+	if record.Live.Annotations == nil {
+		record.Live.Annotations = map[string]string{}
+	}
+
+	if cred != nil {
+		record.Config = &ConfigSecretState{Title: cred.Title, Attributes: cred.Attributes}
+		if record.Config.Attributes == nil {
+			record.Config.Attributes = map[string]interface{}{}
+		}
+	}
+	return record
+}
+
+// listSecretsMergedWithConfig prints each secret's live state and config entry as JSON or YAML
+func listSecretsMergedWithConfig(project, filter string, exclusions []labelExclusion, limit int, attrFilter, format string) error {
+	secrets, err := secretManager.List(project, filter, limit)
+	if err != nil {
+		return err
+	}
+
+	var filtered []SecretInfo
+	for _, secret := range secrets {
+		if FilterSecretsByPrefix(extractSecretName(secret.Name)) {
+			filtered = append(filtered, secret)
+		}
+	}
+	filtered = excludeSecretsByLabels(filtered, exclusions)
+	sortSecrets(filtered)
+
+	// Restrict to secrets whose config entry matches the attribute filters
+	allowed, err := credentialNamesMatchingAttributes(attrFilter)
+	if err != nil {
+		return err
+	}
+
+	prefix := GetPrefix()
+	records := []MergedSecretRecord{}
+	for _, secret := range filtered {
+		bareName := strings.TrimPrefix(extractSecretName(secret.Name), prefix)
+		if allowed != nil && !allowed[bareName] {
+			continue
+		}
+		records = append(records, newMergedSecretRecord(secret, GetCredentialInfo(bareName)))
+	}
+
+	return printStructuredOutput(records, format)
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestNewMergedSecretRecord tests namespacing of live and config state
+func TestNewMergedSecretRecord(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Prefix: "team-"}
+
+	secret := SecretInfo{
+		Name:   "projects/p/secrets/team-db",
+		Labels: map[string]string{"env": "prod"},
+		Etag:   "\"abc\"",
+	}
+	cred := &CredentialInfo{Name: "db", Title: "Database", Attributes: map[string]interface{}{"owner": "alice"}}
+
+	record := newMergedSecretRecord(secret, cred)
+	if record.Name != "db" || record.Live.Name != secret.Name || record.Live.Labels["env"] != "prod" {
+		t.Errorf("Live state not copied: %+v", record)
+	}
+	if record.Config == nil || record.Config.Title != "Database" || record.Config.Attributes["owner"] != "alice" {
+		t.Errorf("Config state not copied: %+v", record.Config)
+	}
+
+	jsonOutput, err := json.Marshal(newMergedSecretRecord(SecretInfo{Name: "projects/p/secrets/team-api"}, nil))
+	if err != nil {
+		t.Fatalf("Failed to marshal record: %v", err)
+	}
+	for _, want := range []string{`"config":null`, `"labels":{}`, `"annotations":{}`} {
+		if !strings.Contains(string(jsonOutput), want) {
+			t.Errorf("Expected %s in %s", want, jsonOutput)
+		}
+	}
+}
@@ -541,7 +541,7 @@ func TestDisplaySecretsWithConfigAttributes(t *testing.T) {
 	}()
 
 	// Call the function - this should work without panicking and include both custom and built-in fields
-	displaySecretsWithConfigAttributes(testSecrets, attributes, true, false)
+	displaySecretsWithConfigAttributes(testSecrets, attributes, true, false, false)
 
 	// Test width calculation logic separately
 	maxNameWidth := 4
@@ -619,13 +619,13 @@ func TestDisplaySecretsWithLabels_ShowUpdated(t *testing.T) {
 	}
 
 	// showUpdated=false: UPDATED column must NOT appear
-	out := captureStdout(func() { displaySecretsWithLabels(secrets, false) })
+	out := captureStdout(func() { displaySecretsWithLabels(secrets, false, false) })
 	if strings.Contains(out, "UPDATED") {
 		t.Errorf("showUpdated=false: unexpected UPDATED column in output:\n%s", out)
 	}
 
 	// showUpdated=true: UPDATED column and formatted time must appear
-	out = captureStdout(func() { displaySecretsWithLabels(secrets, true) })
+	out = captureStdout(func() { displaySecretsWithLabels(secrets, true, false) })
 	if !strings.Contains(out, "UPDATED (UTC)") {
 		t.Errorf("showUpdated=true: missing UPDATED (UTC) header in output:\n%s", out)
 	}
@@ -645,7 +645,7 @@ func TestDisplaySecretsWithLabels_ZeroUpdatedTime(t *testing.T) {
 		},
 	}
 
-	out := captureStdout(func() { displaySecretsWithLabels(secrets, true) })
+	out := captureStdout(func() { displaySecretsWithLabels(secrets, true, false) })
 	if !strings.Contains(out, " - ") && !strings.HasSuffix(strings.TrimSpace(out), "-") {
 		t.Errorf("expected dash for zero LatestVersionTime, got:\n%s", out)
 	}
@@ -664,13 +664,13 @@ func TestDisplaySecretsSimple_ShowUpdated(t *testing.T) {
 	}
 
 	// showUpdated=false: UPDATED column must NOT appear
-	out := captureStdout(func() { displaySecretsSimple(secrets, false) })
+	out := captureStdout(func() { displaySecretsSimple(secrets, false, false) })
 	if strings.Contains(out, "UPDATED") {
 		t.Errorf("showUpdated=false: unexpected UPDATED column in output:\n%s", out)
 	}
 
 	// showUpdated=true: UPDATED column and formatted time must appear
-	out = captureStdout(func() { displaySecretsSimple(secrets, true) })
+	out = captureStdout(func() { displaySecretsSimple(secrets, true, false) })
 	if !strings.Contains(out, "UPDATED (UTC)") {
 		t.Errorf("showUpdated=true: missing UPDATED (UTC) header in output:\n%s", out)
 	}
@@ -679,6 +679,31 @@ func TestDisplaySecretsSimple_ShowUpdated(t *testing.T) {
 	}
 }
 
+// TestDisplaySecretsSimple_ShowSize verifies that the SIZE column is included
+// only when showSize=true, with "-" for secrets whose size is unknown.
+func TestDisplaySecretsSimple_ShowSize(t *testing.T) {
+	size := 2048
+	secrets := []SecretInfo{
+		{Name: "projects/test/secrets/known", ValueSize: &size},
+		{Name: "projects/test/secrets/unknown"},
+	}
+
+	out := captureStdout(func() { displaySecretsSimple(secrets, false, false) })
+	if strings.Contains(out, "SIZE") {
+		t.Errorf("showSize=false: unexpected SIZE column in output:\n%s", out)
+	}
+
+	out = captureStdout(func() { displaySecretsSimple(secrets, false, true) })
+	if !strings.Contains(out, "SIZE") || !strings.Contains(out, "2.0 KiB") {
+		t.Errorf("showSize=true: missing SIZE column or value in output:\n%s", out)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "unknown") && !strings.HasSuffix(strings.TrimSpace(line), "-") {
+			t.Errorf("showSize=true: expected '-' for unknown size, got %q", line)
+		}
+	}
+}
+
 // TestDisplaySecretsWithConfigAttributes_ShowUpdated verifies that the UPDATED
 // column is included only when showUpdated=true.
 func TestDisplaySecretsWithConfigAttributes_ShowUpdated(t *testing.T) {
@@ -693,13 +718,13 @@ func TestDisplaySecretsWithConfigAttributes_ShowUpdated(t *testing.T) {
 	attributes := []string{"title"}
 
 	// showUpdated=false: UPDATED column must NOT appear
-	out := captureStdout(func() { displaySecretsWithConfigAttributes(secrets, attributes, false, false) })
+	out := captureStdout(func() { displaySecretsWithConfigAttributes(secrets, attributes, false, false, false) })
 	if strings.Contains(out, "UPDATED") {
 		t.Errorf("showUpdated=false: unexpected UPDATED column in output:\n%s", out)
 	}
 
 	// showUpdated=true: UPDATED column and formatted time must appear
-	out = captureStdout(func() { displaySecretsWithConfigAttributes(secrets, attributes, false, true) })
+	out = captureStdout(func() { displaySecretsWithConfigAttributes(secrets, attributes, false, true, false) })
 	if !strings.Contains(out, "UPDATED (UTC)") {
 		t.Errorf("showUpdated=true: missing UPDATED (UTC) header in output:\n%s", out)
 	}
@@ -829,3 +854,250 @@ func TestConfigAttributeDisplay(t *testing.T) {
 		})
 	}
 }
+
+// TestFormatCompactSecret tests the single-line list format
+func TestFormatCompactSecret(t *testing.T) {
+	created := time.Date(2025, 3, 4, 5, 6, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		secret   SecretInfo
+		prefix   string
+		expected string
+	}{
+		{
+			name:     "with labels and prefix",
+			secret:   SecretInfo{Name: "projects/p/secrets/team-db", CreateTime: created, Labels: map[string]string{"team": "x", "env": "prod"}},
+			prefix:   "team-",
+			expected: "db [env=prod,team=x] (2025-03-04 05:06)",
+		},
+		{
+			name:     "without labels",
+			secret:   SecretInfo{Name: "projects/p/secrets/api-key", CreateTime: created},
+			expected: "api-key (2025-03-04 05:06)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatCompactSecret(tt.secret, tt.prefix); result != tt.expected {
+				t.Errorf("formatCompactSecret() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestSelectListedSecretRecords tests that --format json/yaml output is filtered and sorted like the table output
+func TestSelectListedSecretRecords(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Prefix: "team-"}
+
+	output := []byte(`[
+		{"name": "projects/p/secrets/team-web", "labels": {"env": "dev"}, "rotation": {"rotationPeriod": "86400s"}},
+		{"name": "projects/p/secrets/other-db"},
+		{"name": "projects/p/secrets/team-api", "labels": {"env": "prod"}},
+		{"name": "projects/p/secrets/my-team-db"}
+	]`)
+
+	tests := []struct {
+		name       string
+		exclusions []labelExclusion
+		allowed    map[string]bool
+		expected   []string
+	}{
+		{
+			name:     "prefix filtering and sorting",
+			expected: []string{"projects/p/secrets/team-api", "projects/p/secrets/team-web"},
+		},
+		{
+			name:       "label exclusions",
+			exclusions: []labelExclusion{{Key: "env", Value: "prod"}},
+			expected:   []string{"projects/p/secrets/team-web"},
+		},
+		{
+			name:     "attribute filter",
+			allowed:  map[string]bool{"api": true},
+			expected: []string{"projects/p/secrets/team-api"},
+		},
+		{
+			name:     "nothing allowed",
+			allowed:  map[string]bool{},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := selectListedSecretRecords(output, tt.exclusions, tt.allowed, "", true)
+			if err != nil {
+				t.Fatalf("selectListedSecretRecords() error = %v", err)
+			}
+			names := []string{}
+			for _, record := range records {
+				names = append(names, record.(map[string]interface{})["name"].(string))
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("names = %v, expected %v", names, tt.expected)
+			}
+		})
+	}
+
+	// With --raw-gcloud, fields SecretInfo does not model must survive re-encoding
+	records, err := selectListedSecretRecords(output, nil, nil, "", true)
+	if err != nil {
+		t.Fatalf("selectListedSecretRecords() error = %v", err)
+	}
+	jsonOutput, err := json.Marshal(records)
+	if err != nil {
+		t.Fatalf("Failed to marshal records: %v", err)
+	}
+	if !strings.Contains(string(jsonOutput), `"rotationPeriod":"86400s"`) {
+		t.Errorf("Expected rotation field to be preserved in %s", jsonOutput)
+	}
+}
+
+// TestListedSecretSchema is a snapshot of the stable list --format json
+// schema; changing it breaks downstream tooling
+func TestListedSecretSchema(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{}
+
+	output := []byte(`[
+		{"name": "projects/p/secrets/db", "createTime": "2025-03-01T12:00:00.123456Z", "labels": {"env": "prod"},
+		 "annotations": {"owner": "backend"}, "etag": "\"abc\"", "replication": {"automatic": {}}},
+		{"name": "projects/p/secrets/api", "createTime": "2025-03-02T12:00:00Z"}
+	]`)
+	records, err := selectListedSecretRecords(output, nil, nil, "", false)
+	if err != nil {
+		t.Fatalf("selectListedSecretRecords() error = %v", err)
+	}
+	jsonOutput, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to marshal records: %v", err)
+	}
+
+	expected := `[
+  {
+    "name": "projects/p/secrets/api",
+    "shortName": "api",
+    "createTime": "2025-03-02T12:00:00Z",
+    "labels": {},
+    "annotations": {}
+  },
+  {
+    "name": "projects/p/secrets/db",
+    "shortName": "db",
+    "createTime": "2025-03-01T12:00:00.123456Z",
+    "labels": {
+      "env": "prod"
+    },
+    "annotations": {
+      "owner": "backend"
+    }
+  }
+]`
+	if string(jsonOutput) != expected {
+		t.Errorf("Stable schema changed:\n%s\nexpected:\n%s", jsonOutput, expected)
+	}
+}
+
+// TestGcloudPrefixFilter tests the filter passed to gcloud for passthrough formats
+func TestGcloudPrefixFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   string
+		prefix   string
+		expected string
+	}{
+		{name: "no prefix", filter: "labels.env=prod", expected: "labels.env=prod"},
+		{name: "prefix only", prefix: "team-", expected: "name~'^projects/[^/]+/secrets/team-'"},
+		{name: "prefix and filter", filter: "labels.env=prod", prefix: "team.", expected: `(labels.env=prod) AND name~'^projects/[^/]+/secrets/team\.'`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := gcloudPrefixFilter(tt.filter, tt.prefix); result != tt.expected {
+				t.Errorf("gcloudPrefixFilter() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestLabelFilterExpression tests translating --label conditions into a gcloud filter
+func TestLabelFilterExpression(t *testing.T) {
+	tests := []struct {
+		name        string
+		filter      string
+		labels      []string
+		expected    string
+		expectError bool
+	}{
+		{name: "no labels", filter: "name:db", expected: "name:db"},
+		{name: "key and value", labels: []string{"env=prod"}, expected: "labels.env=prod"},
+		{name: "key only", labels: []string{"team"}, expected: "labels.team:*"},
+		{name: "several labels", labels: []string{"env=prod", "labels.team"}, expected: "labels.env=prod AND labels.team:*"},
+		{name: "with filter", filter: "name:db OR name:api", labels: []string{"env=prod"}, expected: "(name:db OR name:api) AND labels.env=prod"},
+		{name: "missing key", labels: []string{"=prod"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := labelFilterExpression(tt.filter, tt.labels)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected an error, got %q", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("labelFilterExpression() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseSecretListFields tests validating list --fields against the
+// built-in fields and the config attributes
+func TestParseSecretListFields(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Credentials: []CredentialInfo{
+		{Name: "db", Title: "Database", Attributes: map[string]interface{}{"owner": "backend"}},
+	}}
+
+	fields, err := parseSecretListFields("created, owner,NAME,title")
+	if err != nil {
+		t.Fatalf("parseSecretListFields() failed: %v", err)
+	}
+	expected := []secretListField{{Name: "created"}, {Name: "owner", Attribute: true}, {Name: "name"}, {Name: "title", Attribute: true}}
+	if len(fields) != len(expected) {
+		t.Fatalf("fields = %+v, expected %+v", fields, expected)
+	}
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Errorf("fields[%d] = %+v, expected %+v", i, fields[i], expected[i])
+		}
+	}
+
+	_, err = parseSecretListFields("name,team")
+	if err == nil || !strings.Contains(err.Error(), "valid fields: name, labels, created, updated, size; config attributes: owner, title") {
+		t.Errorf("Expected the valid fields in the error, got %v", err)
+	}
+	for _, value := range []string{"name,name", ""} {
+		if _, err := parseSecretListFields(value); err == nil {
+			t.Errorf("Expected an error for %q", value)
+		}
+	}
+
+	header, rows := secretFieldColumns([]SecretInfo{{
+		Name:       "projects/p/secrets/db",
+		CreateTime: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+	}}, fields)
+	if strings.Join(header, ",") != "CREATED (UTC),OWNER,NAME,TITLE" || strings.Join(rows[0], ",") != "2025-03-01 12:00,backend,db,Database" {
+		t.Errorf("Unexpected columns: %v %v", header, rows)
+	}
+}
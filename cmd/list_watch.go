@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// watchSecretList re-renders the enhanced list every interval until Ctrl-C.
+// Below the table it lists the secrets added and removed since the previous
+// poll. A failed poll is shown in place of the table and watching continues.
+func watchSecretList(project, filter string, exclusions []labelExclusion, limit int, attrFilter string, view secretListView, interval time.Duration) error {
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	prefix := GetPrefix()
+	var previous []string
+	for {
+		secrets, err := selectSecretsForList(project, filter, exclusions, limit, attrFilter)
+
+		fmt.Print(clearScreen)
+		fmt.Printf("Every %s: gsecutil list   %s   (Ctrl-C to stop)\n\n", interval, time.Now().Format("2006-01-02 15:04:05"))
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+		} else {
+			current := make([]string, len(secrets))
+			for i, secret := range secrets {
+				current[i] = strings.TrimPrefix(extractSecretName(secret.Name), prefix)
+			}
+
+			if len(secrets) == 0 {
+				fmt.Println("No secrets found.")
+			} else {
+				if err := displayEnhancedSecretList(secrets, project, view); err != nil {
+					fmt.Printf("Error: %v\n", err)
+				}
+			}
+
+			// The first poll has nothing to compare against
+			if previous != nil {
+				printSecretListChanges(diffSecretNames(previous, current))
+			}
+			previous = current
+		}
+
+		select {
+		case <-interrupt:
+			fmt.Println()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// diffSecretNames returns the names in current but not previous (added) and
+// in previous but not current (removed), each sorted
+func diffSecretNames(previous, current []string) (added, removed []string) {
+	before := make(map[string]bool, len(previous))
+	for _, name := range previous {
+		before[name] = true
+	}
+	after := make(map[string]bool, len(current))
+	for _, name := range current {
+		after[name] = true
+		if !before[name] {
+			added = append(added, name)
+		}
+	}
+	for _, name := range previous {
+		if !after[name] {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// printSecretListChanges prints the additions and removals of one poll
+func printSecretListChanges(added, removed []string) {
+	fmt.Println()
+	if len(added) == 0 && len(removed) == 0 {
+		fmt.Println("No changes since the previous poll.")
+		return
+	}
+	fmt.Println("Changes since the previous poll:")
+	for _, name := range added {
+		fmt.Printf("  + %s\n", name)
+	}
+	for _, name := range removed {
+		fmt.Printf("  - %s\n", name)
+	}
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestDiffSecretNames tests detecting secrets added and removed between polls
+func TestDiffSecretNames(t *testing.T) {
+	tests := []struct {
+		name            string
+		previous        []string
+		current         []string
+		expectedAdded   []string
+		expectedRemoved []string
+	}{
+		{name: "no changes", previous: []string{"a", "b"}, current: []string{"a", "b"}},
+		{name: "added and removed", previous: []string{"a", "c"}, current: []string{"d", "a", "b"}, expectedAdded: []string{"b", "d"}, expectedRemoved: []string{"c"}},
+		{name: "everything removed", previous: []string{"a"}, current: []string{}, expectedRemoved: []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := diffSecretNames(tt.previous, tt.current)
+			if !reflect.DeepEqual(added, tt.expectedAdded) {
+				t.Errorf("added = %v, expected %v", added, tt.expectedAdded)
+			}
+			if !reflect.DeepEqual(removed, tt.expectedRemoved) {
+				t.Errorf("removed = %v, expected %v", removed, tt.expectedRemoved)
+			}
+		})
+	}
+}
+
+// TestPrintSecretListChanges tests the change summary shown below the watched table
+func TestPrintSecretListChanges(t *testing.T) {
+	out := captureStdout(func() {
+		printSecretListChanges([]string{"new-key"}, []string{"old-key"})
+	})
+	for _, want := range []string{"Changes since the previous poll:", "  + new-key", "  - old-key"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected %q in output:\n%s", want, out)
+		}
+	}
+
+	out = captureStdout(func() {
+		printSecretListChanges(nil, nil)
+	})
+	if !strings.Contains(out, "No changes since the previous poll.") {
+		t.Errorf("Expected no-changes message, got:\n%s", out)
+	}
+}
@@ -0,0 +1,264 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ManifestEntry is a single secret in a JSON export manifest. It carries the
+// same information as one CSV row so that both formats share a single schema.
+type ManifestEntry struct {
+	Name          string            `json:"name"`
+	Value         *string           `json:"value,omitempty"`
+	RedactedValue string            `json:"redactedValue,omitempty"`
+	Version       string            `json:"version,omitempty"`
+	Title         string            `json:"title,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+	Attributes    map[string]string `json:"attributes,omitempty"`
+}
+
+// csvRecordsToManifest converts export CSV records (header first) into manifest entries.
+// Empty cells are omitted, matching how import ignores them.
+func csvRecordsToManifest(records [][]string) []ManifestEntry {
+	entries := []ManifestEntry{}
+	if len(records) == 0 {
+		return entries
+	}
+
+	header := records[0]
+	for _, record := range records[1:] {
+		var entry ManifestEntry
+		for i, col := range header {
+			value := record[i]
+			switch {
+			case col == "name":
+				entry.Name = value
+			case col == "value":
+				v := value
+				entry.Value = &v
+			case col == redactedValueColumn:
+				entry.RedactedValue = value
+			case col == versionColumn:
+				entry.Version = value
+			case col == "title":
+				entry.Title = value
+			case strings.HasPrefix(col, "label:"):
+				if value != "" {
+					if entry.Labels == nil {
+						entry.Labels = make(map[string]string)
+					}
+					entry.Labels[strings.TrimPrefix(col, "label:")] = value
+				}
+			default:
+				if value != "" {
+					if entry.Attributes == nil {
+						entry.Attributes = make(map[string]string)
+					}
+					entry.Attributes[col] = value
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// manifestToCsvRecords converts manifest entries into a CSV header and rows so
+// that JSON imports go through exactly the same processing as CSV imports
+func manifestToCsvRecords(entries []ManifestEntry) ([]string, [][]string) {
+	hasValue := false
+	hasRedacted := false
+	hasVersion := false
+	labelKeys := make(map[string]bool)
+	attrKeys := make(map[string]bool)
+	for _, entry := range entries {
+		if entry.Value != nil {
+			hasValue = true
+		}
+		if entry.RedactedValue != "" {
+			hasRedacted = true
+		}
+		if entry.Version != "" {
+			hasVersion = true
+		}
+		for key := range entry.Labels {
+			labelKeys[key] = true
+		}
+		for key := range entry.Attributes {
+			attrKeys[key] = true
+		}
+	}
+
+	labelKeysSorted := sortedKeys(labelKeys)
+	attrKeysSorted := sortedKeys(attrKeys)
+
+	header := []string{"name"}
+	if hasValue {
+		header = append(header, "value")
+	}
+	if hasRedacted {
+		header = append(header, redactedValueColumn)
+	}
+	if hasVersion {
+		header = append(header, versionColumn)
+	}
+	header = append(header, "title")
+	for _, key := range labelKeysSorted {
+		header = append(header, "label:"+key)
+	}
+	header = append(header, attrKeysSorted...)
+
+	records := make([][]string, 0, len(entries))
+	for _, entry := range entries {
+		row := []string{entry.Name}
+		if hasValue {
+			value := ""
+			if entry.Value != nil {
+				value = *entry.Value
+			}
+			row = append(row, value)
+		}
+		if hasRedacted {
+			row = append(row, entry.RedactedValue)
+		}
+		if hasVersion {
+			row = append(row, entry.Version)
+		}
+		row = append(row, entry.Title)
+		for _, key := range labelKeysSorted {
+			row = append(row, entry.Labels[key])
+		}
+		for _, key := range attrKeysSorted {
+			row = append(row, entry.Attributes[key])
+		}
+		records = append(records, row)
+	}
+
+	return header, records
+}
+
+// parseManifest parses a JSON manifest into a CSV-style header and rows
+func parseManifest(data []byte) ([]string, [][]string, error) {
+	var entries []ManifestEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse JSON manifest: %w", err)
+	}
+	header, records := manifestToCsvRecords(entries)
+	return header, records, nil
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// roundTripSecret is the part of a secret that export and import must agree on
+type roundTripSecret struct {
+	Labels     map[string]string
+	Title      string
+	Attributes map[string]string
+}
+
+// expectedRoundTripSecrets builds the state an export should reproduce from
+// the listed secrets and the loaded configuration, keyed by full secret name
+func expectedRoundTripSecrets(secrets []SecretInfo) map[string]roundTripSecret {
+	expected := make(map[string]roundTripSecret)
+	prefix := GetPrefix()
+	for _, secret := range secrets {
+		name := extractSecretName(secret.Name)
+		state := roundTripSecret{
+			Labels:     make(map[string]string),
+			Attributes: make(map[string]string),
+		}
+		for key, value := range secret.Labels {
+			state.Labels[key] = value
+		}
+		if credInfo := GetCredentialInfo(strings.TrimPrefix(name, prefix)); credInfo != nil {
+			state.Title = credInfo.Title
+			for key, value := range credInfo.Attributes {
+				state.Attributes[key] = fmt.Sprintf("%v", value)
+			}
+		}
+		expected[name] = state
+	}
+	return expected
+}
+
+// reimportedRoundTripSecrets interprets exported rows exactly as a dry-run
+// import would, keyed by the resolved secret name. Rows import would skip are
+// reported as differences.
+func reimportedRoundTripSecrets(header []string, records [][]string) (map[string]roundTripSecret, []string, error) {
+	nameIdx, valueIdx, err := validateHeader(header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prefix := GetPrefix()
+	reimported := make(map[string]roundTripSecret)
+	var problems []string
+	for i, record := range records {
+		userInputName := strings.TrimSpace(record[nameIdx])
+		resolvedName, _, skip, skipReason := resolveImportSecretName(userInputName, prefix)
+		if skip {
+			problems = append(problems, fmt.Sprintf("row %d would be skipped on import: %s", i+2, skipReason))
+			continue
+		}
+
+		labels, title, attributes := extractColumnsData(header, record, nameIdx, valueIdx)
+		// Import stores attribute keys in lowercase
+		storedAttributes := make(map[string]string)
+		for key, value := range attributes {
+			storedAttributes[strings.ToLower(key)] = value
+		}
+		reimported[resolvedName] = roundTripSecret{Labels: labels, Title: title, Attributes: storedAttributes}
+	}
+
+	return reimported, problems, nil
+}
+
+// compareRoundTrip reports differences between the expected and re-imported secret sets
+func compareRoundTrip(expected, reimported map[string]roundTripSecret) []string {
+	var differences []string
+
+	names := make(map[string]bool)
+	for name := range expected {
+		names[name] = true
+	}
+	for name := range reimported {
+		names[name] = true
+	}
+
+	for _, name := range sortedKeys(names) {
+		want, inExpected := expected[name]
+		got, inReimported := reimported[name]
+		switch {
+		case !inReimported:
+			differences = append(differences, fmt.Sprintf("%s: missing after re-import", name))
+			continue
+		case !inExpected:
+			differences = append(differences, fmt.Sprintf("%s: unexpected secret after re-import", name))
+			continue
+		}
+
+		if want.Title != got.Title {
+			differences = append(differences, fmt.Sprintf("%s: title %q would be re-imported as %q", name, want.Title, got.Title))
+		}
+		if !reflect.DeepEqual(want.Labels, got.Labels) {
+			differences = append(differences, fmt.Sprintf("%s: labels %v would be re-imported as %v", name, want.Labels, got.Labels))
+		}
+		if !reflect.DeepEqual(want.Attributes, got.Attributes) {
+			differences = append(differences, fmt.Sprintf("%s: attributes %v would be re-imported as %v", name, want.Attributes, got.Attributes))
+		}
+	}
+
+	return differences
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestManifestConversionRoundTrip tests that CSV records survive conversion to a manifest and back
+func TestManifestConversionRoundTrip(t *testing.T) {
+	records := [][]string{
+		{"name", "value", "title", "label:env", "label:team", "owner"},
+		{"db-password", "s3cret", "Database Password", "prod", "", "alice"},
+		{"api-key", "", "", "dev", "backend", ""},
+	}
+
+	entries := csvRecordsToManifest(records)
+	if len(entries) != 2 {
+		t.Fatalf("csvRecordsToManifest() returned %d entries, expected 2", len(entries))
+	}
+	if entries[0].Value == nil || *entries[0].Value != "s3cret" {
+		t.Errorf("entries[0].Value = %v, expected s3cret", entries[0].Value)
+	}
+	if _, ok := entries[0].Labels["team"]; ok {
+		t.Errorf("empty label cell should be omitted, got %v", entries[0].Labels)
+	}
+	if entries[1].Attributes != nil {
+		t.Errorf("empty attribute cell should be omitted, got %v", entries[1].Attributes)
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		t.Fatalf("Failed to marshal manifest: %v", err)
+	}
+	header, rows, err := parseManifest(data)
+	if err != nil {
+		t.Fatalf("parseManifest() failed: %v", err)
+	}
+
+	if !reflect.DeepEqual(header, records[0]) {
+		t.Errorf("header = %v, expected %v", header, records[0])
+	}
+	if !reflect.DeepEqual(rows, records[1:]) {
+		t.Errorf("rows = %v, expected %v", rows, records[1:])
+	}
+}
+
+// TestParseManifestInvalid tests that malformed manifests are rejected
+func TestParseManifestInvalid(t *testing.T) {
+	if _, _, err := parseManifest([]byte(`{"name": "not-an-array"}`)); err == nil {
+		t.Error("Expected error for non-array manifest")
+	}
+}
+
+// TestManifestRedactedValues tests that redacted manifests keep the redacted column, which import rejects
+func TestManifestRedactedValues(t *testing.T) {
+	records := [][]string{
+		{"name", redactedValueColumn, "title"},
+		{"db-password", "sha256:0123456789abcdef", ""},
+	}
+
+	header, _ := manifestToCsvRecords(csvRecordsToManifest(records))
+	if _, _, err := validateHeader(header); err == nil {
+		t.Error("Expected validateHeader to reject a redacted manifest")
+	}
+}
+
+// TestManifestVersionColumn tests that the exported version survives a JSON round trip
+// without becoming a config attribute
+func TestManifestVersionColumn(t *testing.T) {
+	records := [][]string{
+		{"name", "value", versionColumn, "title"},
+		{"db-password", "s3cret", "3", "Database Password"},
+	}
+
+	entries := csvRecordsToManifest(records)
+	if entries[0].Version != "3" || entries[0].Attributes != nil {
+		t.Errorf("Version not mapped to the manifest field: %+v", entries[0])
+	}
+
+	header, rows := manifestToCsvRecords(entries)
+	if !reflect.DeepEqual(header, records[0]) || !reflect.DeepEqual(rows, records[1:]) {
+		t.Errorf("manifestToCsvRecords() = %v %v, expected %v", header, rows, records)
+	}
+
+	_, _, attributes := extractColumnsData(header, rows[0], 0, 1)
+	if len(attributes) != 0 {
+		t.Errorf("version column should not be imported as an attribute, got %v", attributes)
+	}
+}
+
+// TestAssertExportRoundTrip tests the export/re-import comparison used by --assert-roundtrip
+func TestAssertExportRoundTrip(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{
+		Prefix: "team-",
+		Credentials: []CredentialInfo{
+			{
+				Name:       "db-password",
+				Title:      "Database Password",
+				Attributes: map[string]interface{}{"owner": "alice"},
+			},
+		},
+	}
+
+	secrets := []SecretInfo{
+		{Name: "projects/p/secrets/team-db-password", Labels: map[string]string{"env": "prod"}},
+		{Name: "projects/p/secrets/team-api-key"},
+	}
+
+	for _, format := range []string{"csv", "json"} {
+		t.Run(format, func(t *testing.T) {
+			records := prepareCsvRecords(secrets, false, false, false, "p")
+			data, err := encodeExportRecords(records, format)
+			if err != nil {
+				t.Fatalf("encodeExportRecords() failed: %v", err)
+			}
+			if err := assertExportRoundTrip(secrets, data, format); err != nil {
+				t.Errorf("assertExportRoundTrip() failed: %v", err)
+			}
+		})
+	}
+}
+
+// TestCompareRoundTrip tests reporting of differences between exported and re-imported data
+func TestCompareRoundTrip(t *testing.T) {
+	expected := map[string]roundTripSecret{
+		"a": {Labels: map[string]string{"env": "prod"}, Title: "A", Attributes: map[string]string{}},
+		"b": {Labels: map[string]string{}, Attributes: map[string]string{"Owner": "bob"}},
+		"c": {Labels: map[string]string{}, Attributes: map[string]string{}},
+	}
+	reimported := map[string]roundTripSecret{
+		"a": {Labels: map[string]string{"env": "prod"}, Title: "A", Attributes: map[string]string{}},
+		"b": {Labels: map[string]string{}, Attributes: map[string]string{"owner": "bob"}},
+		"d": {Labels: map[string]string{}, Attributes: map[string]string{}},
+	}
+
+	differences := compareRoundTrip(expected, reimported)
+	if len(differences) != 3 {
+		t.Fatalf("compareRoundTrip() returned %d differences, expected 3: %v", len(differences), differences)
+	}
+	for i, want := range []string{"b: attributes", "c: missing", "d: unexpected"} {
+		if !strings.HasPrefix(differences[i], want) {
+			t.Errorf("differences[%d] = %q, expected prefix %q", i, differences[i], want)
+		}
+	}
+}
+
+// TestReimportedRoundTripSecretsSkippedRows tests that rows import would skip are reported
+func TestReimportedRoundTripSecretsSkippedRows(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Prefix: "team-"}
+
+	header := []string{"name", "title"}
+	records := [][]string{
+		{"team-db", "DB"},
+		{"other-db", "Other"},
+	}
+
+	reimported, problems, err := reimportedRoundTripSecrets(header, records)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := reimported["team-db"]; !ok || len(reimported) != 1 {
+		t.Errorf("reimported = %v, expected only team-db", reimported)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0], "row 3") {
+		t.Errorf("problems = %v, expected one problem for row 3", problems)
+	}
+}
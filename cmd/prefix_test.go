@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -726,3 +727,45 @@ func TestPrefixConsistencyAcrossCommands(t *testing.T) {
 		})
 	}
 }
+
+// TestValidateSecretName tests the Secret Manager naming rules applied after prefixing
+func TestValidateSecretName(t *testing.T) {
+	longName := strings.Repeat("a", maxSecretNameLength)
+	tests := []struct {
+		name        string
+		prefix      string
+		secretName  string
+		expectError string
+	}{
+		{name: "Simple name", secretName: "db-password"},
+		{name: "Letters digits underscores", secretName: "API_Key_2"},
+		{name: "Maximum length", secretName: longName},
+		{name: "Prefixed name at maximum length", prefix: "team-", secretName: "team-" + longName[len("team-"):]},
+		{name: "Empty name", secretName: "", expectError: "empty"},
+		{name: "Dot", secretName: "db.password", expectError: `contains "."`},
+		{name: "Slash", secretName: "team/db", expectError: `contains "/"`},
+		{name: "Space", secretName: "db password", expectError: `contains " "`},
+		{name: "Non-ASCII letter", secretName: "clé", expectError: `contains "é"`},
+		{name: "Too long", secretName: longName + "a", expectError: "256 characters long; the maximum"},
+		{name: "Too long only because of the prefix", prefix: "team-", secretName: "team-" + longName, expectError: "including the prefix 'team-'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			originalConfig := globalConfig
+			defer func() { globalConfig = originalConfig }()
+			globalConfig = &Config{Prefix: tt.prefix}
+
+			err := validateSecretName(tt.secretName)
+			if tt.expectError == "" {
+				if err != nil {
+					t.Errorf("validateSecretName(%q) failed: %v", tt.secretName, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+				t.Errorf("validateSecretName(%q) error = %v, expected it to contain %q", tt.secretName, err, tt.expectError)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// projectsFlagUsage is the help text for --projects on read-only commands
+const projectsFlagUsage = "Run across these projects concurrently (comma-separated IDs or patterns like 'team-*')"
+
+// projectRunResult is the captured output of one project in a --projects fan-out
+type projectRunResult struct {
+	Project string
+	Stdout  []byte
+	Stderr  []byte
+	Err     error
+}
+
+// addProjectsFlag registers --projects on a read-only command
+func addProjectsFlag(cmd *cobra.Command) {
+	cmd.Flags().String("projects", "", projectsFlagUsage)
+}
+
+// runAcrossProjects handles --projects: it resolves the project list, runs the
+// same command once per project concurrently (as a child gsecutil process with
+// --project set), then prints each project's output under a header and reports
+// the projects that failed. Every flag except --project and --projects is
+// passed through to the children.
+func runAcrossProjects(cmd *cobra.Command, args []string, projectsValue string) error {
+	if cmd.Flags().Changed("project") {
+		return fmt.Errorf("--project and --projects cannot be used together")
+	}
+
+	projects, err := resolveProjectList(projectsValue, listAccessibleProjects)
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		return fmt.Errorf("no projects match '%s'", projectsValue)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate gsecutil executable: %w", err)
+	}
+
+	results := fanOutProjects(projects, func(project string) projectRunResult {
+		var stdout, stderr bytes.Buffer
+		child := exec.Command(executable, buildProjectFanOutArgs(cmd, args, project)...)
+		child.Stdout = &stdout
+		child.Stderr = &stderr
+		err := child.Run()
+		return projectRunResult{Stdout: stdout.Bytes(), Stderr: stderr.Bytes(), Err: err}
+	})
+
+	return displayProjectResults(results)
+}
+
+// resolveProjectList splits a --projects value into project IDs. Entries with
+// glob characters are matched against the projects returned by listProjects.
+// The result is sorted and deduplicated.
+func resolveProjectList(value string, listProjects func() ([]string, error)) ([]string, error) {
+	seen := make(map[string]bool)
+	var available []string
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.ContainsAny(entry, "*?[") {
+			seen[entry] = true
+			continue
+		}
+
+		if available == nil {
+			var err error
+			if available, err = listProjects(); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := path.Match(entry, ""); err != nil {
+			return nil, fmt.Errorf("invalid project pattern '%s': %w", entry, err)
+		}
+		for _, project := range available {
+			if matched, _ := path.Match(entry, project); matched {
+				seen[project] = true
+			}
+		}
+	}
+
+	projects := make([]string, 0, len(seen))
+	for project := range seen {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+	return projects, nil
+}
+
+// listAccessibleProjects returns the IDs of the projects visible to the active gcloud account
+func listAccessibleProjects() ([]string, error) {
+	gcloudCmd := exec.Command(gcloudBinary(), "projects", "list", "--format", "value(projectId)")
+	output, err := gcloudCmd.Output()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return nil, formatGcloudError(string(exitError.Stderr))
+		}
+		return nil, fmt.Errorf("failed to execute gcloud command: %v", err)
+	}
+
+	var projects []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if project := strings.TrimSpace(line); project != "" {
+			projects = append(projects, project)
+		}
+	}
+	return projects, nil
+}
+
+// buildProjectFanOutArgs rebuilds the command line for the child process of one
+// project: the command path, every flag the user set except --projects, the
+// project, then the positional arguments
+func buildProjectFanOutArgs(cmd *cobra.Command, args []string, project string) []string {
+	childArgs := strings.Fields(cmd.CommandPath())[1:]
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if flag.Name == "project" || flag.Name == "projects" {
+			return
+		}
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			for _, value := range slice.GetSlice() {
+				childArgs = append(childArgs, "--"+flag.Name+"="+value)
+			}
+			return
+		}
+		childArgs = append(childArgs, "--"+flag.Name+"="+flag.Value.String())
+	})
+	childArgs = append(childArgs, "--project="+project, "--")
+	return append(childArgs, args...)
+}
+
+// fanOutProjects runs fn for each project concurrently and returns the results
+// in project order
+func fanOutProjects(projects []string, fn func(project string) projectRunResult) []projectRunResult {
+	const maxConcurrency = 10
+	results := make([]projectRunResult, len(projects))
+	sem := make(chan struct{}, maxConcurrency)
+	var wg sync.WaitGroup
+	for i := range projects {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[idx] = fn(projects[idx])
+			results[idx].Project = projects[idx]
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// displayProjectResults prints each project's output under a header, followed
+// by a summary of failed projects. It returns an error if any project failed.
+func displayProjectResults(results []projectRunResult) error {
+	var failed []projectRunResult
+	for i, result := range results {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("=== %s ===\n", result.Project)
+		if result.Err != nil {
+			failed = append(failed, result)
+			fmt.Printf("Error: %s\n", projectFailureMessage(result))
+			continue
+		}
+		os.Stdout.Write(result.Stdout)
+		if len(result.Stdout) > 0 && !bytes.HasSuffix(result.Stdout, []byte("\n")) {
+			fmt.Println()
+		}
+		os.Stderr.Write(result.Stderr)
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "\nFailed in %d of %d projects:\n", len(failed), len(results))
+	for _, result := range failed {
+		fmt.Fprintf(os.Stderr, "  - %s: %s\n", result.Project, projectFailureMessage(result))
+	}
+	return fmt.Errorf("command failed in %d of %d projects", len(failed), len(results))
+}
+
+// projectFailureMessage returns the error a failed child printed last (from
+// its final "Error: " line onwards, condensed into one line), or the process error
+func projectFailureMessage(result projectRunResult) string {
+	lines := strings.Split(strings.TrimSpace(string(result.Stderr)), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if !strings.HasPrefix(lines[i], "Error: ") {
+			continue
+		}
+		// Drop decoration lines such as the separators around gcloud errors
+		var parts []string
+		for _, line := range lines[i:] {
+			if strings.IndexFunc(line, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+				parts = append(parts, strings.TrimSpace(line))
+			}
+		}
+		return strings.TrimPrefix(strings.Join(parts, " "), "Error: ")
+	}
+	return result.Err.Error()
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// TestResolveProjectList tests explicit IDs, patterns, and deduplication
+func TestResolveProjectList(t *testing.T) {
+	available := func() ([]string, error) {
+		return []string{"app-dev", "app-prod", "data-prod"}, nil
+	}
+	failing := func() ([]string, error) { return nil, errors.New("no access") }
+
+	tests := []struct {
+		name        string
+		value       string
+		lister      func() ([]string, error)
+		expected    string
+		expectError bool
+	}{
+		{name: "explicit IDs are not looked up", value: "b, a,,a", lister: failing, expected: "a,b"},
+		{name: "pattern", value: "app-*", lister: available, expected: "app-dev,app-prod"},
+		{name: "pattern and ID", value: "*-prod,extra", lister: available, expected: "app-prod,data-prod,extra"},
+		{name: "no match", value: "web-*", lister: available, expected: ""},
+		{name: "lister fails", value: "app-*", lister: failing, expectError: true},
+		{name: "invalid pattern", value: "app-[", lister: available, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects, err := resolveProjectList(tt.value, tt.lister)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := strings.Join(projects, ","); got != tt.expected {
+				t.Errorf("resolveProjectList(%q) = %s, expected %s", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestBuildProjectFanOutArgs tests that set flags and arguments are passed to children
+func TestBuildProjectFanOutArgs(t *testing.T) {
+	root := &cobra.Command{Use: "gsecutil"}
+	root.PersistentFlags().StringP("project", "p", "", "")
+	child := &cobra.Command{Use: "get"}
+	child.Flags().String("projects", "", "")
+	child.Flags().String("version", "", "")
+	child.Flags().Bool("metadata-only", false, "")
+	child.Flags().StringSlice("labels", nil, "")
+	root.AddCommand(child)
+
+	if err := child.ParseFlags([]string{"--projects", "a,b", "-p", "x", "--metadata-only", "--labels", "env=prod,team=x", "--version=3"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	got := strings.Join(buildProjectFanOutArgs(child, []string{"-db"}, "a"), " ")
+	expected := "get --labels=env=prod --labels=team=x --metadata-only=true --version=3 --project=a -- -db"
+	if got != expected {
+		t.Errorf("buildProjectFanOutArgs() = %q, expected %q", got, expected)
+	}
+}
+
+// TestProjectFailureMessage tests condensing a child's error output
+func TestProjectFailureMessage(t *testing.T) {
+	stderr := "Warning: something\nError: gsecutil: Error executing gcloud command:\n────────\nERROR: (gcloud) denied\n────────\n"
+	result := projectRunResult{Stderr: []byte(stderr), Err: errors.New("exit status 1")}
+	if got := projectFailureMessage(result); got != "gsecutil: Error executing gcloud command: ERROR: (gcloud) denied" {
+		t.Errorf("projectFailureMessage() = %q", got)
+	}
+
+	result = projectRunResult{Err: errors.New("exit status 2")}
+	if got := projectFailureMessage(result); got != "exit status 2" {
+		t.Errorf("projectFailureMessage() without stderr = %q", got)
+	}
+}
@@ -25,7 +25,7 @@ func Execute(version string) {
 	rootCmd.Version = version
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitCodeFor(err))
 	}
 }
 
@@ -33,10 +33,18 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringP("project", "p", "", "Google Cloud project ID")
 	rootCmd.PersistentFlags().String("config", "", "Configuration file path (default: auto-detect: ./gsecutil.conf then $HOME/.config/gsecutil/gsecutil.conf)")
+	rootCmd.PersistentFlags().Bool("no-config", false, "Ignore the configuration file (no project, prefix, or credentials from config; flags and environment variables still apply)")
 
 	// Set up pre-run hook to load custom config if specified
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
 		configPath, _ := cmd.Flags().GetString("config")
+		if noConfig, _ := cmd.Flags().GetBool("no-config"); noConfig {
+			if configPath != "" {
+				return fmt.Errorf("--no-config cannot be combined with --config")
+			}
+			DisableConfig()
+			return nil
+		}
 		if configPath != "" {
 			if err := SetCustomConfigPath(configPath); err != nil {
 				return fmt.Errorf("failed to load config file: %w", err)
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run --secret NAME[=VAR] ... -- COMMAND [ARGS...]",
+	Short: "Run a command with secrets in its environment",
+	Long: `Run a command with secret values set as environment variables.
+
+Each --secret is NAME=VAR, which sets VAR to the latest value of secret NAME,
+or just NAME, which derives the variable by uppercasing the name and turning
+dashes into underscores (db-password becomes DB_PASSWORD). The configured
+prefix is added to secret names as usual. Every secret is read before the
+command starts, so a missing secret means the command never runs.
+
+The command inherits gsecutil's environment, stdin, stdout, and stderr, plus
+the secret variables; values are never written to disk or printed. Interrupt
+and termination signals are passed on to the command, and gsecutil exits with
+the command's exit code.
+
+Flags after the command name belong to the command; a -- before it makes the
+boundary explicit.
+
+Examples:
+  gsecutil run --secret db-password=DB_PASS --secret api-key=API_KEY -- ./server --port 8080
+  gsecutil run --secret db-password -- printenv DB_PASSWORD
+  gsecutil run --secret db-password,api-key -p my-project -- make test`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		project, _ := cmd.Flags().GetString("project")
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
+		specs, _ := cmd.Flags().GetStringSlice("secret")
+		secrets, err := parseRunSecrets(specs)
+		if err != nil {
+			return err
+		}
+		env, err := runSecretEnv(secrets, project)
+		if err != nil {
+			return err
+		}
+
+		return runWithEnv(args, env)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+	runCmd.Flags().StringSlice("secret", nil, "Secret to inject as NAME=VAR, or NAME to derive VAR by uppercasing (comma-separated or repeated)")
+	if err := runCmd.MarkFlagRequired("secret"); err != nil {
+		panic(fmt.Sprintf("Failed to mark secret flag as required for run command: %v", err))
+	}
+	// Stop parsing at the command name, so its flags are passed through
+	runCmd.Flags().SetInterspersed(false)
+}
+
+// runSecret maps a secret to the environment variable 'run' sets
+type runSecret struct {
+	Name string // secret name as given by the user, without the prefix
+	Var  string // environment variable name
+}
+
+// parseRunSecrets parses --secret values. A variable set by more than one
+// secret is an error, since only one value could reach the command.
+func parseRunSecrets(specs []string) ([]runSecret, error) {
+	secrets := make([]runSecret, 0, len(specs))
+	sources := make(map[string]string)
+	for _, spec := range specs {
+		name, variable, explicit := strings.Cut(strings.TrimSpace(spec), "=")
+		if name == "" {
+			return nil, fmt.Errorf("invalid --secret '%s': use NAME=VAR or NAME", spec)
+		}
+		if explicit {
+			if !envVarNamePattern.MatchString(variable) {
+				return nil, fmt.Errorf("invalid --secret '%s': '%s' is not a valid environment variable name", spec, variable)
+			}
+		} else {
+			var err error
+			if variable, err = envVarName(name, envNameOptions{Upper: true, ReplaceDash: true}); err != nil {
+				return nil, err
+			}
+		}
+		if other, ok := sources[variable]; ok {
+			return nil, fmt.Errorf("secrets '%s' and '%s' both set %s", other, name, variable)
+		}
+		sources[variable] = name
+		secrets = append(secrets, runSecret{Name: name, Var: variable})
+	}
+	return secrets, nil
+}
+
+// runSecretEnv reads the latest value of each secret and returns VAR=value
+// entries for the command's environment
+func runSecretEnv(secrets []runSecret, project string) ([]string, error) {
+	env := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		value, err := secretManager.GetValue(AddPrefixToSecretName(secret.Name), "latest", project)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret '%s': %w", secret.Name, err)
+		}
+		env = append(env, secret.Var+"="+value)
+	}
+	return env, nil
+}
+
+// runWithEnv runs a command with extra environment entries, which override
+// inherited variables of the same name. A non-zero exit is returned as an
+// error wrapping *exec.ExitError, so gsecutil exits with the same code.
+func runWithEnv(args []string, env []string) error {
+	child := exec.Command(args[0], args[1:]...)
+	child.Env = append(os.Environ(), env...)
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+
+	if err := child.Start(); err != nil {
+		return fmt.Errorf("failed to start '%s': %w", args[0], err)
+	}
+
+	// Pass signals on instead of letting them end gsecutil before the command
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer func() {
+		signal.Stop(signals)
+		close(signals)
+	}()
+	go func() {
+		for sig := range signals {
+			_ = child.Process.Signal(sig)
+		}
+	}()
+
+	if err := child.Wait(); err != nil {
+		return fmt.Errorf("'%s' failed: %w", args[0], err)
+	}
+	return nil
+}
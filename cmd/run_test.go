@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestParseRunSecrets tests parsing of run --secret values
+func TestParseRunSecrets(t *testing.T) {
+	tests := []struct {
+		name        string
+		specs       []string
+		expected    []runSecret
+		expectError bool
+	}{
+		{
+			name:     "Explicit variables",
+			specs:    []string{"db-password=DB_PASS", "api-key=API_KEY"},
+			expected: []runSecret{{Name: "db-password", Var: "DB_PASS"}, {Name: "api-key", Var: "API_KEY"}},
+		},
+		{
+			name:     "Derived variable",
+			specs:    []string{"db-password"},
+			expected: []runSecret{{Name: "db-password", Var: "DB_PASSWORD"}},
+		},
+		{
+			name:        "Invalid explicit variable",
+			specs:       []string{"db-password=DB-PASS"},
+			expectError: true,
+		},
+		{
+			name:        "Derived variable starting with a digit",
+			specs:       []string{"2fa-seed"},
+			expectError: true,
+		},
+		{
+			name:        "Missing secret name",
+			specs:       []string{"=DB_PASS"},
+			expectError: true,
+		},
+		{
+			name:        "Two secrets setting one variable",
+			specs:       []string{"db-password", "other=DB_PASSWORD"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseRunSecrets(tt.specs)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("parseRunSecrets() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRunSecretEnv tests reading secrets into environment entries
+func TestRunSecretEnv(t *testing.T) {
+	fake := useFakeSecretManager(t, &Config{Prefix: "team-"})
+	fake.secrets["team-db-password"] = []string{"old", "s3cret"}
+
+	env, err := runSecretEnv([]runSecret{{Name: "db-password", Var: "DB_PASS"}}, "test-project")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := []string{"DB_PASS=s3cret"}; !reflect.DeepEqual(env, expected) {
+		t.Errorf("runSecretEnv() = %v, expected %v", env, expected)
+	}
+
+	var notFound *NotFoundError
+	if _, err := runSecretEnv([]runSecret{{Name: "missing", Var: "MISSING"}}, "test-project"); !errors.As(err, &notFound) {
+		t.Errorf("Expected NotFoundError for a missing secret, got %v", err)
+	}
+}
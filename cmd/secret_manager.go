@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SecretManager is the set of Secret Manager operations the commands depend
+// on. Secret names are full names (with the configured prefix), and an empty
+// project means the gcloud default. Commands use the package-level
+// secretManager, which tests replace with a fake.
+type SecretManager interface {
+	// GetValue reads one version ("latest" or a number) of a secret, with
+	// surrounding whitespace trimmed
+	GetValue(secretName, version, project string) (string, error)
+	// GetRawValue reads one version like GetValue, but returns the payload
+	// exactly as stored
+	GetRawValue(secretName, version, project string) (string, error)
+	// List returns the secrets matching a gcloud --filter expression, at most
+	// limit of them when limit is positive
+	List(project, filter string, limit int) ([]SecretInfo, error)
+	// ListNames returns the IDs of the secrets whose names start with prefix
+	ListNames(project, prefix string) (map[string]bool, error)
+	// Describe returns the metadata of a secret
+	Describe(secretName, project string) (SecretInfo, error)
+	// Exists reports whether a secret exists
+	Exists(secretName, project string) (bool, error)
+	// GetVersion returns the metadata of one version of a secret
+	GetVersion(secretName, version, project string) (*SecretVersionInfo, error)
+	// ListVersions returns every version of a secret
+	ListVersions(secretName, project string) ([]SecretVersionInfo, error)
+	// Create creates a secret with a first version
+	Create(secretName, project, value string, options SecretCreateOptions) error
+	// AddVersion adds a new version to an existing secret
+	AddVersion(secretName, project, value string) error
+	// UpdateLabels changes the labels of an existing secret. labelArgs are the
+	// flags built by buildLabelUpdateArgs or setLabelsArgs.
+	UpdateLabels(secretName, project string, labelArgs []string) error
+	// Delete deletes a secret and all of its versions
+	Delete(secretName, project string) error
+	// GetIamPolicy returns the secret-level IAM policy
+	GetIamPolicy(secretName, project string) (*IAMPolicy, error)
+	// AddIamBinding grants role on a secret to principal, bound by condition
+	// when it is not nil
+	AddIamBinding(secretName, project, principal, role string, condition *Condition) error
+	// RemoveIamBinding revokes role on a secret from principal
+	RemoveIamBinding(secretName, project, principal, role string) error
+	// GetProjectIamPolicy returns the IAM policy of a project
+	GetProjectIamPolicy(projectID string) (*IAMPolicy, error)
+	// AddProjectIamBinding grants role on a project to principal, bound by
+	// condition when it is not nil
+	AddProjectIamBinding(projectID, principal, role string, condition *Condition) error
+	// RemoveProjectIamBinding revokes the unconditional binding of role on a
+	// project from principal
+	RemoveProjectIamBinding(projectID, principal, role string) error
+	// GetAncestors returns the resource hierarchy above a project
+	GetAncestors(projectID string) ([]ProjectAncestor, error)
+	// GetAncestorIamPolicy returns the IAM policy of a folder or organization
+	GetAncestorIamPolicy(ancestor ProjectAncestor) (*IAMPolicy, error)
+	// DefaultProject returns the project used when none is configured, or
+	// an empty string when there is none
+	DefaultProject() string
+}
+
+// SecretCreateOptions holds the optional settings of a new secret
+type SecretCreateOptions struct {
+	Labels      []string      // key=value
+	Annotations []string      // key=value
+	TTL         time.Duration // time until the secret expires, zero for none
+	ExpireTime  time.Time     // when the secret expires, zero for none
+}
+
+// secretManager is the backend used by the commands
+var secretManager SecretManager = gcloudSecretManager{}
+
+// gcloudOutputError is returned by the write operations of
+// gcloudSecretManager when gcloud fails. Output is gcloud's combined output,
+// which callers pass to classifyGcloudFailure.
+type gcloudOutputError struct {
+	Output string
+}
+
+func (e *gcloudOutputError) Error() string {
+	return fmt.Sprintf("gcloud command failed: %s", e.Output)
+}
+
+// classifyWriteFailure turns a failed write into a SecretExistsError or
+// NotFoundError when gcloud's output says so, or returns err unchanged
+func classifyWriteFailure(err error, secretName, userInputName string) error {
+	var outputErr *gcloudOutputError
+	if errors.As(err, &outputErr) {
+		if typed := classifyGcloudFailure(outputErr.Output, secretName, userInputName); typed != nil {
+			return typed
+		}
+	}
+	return err
+}
+
+// gcloudSecretManager implements SecretManager by running gcloud
+type gcloudSecretManager struct{}
+
+func (gcloudSecretManager) GetValue(secretName, version, project string) (string, error) {
+	return accessSecretValue(secretName, version, project)
+}
+
+func (gcloudSecretManager) GetRawValue(secretName, version, project string) (string, error) {
+	return accessSecretPayload(secretName, version, project)
+}
+
+func (gcloudSecretManager) List(project, filter string, limit int) ([]SecretInfo, error) {
+	return fetchSecrets(project, filter, limit)
+}
+
+func (gcloudSecretManager) ListNames(project, prefix string) (map[string]bool, error) {
+	return getExistingSecretNames(project, prefix)
+}
+
+func (gcloudSecretManager) Describe(secretName, project string) (SecretInfo, error) {
+	var secretInfo SecretInfo
+	output, err := runGcloudDescribe(secretName, project, "json")
+	if err != nil {
+		return secretInfo, err
+	}
+	if err := json.Unmarshal(output, &secretInfo); err != nil {
+		return secretInfo, fmt.Errorf("failed to parse secret metadata: %w", err)
+	}
+	return secretInfo, nil
+}
+
+func (gcloudSecretManager) Exists(secretName, project string) (bool, error) {
+	return secretExists(secretName, project)
+}
+
+func (gcloudSecretManager) GetVersion(secretName, version, project string) (*SecretVersionInfo, error) {
+	return getSecretVersionInfo(secretName, version, project)
+}
+
+func (gcloudSecretManager) ListVersions(secretName, project string) ([]SecretVersionInfo, error) {
+	return fetchSecretVersions(secretName, project)
+}
+
+func (gcloudSecretManager) Create(secretName, project, value string, options SecretCreateOptions) error {
+	gcloudArgs := []string{"secrets", "create", secretName}
+	if project != "" {
+		gcloudArgs = append(gcloudArgs, "--project", project)
+	}
+	for _, label := range options.Labels {
+		gcloudArgs = append(gcloudArgs, "--labels", label)
+	}
+	if len(options.Annotations) > 0 {
+		gcloudArgs = append(gcloudArgs, "--annotations", gcloudDictFlagValue(options.Annotations))
+	}
+	if options.TTL > 0 {
+		gcloudArgs = append(gcloudArgs, "--ttl", strconv.FormatFloat(options.TTL.Seconds(), 'f', -1, 64)+"s")
+	}
+	if !options.ExpireTime.IsZero() {
+		gcloudArgs = append(gcloudArgs, "--expire-time", options.ExpireTime.Format(time.RFC3339Nano))
+	}
+	gcloudArgs = append(gcloudArgs, "--data-file", "-")
+	return runGcloudWrite(gcloudArgs, value)
+}
+
+func (gcloudSecretManager) AddVersion(secretName, project, value string) error {
+	gcloudArgs := []string{"secrets", "versions", "add", secretName}
+	if project != "" {
+		gcloudArgs = append(gcloudArgs, "--project", project)
+	}
+	gcloudArgs = append(gcloudArgs, "--data-file", "-")
+	return runGcloudWrite(gcloudArgs, value)
+}
+
+func (gcloudSecretManager) UpdateLabels(secretName, project string, labelArgs []string) error {
+	return updateSecretLabels(secretName, project, labelArgs)
+}
+
+func (gcloudSecretManager) Delete(secretName, project string) error {
+	gcloudArgs := []string{"secrets", "delete", secretName, "--quiet"}
+	if project != "" {
+		gcloudArgs = append(gcloudArgs, "--project", project)
+	}
+	return runGcloudWrite(gcloudArgs, "")
+}
+
+func (gcloudSecretManager) GetIamPolicy(secretName, project string) (*IAMPolicy, error) {
+	return fetchSecretIAMPolicy(secretName, project)
+}
+
+func (gcloudSecretManager) AddIamBinding(secretName, project, principal, role string, condition *Condition) error {
+	return runGcloudIAMChange(grantAccessArgs(secretName, principal, role, project, condition))
+}
+
+func (gcloudSecretManager) RemoveIamBinding(secretName, project, principal, role string) error {
+	return runGcloudIAMChange(revokeAccessArgs(secretName, principal, role, project))
+}
+
+func (gcloudSecretManager) GetProjectIamPolicy(projectID string) (*IAMPolicy, error) {
+	return fetchProjectIAMPolicy(projectID)
+}
+
+func (gcloudSecretManager) AddProjectIamBinding(projectID, principal, role string, condition *Condition) error {
+	return runGcloudIAMChange(projectBindingArgs(projectAccessGrant, projectID, principal, role, condition))
+}
+
+func (gcloudSecretManager) RemoveProjectIamBinding(projectID, principal, role string) error {
+	return runGcloudIAMChange(projectBindingArgs(projectAccessRevoke, projectID, principal, role, nil))
+}
+
+func (gcloudSecretManager) GetAncestors(projectID string) ([]ProjectAncestor, error) {
+	return fetchProjectAncestors(projectID)
+}
+
+func (gcloudSecretManager) GetAncestorIamPolicy(ancestor ProjectAncestor) (*IAMPolicy, error) {
+	return fetchAncestorIAMPolicy(ancestor)
+}
+
+func (gcloudSecretManager) DefaultProject() string {
+	return gcloudConfigProject()
+}
+
+// runGcloudWrite runs a gcloud command that changes a secret, passing stdin
+// (the secret value for --data-file -) and returning a gcloudOutputError on failure
+func runGcloudWrite(gcloudArgs []string, stdin string) error {
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
+	gcloudCmd.Stdin = strings.NewReader(stdin)
+	if output, err := gcloudCmd.CombinedOutput(); err != nil {
+		return &gcloudOutputError{Output: string(output)}
+	}
+	return nil
+}
@@ -0,0 +1,991 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// fakeSecretManager is an in-memory SecretManager. Each secret holds its
+// version values in order, so version N is values[N-1].
+type fakeSecretManager struct {
+	secrets     map[string][]string
+	labels      map[string]map[string]string
+	annotations map[string]map[string]string
+	policies    map[string]*IAMPolicy
+	// projectPolicies, ancestors, and ancestorPolicies are keyed by project
+	// or ancestor ID
+	projectPolicies  map[string]*IAMPolicy
+	ancestors        map[string][]ProjectAncestor
+	ancestorPolicies map[string]*IAMPolicy
+	// defaultProject stands in for the gcloud default project
+	defaultProject string
+}
+
+func newFakeSecretManager() *fakeSecretManager {
+	return &fakeSecretManager{
+		secrets:          make(map[string][]string),
+		labels:           make(map[string]map[string]string),
+		annotations:      make(map[string]map[string]string),
+		policies:         make(map[string]*IAMPolicy),
+		projectPolicies:  make(map[string]*IAMPolicy),
+		ancestors:        make(map[string][]ProjectAncestor),
+		ancestorPolicies: make(map[string]*IAMPolicy),
+		defaultProject:   "fake-project",
+	}
+}
+
+func (f *fakeSecretManager) resourceName(secretName string) string {
+	return "projects/fake-project/secrets/" + secretName
+}
+
+func (f *fakeSecretManager) versionIndex(secretName, version string) (int, error) {
+	values, ok := f.secrets[secretName]
+	if !ok {
+		return 0, &NotFoundError{Secret: secretName}
+	}
+	if version == "latest" {
+		return len(values) - 1, nil
+	}
+	n, err := strconv.Atoi(version)
+	if err != nil || n < 1 || n > len(values) {
+		return 0, &NotFoundError{Secret: secretName, Version: version}
+	}
+	return n - 1, nil
+}
+
+func (f *fakeSecretManager) versionInfo(secretName string, index int) SecretVersionInfo {
+	return SecretVersionInfo{
+		Name:       fmt.Sprintf("%s/versions/%d", f.resourceName(secretName), index+1),
+		State:      "ENABLED",
+		CreateTime: time.Date(2025, 1, index+1, 0, 0, 0, 0, time.UTC),
+		Etag:       fmt.Sprintf("\"etag-%d\"", index+1),
+	}
+}
+
+func (f *fakeSecretManager) GetValue(secretName, version, project string) (string, error) {
+	value, err := f.GetRawValue(secretName, version, project)
+	return strings.TrimSpace(value), err
+}
+
+func (f *fakeSecretManager) GetRawValue(secretName, version, project string) (string, error) {
+	index, err := f.versionIndex(secretName, version)
+	if err != nil {
+		return "", err
+	}
+	return f.secrets[secretName][index], nil
+}
+
+func (f *fakeSecretManager) List(project, filter string, limit int) ([]SecretInfo, error) {
+	var secrets []SecretInfo
+	for name := range f.secrets {
+		secrets = append(secrets, SecretInfo{
+			Name:       f.resourceName(name),
+			CreateTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Labels:     f.labels[name],
+		})
+	}
+	sort.Slice(secrets, func(i, j int) bool { return secrets[i].Name < secrets[j].Name })
+	if limit > 0 && len(secrets) > limit {
+		secrets = secrets[:limit]
+	}
+	return secrets, nil
+}
+
+func (f *fakeSecretManager) ListNames(project, prefix string) (map[string]bool, error) {
+	names := make(map[string]bool)
+	for name := range f.secrets {
+		if strings.HasPrefix(name, prefix) {
+			names[name] = true
+		}
+	}
+	return names, nil
+}
+
+func (f *fakeSecretManager) Describe(secretName, project string) (SecretInfo, error) {
+	if _, ok := f.secrets[secretName]; !ok {
+		return SecretInfo{}, &NotFoundError{Secret: secretName}
+	}
+	return SecretInfo{Name: f.resourceName(secretName), Labels: f.labels[secretName]}, nil
+}
+
+func (f *fakeSecretManager) Exists(secretName, project string) (bool, error) {
+	_, ok := f.secrets[secretName]
+	return ok, nil
+}
+
+func (f *fakeSecretManager) GetVersion(secretName, version, project string) (*SecretVersionInfo, error) {
+	index, err := f.versionIndex(secretName, version)
+	if err != nil {
+		return nil, err
+	}
+	info := f.versionInfo(secretName, index)
+	return &info, nil
+}
+
+func (f *fakeSecretManager) ListVersions(secretName, project string) ([]SecretVersionInfo, error) {
+	values, ok := f.secrets[secretName]
+	if !ok {
+		return nil, &NotFoundError{Secret: secretName}
+	}
+	versions := make([]SecretVersionInfo, 0, len(values))
+	for i := range values {
+		versions = append(versions, f.versionInfo(secretName, i))
+	}
+	return versions, nil
+}
+
+func (f *fakeSecretManager) Create(secretName, project, value string, options SecretCreateOptions) error {
+	if _, ok := f.secrets[secretName]; ok {
+		return &gcloudOutputError{Output: "ERROR: (gcloud.secrets.create) Resource in projects [fake-project] is the subject of a conflict: Secret [" + secretName + "] already exists."}
+	}
+	f.secrets[secretName] = []string{value}
+	f.labels[secretName] = make(map[string]string)
+	for _, label := range options.Labels {
+		key, val, _ := strings.Cut(label, "=")
+		f.labels[secretName][key] = val
+	}
+	f.annotations[secretName] = make(map[string]string)
+	for _, annotation := range options.Annotations {
+		key, val, _ := strings.Cut(annotation, "=")
+		f.annotations[secretName][key] = val
+	}
+	return nil
+}
+
+func (f *fakeSecretManager) AddVersion(secretName, project, value string) error {
+	if _, ok := f.secrets[secretName]; !ok {
+		return &gcloudOutputError{Output: "ERROR: (gcloud.secrets.versions.add) NOT_FOUND: Secret [" + secretName + "] not found."}
+	}
+	f.secrets[secretName] = append(f.secrets[secretName], value)
+	return nil
+}
+
+// UpdateLabels applies the --clear-labels, --update-labels, and
+// --remove-labels flags in the order gcloud does
+func (f *fakeSecretManager) UpdateLabels(secretName, project string, labelArgs []string) error {
+	if _, ok := f.secrets[secretName]; !ok {
+		return &gcloudOutputError{Output: "ERROR: (gcloud.secrets.update) NOT_FOUND: Secret [" + secretName + "] not found."}
+	}
+	labels := f.labels[secretName]
+	if labels == nil {
+		labels = make(map[string]string)
+		f.labels[secretName] = labels
+	}
+	if slices.Contains(labelArgs, "--clear-labels") {
+		clear(labels)
+	}
+	for i := 0; i+1 < len(labelArgs); i++ {
+		switch labelArgs[i] {
+		case "--update-labels":
+			for _, label := range strings.Split(labelArgs[i+1], ",") {
+				key, val, _ := strings.Cut(label, "=")
+				labels[key] = val
+			}
+		case "--remove-labels":
+			for _, key := range strings.Split(labelArgs[i+1], ",") {
+				delete(labels, key)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *fakeSecretManager) Delete(secretName, project string) error {
+	if _, ok := f.secrets[secretName]; !ok {
+		return &gcloudOutputError{Output: "ERROR: (gcloud.secrets.delete) NOT_FOUND: Secret [" + secretName + "] not found."}
+	}
+	delete(f.secrets, secretName)
+	delete(f.labels, secretName)
+	return nil
+}
+
+func (f *fakeSecretManager) GetIamPolicy(secretName, project string) (*IAMPolicy, error) {
+	if _, ok := f.secrets[secretName]; !ok {
+		return nil, &NotFoundError{Secret: secretName}
+	}
+	if policy, ok := f.policies[secretName]; ok {
+		return policy, nil
+	}
+	return &IAMPolicy{}, nil
+}
+
+func (f *fakeSecretManager) AddIamBinding(secretName, project, principal, role string, condition *Condition) error {
+	policy, err := f.GetIamPolicy(secretName, project)
+	if err != nil {
+		return err
+	}
+	f.policies[secretName] = addFakeBinding(policy, principal, role, condition)
+	return nil
+}
+
+func (f *fakeSecretManager) RemoveIamBinding(secretName, project, principal, role string) error {
+	policy, err := f.GetIamPolicy(secretName, project)
+	if err != nil {
+		return err
+	}
+	return removeFakeBinding(policy, principal, role)
+}
+
+func (f *fakeSecretManager) GetProjectIamPolicy(projectID string) (*IAMPolicy, error) {
+	if policy, ok := f.projectPolicies[projectID]; ok {
+		return policy, nil
+	}
+	return &IAMPolicy{}, nil
+}
+
+func (f *fakeSecretManager) AddProjectIamBinding(projectID, principal, role string, condition *Condition) error {
+	policy, _ := f.GetProjectIamPolicy(projectID)
+	f.projectPolicies[projectID] = addFakeBinding(policy, principal, role, condition)
+	return nil
+}
+
+func (f *fakeSecretManager) RemoveProjectIamBinding(projectID, principal, role string) error {
+	policy, _ := f.GetProjectIamPolicy(projectID)
+	return removeFakeBinding(policy, principal, role)
+}
+
+func (f *fakeSecretManager) GetAncestors(projectID string) ([]ProjectAncestor, error) {
+	return f.ancestors[projectID], nil
+}
+
+func (f *fakeSecretManager) GetAncestorIamPolicy(ancestor ProjectAncestor) (*IAMPolicy, error) {
+	if policy, ok := f.ancestorPolicies[ancestor.ID]; ok {
+		return policy, nil
+	}
+	return &IAMPolicy{}, nil
+}
+
+// addFakeBinding adds principal to the binding of role with the same
+// condition, creating the binding when there is none
+func addFakeBinding(policy *IAMPolicy, principal, role string, condition *Condition) *IAMPolicy {
+	for i, binding := range policy.Bindings {
+		sameCondition := binding.Condition == nil && condition == nil ||
+			binding.Condition != nil && condition != nil && *binding.Condition == *condition
+		if binding.Role == role && sameCondition {
+			if !slices.Contains(binding.Members, principal) {
+				policy.Bindings[i].Members = append(binding.Members, principal)
+			}
+			return policy
+		}
+	}
+	policy.Bindings = append(policy.Bindings, Binding{Role: role, Members: []string{principal}, Condition: condition})
+	return policy
+}
+
+// removeFakeBinding removes principal from the unconditional binding of
+// role, failing like gcloud when it is not a member
+func removeFakeBinding(policy *IAMPolicy, principal, role string) error {
+	for i, binding := range policy.Bindings {
+		if binding.Role != role || binding.Condition != nil {
+			continue
+		}
+		if index := slices.Index(binding.Members, principal); index >= 0 {
+			policy.Bindings[i].Members = slices.Delete(binding.Members, index, index+1)
+			return nil
+		}
+	}
+	return fmt.Errorf("NOT_FOUND: Policy binding with the specified principal, role, and condition not found")
+}
+
+func (f *fakeSecretManager) DefaultProject() string {
+	return f.defaultProject
+}
+
+// useFakeSecretManager replaces the backend and configuration for one test
+func useFakeSecretManager(t *testing.T, config *Config) *fakeSecretManager {
+	t.Helper()
+	originalManager, originalConfig := secretManager, globalConfig
+	t.Cleanup(func() { secretManager, globalConfig = originalManager, originalConfig })
+
+	fake := newFakeSecretManager()
+	secretManager = fake
+	globalConfig = config
+	return fake
+}
+
+// resetCommandFlags restores every flag of cmd and its subcommands to its
+// default, since cobra commands are package-level and keep flag values
+func resetCommandFlags(cmd *cobra.Command) {
+	reset := func(flag *pflag.Flag) {
+		if !flag.Changed {
+			return
+		}
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil) //nolint:errcheck
+		} else {
+			flag.Value.Set(flag.DefValue) //nolint:errcheck
+		}
+		flag.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, sub := range cmd.Commands() {
+		resetCommandFlags(sub)
+	}
+}
+
+// executeCommand runs gsecutil with args and returns what it printed to stdout
+func executeCommand(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	defer resetCommandFlags(rootCmd)
+
+	var err error
+	rootCmd.SetArgs(args)
+	output := captureStdout(func() { err = rootCmd.Execute() })
+	return output, err
+}
+
+// TestGetWithSecretManager tests the get command against the fake backend
+func TestGetWithSecretManager(t *testing.T) {
+	fake := useFakeSecretManager(t, &Config{Prefix: "team-"})
+	fake.secrets["team-db"] = []string{"first", "second"}
+	fake.secrets["team-api"] = []string{"it's"}
+	fake.secrets["team-config"] = []string{`{"db": {"password": "s3cret"}}`}
+
+	tests := []struct {
+		name        string
+		args        []string
+		expected    string
+		notFound    bool
+		expectError bool
+	}{
+		{name: "Latest version with prefix added", args: []string{"get", "db"}, expected: "second\n"},
+		{name: "Specific version", args: []string{"get", "db", "--version", "1"}, expected: "first\n"},
+		{name: "Full name", args: []string{"get", "team-db"}, expected: "second\n"},
+		{name: "Missing secret", args: []string{"get", "missing"}, notFound: true},
+		{name: "Multiple secrets", args: []string{"get", "db", "api"}, expected: "db=second\napi=it's\n"},
+		{name: "Multiple secrets as env", args: []string{"get", "db", "api", "--format", "env"}, expected: "db='second'\napi='it'\\''s'\n"},
+		{name: "Env format with --output", args: []string{"get", "db", "api", "--format", "env", "--output", ".env"}, expectError: true},
+		{name: "Combine with --upper", args: []string{"get", "--combine", "db=db", "--upper"}, expectError: true},
+		{name: "Combine with --env-prefix", args: []string{"get", "--combine", "db=db", "--env-prefix", "APP_"}, expectError: true},
+		{name: "Combine with --replace-dash", args: []string{"get", "--combine", "db=db", "--replace-dash"}, expectError: true},
+		{name: "Single secret with --upper", args: []string{"get", "db", "--upper"}, expectError: true},
+		{name: "Multiple secrets with one missing", args: []string{"get", "db", "missing"}, notFound: true},
+		{name: "Multiple secrets with clipboard", args: []string{"get", "db", "api", "--clipboard"}, expectError: true},
+		{name: "Multiple secrets with version", args: []string{"get", "db", "api", "--version", "1"}, expectError: true},
+		{name: "JSON field", args: []string{"get", "config", "--json-field", "db.password"}, expected: "s3cret\n"},
+		{name: "JSON field with base64", args: []string{"get", "config", "--json-field", "db.password", "--decode-base64"}, expectError: true},
+		{name: "JSON field of a missing secret", args: []string{"get", "missing", "--json-field", "db.password"}, notFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := executeCommand(t, tt.args...)
+			if tt.notFound {
+				var notFound *NotFoundError
+				if !errors.As(err, &notFound) {
+					t.Fatalf("Expected NotFoundError, got %v", err)
+				}
+				return
+			}
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if output != tt.expected {
+				t.Errorf("Output = %q, expected %q", output, tt.expected)
+			}
+		})
+	}
+}
+
+// TestGetOutputFileWithSecretManager tests writing a value with --output-file
+func TestGetOutputFileWithSecretManager(t *testing.T) {
+	fake := useFakeSecretManager(t, &Config{})
+	fake.secrets["tls-key"] = []string{"old", "-----BEGIN KEY-----"}
+
+	path := filepath.Join(t.TempDir(), "tls.key")
+	output, err := executeCommand(t, "get", "tls-key", "--output-file", path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(output, "BEGIN KEY") {
+		t.Errorf("The value was printed: %q", output)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if string(data) != "-----BEGIN KEY-----" {
+		t.Errorf("Output file content = %q", data)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat output file: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Output file mode = %v, expected 0600", info.Mode().Perm())
+	}
+
+	if _, err := executeCommand(t, "get", "tls-key", "--version", "1", "--output-file", path); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("Expected an error for an existing file without --force, got %v", err)
+	}
+	if _, err := executeCommand(t, "get", "tls-key", "--version", "1", "--output-file", path, "--force"); err != nil {
+		t.Fatalf("Unexpected error with --force: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old" {
+		t.Errorf("After --force, output file content = %q", data)
+	}
+
+	// The payload is written byte for byte, after any base64 decoding
+	fake.secrets["cert"] = []string{"line 1\nline 2\n", "bGluZSAxCmxpbmUgMgo=\n"}
+	for _, tt := range []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"get", "cert", "--version", "1"}, "line 1\nline 2\n"},
+		{[]string{"get", "cert", "--version", "2", "--decode-base64"}, "line 1\nline 2\n"},
+		{[]string{"get", "cert", "--version", "2"}, "bGluZSAxCmxpbmUgMgo=\n"},
+	} {
+		args := append(tt.args, "--output-file", path, "--force")
+		if _, err := executeCommand(t, args...); err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		if data, _ := os.ReadFile(path); string(data) != tt.expected {
+			t.Errorf("%v: output file content = %q, expected %q", args, data, tt.expected)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"get", "tls-key", "--output-file", path, "--force", "--clipboard"},
+		{"get", "tls-key", "--output-file", path, "--force", "--metadata-only"},
+		{"get", "tls-key", "--force"},
+	} {
+		if _, err := executeCommand(t, args...); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
+// TestGetShowMetadataJSONWithSecretManager tests the structured --show-metadata output
+func TestGetShowMetadataJSONWithSecretManager(t *testing.T) {
+	fake := useFakeSecretManager(t, &Config{})
+	fake.secrets["api-key"] = []string{"v1", "v2", "v3"}
+
+	output, err := executeCommand(t, "get", "api-key", "--show-metadata", "--format", "json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var value VersionValue
+	if err := json.Unmarshal([]byte(output), &value); err != nil {
+		t.Fatalf("Failed to parse output: %v\n%s", err, output)
+	}
+	if value.Version != "3" || value.Value != "v3" || value.Secret != "api-key" {
+		t.Errorf("Unexpected output: %+v", value)
+	}
+}
+
+// TestVersionsListWithSecretManager tests 'versions list' text and JSON output
+func TestVersionsListWithSecretManager(t *testing.T) {
+	fake := useFakeSecretManager(t, &Config{Prefix: "team-"})
+	fake.secrets["team-db"] = []string{"v1", "v2"}
+
+	output, err := executeCommand(t, "versions", "list", "db", "--format", "json")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var versions []SecretVersionInfo
+	if err := json.Unmarshal([]byte(output), &versions); err != nil {
+		t.Fatalf("Failed to parse output: %v\n%s", err, output)
+	}
+	if len(versions) != 2 || extractVersionNumber(versions[0].Name) != "2" || versions[0].State != "ENABLED" {
+		t.Errorf("Expected versions newest first, got %+v", versions)
+	}
+
+	output, err = executeCommand(t, "versions", "list", "db")
+	if err != nil || !strings.Contains(output, "Version: 2\n  State: ENABLED") {
+		t.Errorf("versions list = %v, %q", err, output)
+	}
+
+	var notFound *NotFoundError
+	if _, err := executeCommand(t, "versions", "list", "missing"); !errors.As(err, &notFound) {
+		t.Errorf("Expected NotFoundError for a missing secret, got %v", err)
+	}
+	if _, err := executeCommand(t, "versions", "list", "db", "--format", "csv"); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
+
+// TestExportEnvWithSecretManager tests export-env keys, quoting, and the
+// prefix handling against the fake backend
+func TestExportEnvWithSecretManager(t *testing.T) {
+	fake := useFakeSecretManager(t, &Config{Prefix: "team-"})
+	fake.secrets["team-db-password"] = []string{"old", "it's new"}
+	fake.secrets["team-team-api"] = []string{"token"}
+	fake.secrets["other-secret"] = []string{"not exported"}
+
+	output, err := executeCommand(t, "export-env", "--uppercase")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := "DB_PASSWORD='it'\\''s new'\nTEAM_API='token'\n"; output != expected {
+		t.Errorf("export-env --uppercase = %q, expected %q", output, expected)
+	}
+
+	path := filepath.Join(t.TempDir(), ".env")
+	if _, err := executeCommand(t, "export-env", path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "db_password='it'\\''s new'\n") {
+		t.Errorf("Unexpected file content: %q", data)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat output file: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Output file mode = %v, expected 0600", info.Mode().Perm())
+	}
+
+	fake.secrets["team-2fa-seed"] = []string{"seed"}
+	if _, err := executeCommand(t, "export-env"); err == nil {
+		t.Error("Expected an error for a secret that is not a valid variable name")
+	}
+}
+
+// TestSecretLifecycleWithSecretManager tests create, update, and delete
+// against the fake backend
+func TestSecretLifecycleWithSecretManager(t *testing.T) {
+	fake := useFakeSecretManager(t, &Config{
+		Prefix:   "team-",
+		Defaults: DefaultConfig{WarnOnDataFlag: new(bool)},
+	})
+
+	if _, err := executeCommand(t, "create", "db", "--data", "initial", "--labels", "env=dev"); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	if got := fake.secrets["team-db"]; len(got) != 1 || got[0] != "initial" {
+		t.Fatalf("After create, versions = %v", got)
+	}
+	if fake.labels["team-db"]["env"] != "dev" {
+		t.Errorf("After create, labels = %v", fake.labels["team-db"])
+	}
+
+	_, err := executeCommand(t, "create", "db", "--data", "again")
+	var exists *SecretExistsError
+	if !errors.As(err, &exists) {
+		t.Errorf("Expected SecretExistsError for a second create, got %v", err)
+	}
+
+	if _, err := executeCommand(t, "update", "db", "--data", "rotated"); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	if got := fake.secrets["team-db"]; len(got) != 2 || got[1] != "rotated" {
+		t.Fatalf("After update, versions = %v", got)
+	}
+
+	output, err := executeCommand(t, "update", "db", "--set-labels", "env=dev")
+	if err != nil {
+		t.Fatalf("update --set-labels failed: %v", err)
+	}
+	if !strings.Contains(output, "already match") || len(fake.secrets["team-db"]) != 2 {
+		t.Errorf("Expected matching labels to change nothing, got versions %v and output:\n%s", fake.secrets["team-db"], output)
+	}
+
+	_, err = executeCommand(t, "update", "missing", "--data", "value")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("Expected NotFoundError when updating a missing secret, got %v", err)
+	}
+
+	if _, err := executeCommand(t, "delete", "db", "--force"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, ok := fake.secrets["team-db"]; ok {
+		t.Error("Expected secret to be deleted")
+	}
+}
+
+// TestCreateUpdateTitleWithSecretManager tests that create and update save
+// titles, attributes, and configuration entries with --title, --attr, and
+// --update-config
+func TestCreateUpdateTitleWithSecretManager(t *testing.T) {
+	originalPath := configFilePath
+	t.Cleanup(func() { configFilePath = originalPath })
+	configFilePath = filepath.Join(t.TempDir(), "gsecutil.conf")
+	configPath := configFilePath
+
+	fake := useFakeSecretManager(t, &Config{
+		Prefix:   "team-",
+		Defaults: DefaultConfig{WarnOnDataFlag: new(bool)},
+	})
+	savedTitles := func() map[string]string {
+		t.Helper()
+		config, err := LoadConfig(configPath)
+		if err != nil {
+			t.Fatalf("Failed to load saved config: %v", err)
+		}
+		titles := make(map[string]string)
+		for _, cred := range config.Credentials {
+			titles[cred.Name] = cred.Title
+		}
+		return titles
+	}
+
+	if _, err := executeCommand(t, "create", "team-db", "--data", "x", "--title", "Database"); err != nil {
+		t.Fatalf("create --title failed: %v", err)
+	}
+	if titles := savedTitles(); titles["db"] != "Database" {
+		t.Errorf("Expected the title under the bare name, got %v", titles)
+	}
+
+	if _, err := executeCommand(t, "update", "db", "--title", "Primary database"); err != nil {
+		t.Fatalf("update --title failed: %v", err)
+	}
+	if titles := savedTitles(); titles["db"] != "Primary database" {
+		t.Errorf("Expected the title to be updated, got %v", titles)
+	}
+	if got := fake.secrets["team-db"]; len(got) != 1 {
+		t.Errorf("Expected a title-only update to add no version, got %v", got)
+	}
+
+	// Without a terminal there is no prompt, so the entry has no title
+	if _, err := executeCommand(t, "create", "api", "--data", "y", "--update-config"); err != nil {
+		t.Fatalf("create --update-config failed: %v", err)
+	}
+	if titles := savedTitles(); len(titles) != 2 || titles["api"] != "" {
+		t.Errorf("Expected an entry for api without a title, got %v", titles)
+	}
+
+	if _, err := executeCommand(t, "update", "db", "--update-config", "--attr", "Owner=backend", "--attr", "environment=prod"); err != nil {
+		t.Fatalf("update --attr failed: %v", err)
+	}
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load saved config: %v", err)
+	}
+	for _, cred := range config.Credentials {
+		if cred.Name == "db" && (cred.Title != "Primary database" || cred.Attributes["owner"] != "backend" || cred.Attributes["environment"] != "prod") {
+			t.Errorf("Expected the attributes next to the title, got %+v", cred)
+		}
+	}
+
+	if _, err := executeCommand(t, "create", "cache", "--data", "z", "--attr", "owner=ops"); err == nil || !strings.Contains(err.Error(), "--attr requires --update-config") {
+		t.Errorf("Expected --attr without --update-config to fail, got %v", err)
+	}
+	if _, ok := fake.secrets["team-cache"]; ok {
+		t.Error("Expected the secret not to be created when --attr is invalid")
+	}
+
+	_, err = executeCommand(t, "update", "missing", "--title", "Nope")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("Expected NotFoundError when titling a missing secret, got %v", err)
+	}
+}
+
+// TestDeleteRemoveConfigWithSecretManager tests that delete --remove-config
+// removes the secret's configuration entry and leaves the others
+func TestDeleteRemoveConfigWithSecretManager(t *testing.T) {
+	originalPath := configFilePath
+	t.Cleanup(func() { configFilePath = originalPath })
+	configFilePath = filepath.Join(t.TempDir(), "gsecutil.conf")
+	if err := saveConfigTo(&Config{
+		Prefix:      "team-",
+		Credentials: []CredentialInfo{{Name: "db", Title: "Database"}, {Name: "api", Title: "API key"}},
+	}, configFilePath); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	fake := useFakeSecretManager(t, &Config{Prefix: "team-"})
+	fake.secrets["team-db"] = []string{"x"}
+	fake.secrets["team-cache"] = []string{"y"}
+
+	output, err := executeCommand(t, "delete", "team-db", "--force", "--remove-config")
+	if err != nil {
+		t.Fatalf("delete --remove-config failed: %v", err)
+	}
+	if !strings.Contains(output, "Configuration entry 'db' removed") {
+		t.Errorf("Expected the removal to be reported, got:\n%s", output)
+	}
+	config, err := LoadConfig(configFilePath)
+	if err != nil {
+		t.Fatalf("Failed to load saved config: %v", err)
+	}
+	if len(config.Credentials) != 1 || config.Credentials[0].Name != "api" {
+		t.Errorf("Expected only the api entry to remain, got %+v", config.Credentials)
+	}
+
+	output, err = executeCommand(t, "delete", "cache", "--force", "--remove-config")
+	if err != nil {
+		t.Fatalf("delete --remove-config failed: %v", err)
+	}
+	if !strings.Contains(output, "No configuration entry for 'cache'") {
+		t.Errorf("Expected a missing entry to be reported, got:\n%s", output)
+	}
+}
+
+// TestListWithSecretManager tests that list filters the fake backend's
+// secrets by prefix and attributes
+func TestListWithSecretManager(t *testing.T) {
+	fake := useFakeSecretManager(t, &Config{
+		Prefix: "team-",
+		Credentials: []CredentialInfo{
+			{Name: "db", Title: "Database", Attributes: map[string]interface{}{"environment": "prod"}},
+			{Name: "api", Title: "API key", Attributes: map[string]interface{}{"environment": "dev"}},
+		},
+	})
+	fake.secrets["team-db"] = []string{"x"}
+	fake.secrets["team-api"] = []string{"y"}
+	fake.secrets["other-secret"] = []string{"z"}
+
+	output, err := executeCommand(t, "list")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	for _, expected := range []string{"Database", "API key"} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "other-secret") || strings.Contains(output, "team-") {
+		t.Errorf("Expected bare names and secrets without the prefix hidden:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "list", "--attr-filter", "environment=prod")
+	if err != nil {
+		t.Fatalf("list --attr-filter failed: %v", err)
+	}
+	if !strings.Contains(output, "Database") || strings.Contains(output, "API key") {
+		t.Errorf("Expected only db with environment=prod:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "list", "--format", "markdown", "--show", "title")
+	if err != nil {
+		t.Fatalf("list --format markdown failed: %v", err)
+	}
+	if !strings.HasPrefix(output, "| NAME | TITLE | CREATED (UTC) |\n| --- | --- | --- |\n") || !strings.Contains(output, "| db | Database |") {
+		t.Errorf("Unexpected Markdown table:\n%s", output)
+	}
+
+	output, err = executeCommand(t, "list", "--fields", "environment,name", "--format", "csv")
+	if err != nil {
+		t.Fatalf("list --fields failed: %v", err)
+	}
+	if output != "environment,name\ndev,api\nprod,db\n" {
+		t.Errorf("Unexpected --fields CSV:\n%s", output)
+	}
+
+	if _, err := executeCommand(t, "list", "--fields", "name", "--show", "title"); err == nil {
+		t.Error("Expected --fields to be rejected with --show")
+	}
+}
+
+// TestResolveProject tests the project resolution order and the error shown
+// when no project is configured anywhere
+func TestResolveProject(t *testing.T) {
+	tests := []struct {
+		name           string
+		cliProject     string
+		config         Config
+		defaultProject string
+		expected       string
+		expectError    bool
+	}{
+		{
+			name:           "Config project wins over the gcloud default",
+			config:         Config{Project: "config-project"},
+			defaultProject: "gcloud-project",
+			expected:       "config-project",
+		},
+		{
+			name:           "gcloud default used when nothing else is set",
+			defaultProject: "gcloud-project",
+			expected:       "gcloud-project",
+		},
+		{
+			name:        "Error when no project is configured",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("GSECUTIL_PROJECT", "")
+			config := tt.config
+			fake := useFakeSecretManager(t, &config)
+			fake.defaultProject = tt.defaultProject
+
+			project, err := resolveProject(tt.cliProject)
+			if tt.expectError {
+				if err == nil || !strings.Contains(err.Error(), "no project configured") {
+					t.Errorf("Expected a missing project error, got %q, %v", project, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if project != tt.expected {
+				t.Errorf("resolveProject(%q) = %q, expected %q", tt.cliProject, project, tt.expected)
+			}
+		})
+	}
+
+	t.Run("Read commands fail before calling the backend", func(t *testing.T) {
+		t.Setenv("GSECUTIL_PROJECT", "")
+		fake := useFakeSecretManager(t, &Config{})
+		fake.defaultProject = ""
+		fake.secrets["api-key"] = []string{"v1"}
+
+		for _, args := range [][]string{{"get", "api-key"}, {"list"}, {"describe", "api-key"}} {
+			if _, err := executeCommand(t, args...); err == nil || !strings.Contains(err.Error(), "no project configured") {
+				t.Errorf("%v: expected a missing project error, got %v", args, err)
+			}
+		}
+	})
+}
+
+// TestImportWithSecretManager tests that import creates and updates secrets
+// through the backend
+func TestImportWithSecretManager(t *testing.T) {
+	fake := useFakeSecretManager(t, &Config{})
+	fake.secrets["db"] = []string{"old"}
+
+	csvPath := filepath.Join(t.TempDir(), "secrets.csv")
+	if err := os.WriteFile(csvPath, []byte("name,value,label:env\nnew,first,dev\ndb,rotated,\n"), 0600); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+	if _, err := executeCommand(t, "import", csvPath, "--upsert"); err != nil {
+		t.Fatalf("import --upsert failed: %v", err)
+	}
+	if !slices.Equal(fake.secrets["new"], []string{"first"}) || fake.labels["new"]["env"] != "dev" {
+		t.Errorf("Expected 'new' to be created with its label, got %v %v", fake.secrets["new"], fake.labels["new"])
+	}
+	if !slices.Equal(fake.secrets["db"], []string{"old", "rotated"}) {
+		t.Errorf("Expected a new version of 'db', got %v", fake.secrets["db"])
+	}
+}
+
+// TestApplyWithSecretManager tests that apply creates secrets and changes
+// access through the backend
+func TestApplyWithSecretManager(t *testing.T) {
+	fake := useFakeSecretManager(t, &Config{})
+	fake.secrets["db"] = []string{"old"}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	originalStdin := os.Stdin
+	os.Stdin = reader
+	defer func() { os.Stdin = originalStdin }()
+	go func() {
+		defer writer.Close()
+		fmt.Fprint(writer, `[
+			{"action": "create", "name": "api", "value": "token"},
+			{"action": "update", "name": "db", "value": "rotated"},
+			{"action": "grant", "name": "api", "principal": "user:alice@example.com"}
+		]`)
+	}()
+
+	if _, err := executeCommand(t, "apply", "--stdin-json"); err != nil {
+		t.Fatalf("apply failed: %v", err)
+	}
+	if !slices.Equal(fake.secrets["api"], []string{"token"}) || !slices.Equal(fake.secrets["db"], []string{"old", "rotated"}) {
+		t.Errorf("Unexpected secrets after apply: %v", fake.secrets)
+	}
+	policy := fake.policies["api"]
+	if policy == nil || len(policy.Bindings) != 1 || policy.Bindings[0].Role != defaultAccessRole || !slices.Equal(policy.Bindings[0].Members, []string{"user:alice@example.com"}) {
+		t.Errorf("Expected alice to be granted access to 'api', got %+v", policy)
+	}
+}
+
+// TestListLabelsWithSecretManager tests list --show-labels without config
+// entries, which reads the labels from the backend
+func TestListLabelsWithSecretManager(t *testing.T) {
+	fake := useFakeSecretManager(t, &Config{Prefix: "team-"})
+	fake.secrets["team-db"] = []string{"v1"}
+	fake.labels["team-db"] = map[string]string{"env": "prod"}
+	fake.secrets["other"] = []string{"v1"}
+
+	output, err := executeCommand(t, "list", "--show-labels")
+	if err != nil {
+		t.Fatalf("list --show-labels failed: %v", err)
+	}
+	if !strings.Contains(output, "db") || !strings.Contains(output, "env=prod") || strings.Contains(output, "other") {
+		t.Errorf("Unexpected list output: %q", output)
+	}
+}
+
+// TestListHealthWithSecretManager tests that --health honors --attr-filter
+// and rejects the --show flags it cannot render
+func TestListHealthWithSecretManager(t *testing.T) {
+	fake := useFakeSecretManager(t, &Config{
+		Prefix: "team-",
+		Credentials: []CredentialInfo{
+			{Name: "db", Title: "Database", Attributes: map[string]interface{}{"environment": "prod"}},
+			{Name: "api", Title: "API key", Attributes: map[string]interface{}{"environment": "dev"}},
+		},
+	})
+	fake.secrets["team-db"] = []string{"x"}
+	fake.secrets["team-api"] = []string{"y"}
+
+	output, err := executeCommand(t, "list", "--health", "--attr-filter", "environment=prod", "--format", "json")
+	if err != nil {
+		t.Fatalf("list --health --attr-filter failed: %v", err)
+	}
+	if !strings.Contains(output, `"name": "db"`) || strings.Contains(output, `"name": "api"`) {
+		t.Errorf("Expected only db with environment=prod:\n%s", output)
+	}
+
+	for _, flag := range [][]string{{"--show-labels"}, {"--show", "title"}, {"--show-updated"}, {"--show-size"}, {"--principal", "user:alice@example.com"}} {
+		args := append([]string{"list", "--only-unhealthy"}, flag...)
+		if _, err := executeCommand(t, args...); err == nil || !strings.Contains(err.Error(), "--health cannot be combined with "+flag[0]) {
+			t.Errorf("Expected %v to be rejected, got %v", flag, err)
+		}
+	}
+}
+
+// TestAccessWithSecretManager tests grant, list, and revoke at the secret and
+// project level through the backend
+func TestAccessWithSecretManager(t *testing.T) {
+	fake := useFakeSecretManager(t, &Config{})
+	fake.secrets["db"] = []string{"v1"}
+
+	if _, err := executeCommand(t, "access", "grant", "db", "--principal", "user:alice@example.com"); err != nil {
+		t.Fatalf("access grant failed: %v", err)
+	}
+	output, err := executeCommand(t, "access", "list", "db", "--format", "json")
+	if err != nil || !strings.Contains(output, "user:alice@example.com") {
+		t.Errorf("Expected alice in access list, got %v: %q", err, output)
+	}
+	if _, err := executeCommand(t, "access", "revoke", "db", "--principal", "user:alice@example.com"); err != nil {
+		t.Fatalf("access revoke failed: %v", err)
+	}
+	if members := fake.policies["db"].Bindings[0].Members; len(members) != 0 {
+		t.Errorf("Expected no members after revoke, got %v", members)
+	}
+	if _, err := executeCommand(t, "access", "revoke", "db", "--principal", "user:alice@example.com"); err == nil {
+		t.Error("Expected an error revoking a binding that does not exist")
+	}
+
+	if _, err := executeCommand(t, "access", "grant", "--project-level", "--principal", "group:sre@example.com", "--force"); err != nil {
+		t.Fatalf("project-level grant failed: %v", err)
+	}
+	policy := fake.projectPolicies["fake-project"]
+	if policy == nil || len(policy.Bindings) != 1 || !slices.Equal(policy.Bindings[0].Members, []string{"group:sre@example.com"}) {
+		t.Errorf("Expected the project-level binding, got %+v", policy)
+	}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// SecretTemplateData is the value templates given to 'describe --template'
+// and '--template-file' are executed with
+type SecretTemplateData struct {
+	Name       string                 // secret name as given by the user, without the prefix
+	Secret     SecretInfo             // gcloud secret metadata
+	Versions   []SecretVersionInfo    // all versions, newest first
+	Title      string                 // title from the configuration file, if any
+	Attributes map[string]interface{} // attributes from the configuration file, if any
+}
+
+// templateFuncs are the functions available to output templates
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"date":  func(layout string, t time.Time) string { return t.Format(layout) },
+	"json": func(value interface{}) (string, error) {
+		data, err := json.Marshal(value)
+		return string(data), err
+	},
+	"version": extractVersionNumber,
+}
+
+// addTemplateFlags registers the template output flags on cmd
+func addTemplateFlags(cmd *cobra.Command) {
+	cmd.Flags().String("template", "", "Render the output with this Go text/template")
+	cmd.Flags().String("template-file", "", "Render the output with the Go text/template in this file")
+	cmd.Flags().String("template-name", "", "Template defined in --template or --template-file to execute (default: the top-level template)")
+}
+
+// templateFromFlags parses the template selected by --template or
+// --template-file, or returns nil when neither is given. Parse errors and an
+// unknown --template-name are reported before anything is fetched.
+func templateFromFlags(cmd *cobra.Command) (*template.Template, error) {
+	inline, _ := cmd.Flags().GetString("template")
+	path, _ := cmd.Flags().GetString("template-file")
+	name, _ := cmd.Flags().GetString("template-name")
+
+	if cmd.Flags().Changed("template") && cmd.Flags().Changed("template-file") {
+		return nil, fmt.Errorf("--template cannot be combined with --template-file")
+	}
+	switch {
+	case cmd.Flags().Changed("template-file"):
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template file: %w", err)
+		}
+		return parseOutputTemplate(filepath.Base(path), string(data), name)
+	case cmd.Flags().Changed("template"):
+		return parseOutputTemplate("template", inline, name)
+	case cmd.Flags().Changed("template-name"):
+		return nil, fmt.Errorf("--template-name requires --template or --template-file")
+	}
+	return nil, nil
+}
+
+// parseOutputTemplate parses text as a template called rootName and returns
+// the template to execute: the one called name, or the top-level template
+// when name is empty. Templates declared with {{define}} can be selected by
+// name; a file holding only definitions needs --template-name.
+func parseOutputTemplate(rootName, text, name string) (*template.Template, error) {
+	root, err := template.New(rootName).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	if name == "" {
+		if !onlyDefinitions(root) {
+			return root, nil
+		}
+		return nil, fmt.Errorf("template %s has no top-level content; select one with --template-name (defined: %s)", rootName, strings.Join(definedTemplateNames(root), ", "))
+	}
+
+	selected := root.Lookup(name)
+	if selected == nil || selected.Tree == nil {
+		return nil, fmt.Errorf("template '%s' is not defined in %s (defined: %s)", name, rootName, strings.Join(definedTemplateNames(root), ", "))
+	}
+	return selected, nil
+}
+
+// onlyDefinitions reports whether the top-level template of root is nothing
+// but whitespace around {{define}} blocks
+func onlyDefinitions(root *template.Template) bool {
+	if root.Tree == nil {
+		return true
+	}
+	for _, node := range root.Tree.Root.Nodes {
+		if strings.TrimSpace(node.String()) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// definedTemplateNames returns the names of the {{define}} blocks of root, sorted
+func definedTemplateNames(root *template.Template) []string {
+	var names []string
+	for _, t := range root.Templates() {
+		if t.Name() != root.Name() {
+			names = append(names, t.Name())
+		}
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return []string{"none"}
+	}
+	return names
+}
+
+// newSecretTemplateData assembles the template data of one secret. Versions
+// are sorted newest first.
+func newSecretTemplateData(userInputName string, secret SecretInfo, versions []SecretVersionInfo) SecretTemplateData {
+	sortSecretVersions(versions, versionSortNewest)
+	data := SecretTemplateData{Name: userInputName, Secret: secret, Versions: versions, Attributes: map[string]interface{}{}}
+	if cred := GetCredentialInfo(userInputName); cred != nil {
+		data.Title = cred.Title
+		for key, value := range cred.Attributes {
+			data.Attributes[key] = value
+		}
+	}
+	return data
+}
+
+// renderTemplate executes tmpl with data. Output is buffered so a failing
+// template prints nothing.
+func renderTemplate(tmpl *template.Template, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// describeSecretWithTemplate fetches a secret and its versions and prints
+// them rendered with tmpl
+func describeSecretWithTemplate(secretName, userInputName, project string, tmpl *template.Template) error {
+	output, err := runGcloudDescribe(secretName, project, "json")
+	if err != nil {
+		return err
+	}
+	var secret SecretInfo
+	if err := json.Unmarshal(output, &secret); err != nil {
+		return fmt.Errorf("failed to parse secret metadata: %w", err)
+	}
+	versions, err := fetchSecretVersions(secretName, project)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := renderTemplate(tmpl, newSecretTemplateData(userInputName, secret, versions))
+	if err != nil {
+		return err
+	}
+	fmt.Print(rendered)
+	return nil
+}
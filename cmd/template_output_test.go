@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseOutputTemplate tests selecting top-level and named templates
+func TestParseOutputTemplate(t *testing.T) {
+	file := `{{define "summary"}}{{.Name}}: {{len .Versions}} versions{{end}}
+{{define "env"}}{{upper .Name}}_VERSION={{version (index .Versions 0).Name}}{{end}}
+`
+	data := newSecretTemplateData("db-password", SecretInfo{Name: "projects/p/secrets/db-password"}, []SecretVersionInfo{
+		{Name: "projects/p/secrets/db-password/versions/1", CreateTime: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "projects/p/secrets/db-password/versions/2", CreateTime: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+	})
+
+	tests := []struct {
+		name     string
+		text     string
+		selected string
+		expected string
+	}{
+		{"Top-level template", `{{.Name}} created {{date "2006-01-02" (index .Versions 1).CreateTime}}`, "", "db-password created 2025-01-01"},
+		{"Named template", file, "summary", "db-password: 2 versions"},
+		{"Versions are newest first", file, "env", "DB-PASSWORD_VERSION=2"},
+		{"Top-level content next to definitions", `{{define "n"}}{{.Name}}{{end}}name={{template "n" .}}`, "", "name=db-password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := parseOutputTemplate("test.tmpl", tt.text, tt.selected)
+			if err != nil {
+				t.Fatalf("parseOutputTemplate() failed: %v", err)
+			}
+			got, err := renderTemplate(tmpl, data)
+			if err != nil {
+				t.Fatalf("renderTemplate() failed: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("renderTemplate() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+
+	errorTests := []struct {
+		name     string
+		text     string
+		selected string
+		contains string
+	}{
+		{"Parse error", `{{.Name`, "", "invalid template"},
+		{"Unknown function", `{{shout .Name}}`, "", "invalid template"},
+		{"Only definitions", file, "", "env, summary"},
+		{"Unknown name", file, "missing", "'missing' is not defined"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseOutputTemplate("test.tmpl", tt.text, tt.selected)
+			if err == nil || !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("Expected error containing %q, got %v", tt.contains, err)
+			}
+		})
+	}
+}
+
+// TestRenderTemplateMissingAttribute tests that unknown attributes fail instead of printing "<no value>"
+func TestRenderTemplateMissingAttribute(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{Credentials: []CredentialInfo{
+		{Name: "db-password", Title: "Database password", Attributes: map[string]interface{}{"owner": "dba"}},
+	}}
+
+	data := newSecretTemplateData("db-password", SecretInfo{}, nil)
+	tmpl, err := parseOutputTemplate("test.tmpl", `{{.Title}} ({{.Attributes.owner}})`, "")
+	if err != nil {
+		t.Fatalf("parseOutputTemplate() failed: %v", err)
+	}
+	if got, err := renderTemplate(tmpl, data); err != nil || got != "Database password (dba)" {
+		t.Errorf("renderTemplate() = %q, %v", got, err)
+	}
+
+	tmpl, _ = parseOutputTemplate("test.tmpl", `{{.Attributes.team}}`, "")
+	if got, err := renderTemplate(tmpl, data); err == nil {
+		t.Errorf("Expected an error for a missing attribute, got %q", got)
+	}
+}
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os/exec"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -13,58 +15,150 @@ var updateCmd = &cobra.Command{
 	Short: "Update an existing secret in Google Secret Manager",
 	Long: `Update an existing secret by creating a new version with new data.
 You can provide the secret value via --data flag, from a file using --data-file,
-or interactively (prompt).
+from an http(s) endpoint using --data-url, or interactively (prompt).
 
 Version Management:
 The free tier of Google Secret Manager allows up to 6 active secret versions.
 Before updating a secret, this command will check if adding a new version would
 exceed this limit. If so, it will ask if you want to disable old versions
 to stay within the free tier, or proceed anyway (which may incur charges).
-Use --force to bypass this check entirely.`,
+Use --force to bypass this check entirely.
+
+Labels:
+Use --labels to replace all labels, --update-labels to add or change labels,
+and --remove-labels to delete labels by key. --set-labels makes the labels
+exactly equal the given set: it reads the current labels, then changes or adds
+the labels that differ and removes every label not in the set, leaving matching
+labels untouched (--set-labels "" removes them all). When only label flags are
+given (no --data, --data-file, or --data-url), the labels are updated without
+adding a new version.
+
+Configuration file:
+--title saves a title for the secret to the configuration file, and
+--update-config adds a configuration entry when the secret has none, asking
+for the title on a terminal, and --attr key=value stores attributes on the
+entry (see 'gsecutil create --help'). Given without any
+data or label flags, they update only the configuration file.`,
+	Example: `  gsecutil update my-secret -d "new-value"
+  gsecutil update my-secret --update-labels env=prod,team=backend
+  gsecutil update my-secret --remove-labels deprecated
+  gsecutil update my-secret --set-labels env=prod,team=backend
+  gsecutil update my-secret --labels env=staging -d "new-value"
+  gsecutil update my-secret --title "Production database password"
+  gsecutil update my-secret --update-config --attr owner=backend --attr environment=prod`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		userInputName := args[0]                           // What the user typed
 		secretName := AddPrefixToSecretName(userInputName) // Add prefix if configured
 		project, _ := cmd.Flags().GetString("project")
-		project = GetProject(project) // Use configuration-based project resolution
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
 		data, _ := cmd.Flags().GetString("data")
 		dataFile, _ := cmd.Flags().GetString("data-file")
 		force, _ := cmd.Flags().GetBool("force")
+		labels, _ := cmd.Flags().GetStringSlice("labels")
+		updateLabels, _ := cmd.Flags().GetStringSlice("update-labels")
+		removeLabels, _ := cmd.Flags().GetStringSlice("remove-labels")
+		setLabels, _ := cmd.Flags().GetStringSlice("set-labels")
+		setLabelsGiven := cmd.Flags().Changed("set-labels")
+		maxSize, _ := cmd.Flags().GetInt("max-size")
+		echo, _ := cmd.Flags().GetBool("echo")
+		prompt, _ := cmd.Flags().GetString("prompt")
 
-		// Get secret value
-		secretValue, err := getSecretInput(data, dataFile, "Enter new secret value: ")
-		if err != nil {
+		warnAboutDataFlag(data != "")
+		if err := validateDataSources(cmd); err != nil {
 			return err
 		}
+		dataURL, _ := cmd.Flags().GetString("data-url")
 
-		// Perform version management check
-		shouldContinue, err := manageVersionsForFreeTier(secretName, project, force)
+		labelArgs, err := buildLabelUpdateArgs(labels, updateLabels, removeLabels)
+		if err != nil {
+			return err
+		}
+		if setLabelsGiven && len(labelArgs) > 0 {
+			return fmt.Errorf("--set-labels cannot be combined with --labels, --update-labels, or --remove-labels")
+		}
+		attributes, err := configMetadataFlags(cmd)
 		if err != nil {
 			return err
 		}
-		if !shouldContinue {
-			return fmt.Errorf("operation cancelled")
+		if setLabelsGiven {
+			info, err := secretManager.Describe(secretName, project)
+			if err != nil {
+				return withUserInputName(err, userInputName)
+			}
+			if labelArgs, err = setLabelsArgs(info.Labels, setLabels); err != nil {
+				return err
+			}
 		}
 
-		// Build gcloud command to add new version
-		gcloudArgs := []string{"secrets", "versions", "add", secretName}
+		// Labels-only update: don't prompt for or add a new version
+		noData := data == "" && dataFile == "" && dataURL == ""
+		labelsOnly := (len(labelArgs) > 0 || setLabelsGiven) && noData
 
-		if project != "" {
-			gcloudArgs = append(gcloudArgs, "--project", project)
+		// Title-only update: only the configuration file changes
+		if noData && len(labelArgs) == 0 && !setLabelsGiven && (cmd.Flags().Changed("title") || cmd.Flags().Changed("update-config")) {
+			exists, err := secretManager.Exists(secretName, project)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return &NotFoundError{Secret: secretName, Name: userInputName}
+			}
+			recordConfigMetadata(cmd, userInputName, attributes)
+			return nil
 		}
 
-		gcloudArgs = append(gcloudArgs, "--data-file", "-")
+		// Get and check the secret value before changing anything
+		var secretValue string
+		if dataURL != "" {
+			secretValue, err = fetchSecretFromFlags(cmd, GetMaxSecretSize(maxSize))
+			if err != nil {
+				return err
+			}
+		} else if !labelsOnly {
+			secretValue, err = getSecretInput(data, dataFile, promptMessage(prompt, "Enter new secret value: "), echo)
+			if err != nil {
+				return err
+			}
+			if err := checkSecretValueSize(secretValue, GetMaxSecretSize(maxSize)); err != nil {
+				return err
+			}
+		}
 
-		// Execute gcloud command
-		gcloudCmd := exec.Command("gcloud", gcloudArgs...)
-		gcloudCmd.Stdin = strings.NewReader(secretValue)
+		// Perform version management check, before the labels change, so a
+		// failed check or a cancelled prompt leaves the secret untouched
+		if !labelsOnly {
+			shouldContinue, err := manageVersionsForFreeTier(secretName, project, force)
+			if err != nil {
+				return withUserInputName(err, userInputName)
+			}
+			if !shouldContinue {
+				return fmt.Errorf("operation cancelled")
+			}
+		}
 
-		output, err := gcloudCmd.CombinedOutput()
-		if err != nil {
-			return fmt.Errorf("gcloud command failed: %s", string(output))
+		if len(labelArgs) > 0 {
+			if err := secretManager.UpdateLabels(secretName, project, labelArgs); err != nil {
+				return withUserInputName(err, userInputName)
+			}
+			fmt.Printf("Labels of secret '%s' updated successfully\n", secretName)
+		} else if setLabelsGiven {
+			fmt.Printf("Labels of secret '%s' already match --set-labels\n", secretName)
+		}
+		if labelsOnly {
+			recordConfigMetadata(cmd, userInputName, attributes)
+			return nil
+		}
+
+		if err := secretManager.AddVersion(secretName, project, secretValue); err != nil {
+			return classifyWriteFailure(err, secretName, userInputName)
 		}
 
 		fmt.Printf("Secret '%s' updated successfully\n", secretName)
+		recordConfigMetadata(cmd, userInputName, attributes)
 		return nil
 	},
 }
@@ -73,5 +167,114 @@ func init() {
 	rootCmd.AddCommand(updateCmd)
 	updateCmd.Flags().StringP("data", "d", "", "New secret data to store")
 	updateCmd.Flags().String("data-file", "", "Path to file containing new secret data")
+	addDataURLFlags(updateCmd)
 	updateCmd.Flags().BoolP("force", "f", false, "Force update without version limit checks (may exceed free tier)")
+	updateCmd.Flags().StringSlice("labels", []string{}, "Replace all labels with these (format: key=value)")
+	updateCmd.Flags().StringSlice("update-labels", []string{}, "Add or change labels (format: key=value)")
+	updateCmd.Flags().StringSlice("remove-labels", []string{}, "Remove labels by key")
+	updateCmd.Flags().StringSlice("set-labels", []string{}, "Make the labels exactly these, adding, changing, and removing labels as needed (format: key=value)")
+	updateCmd.Flags().StringP("title", "t", "", "Title for the secret (saved to config file)")
+	updateCmd.Flags().Bool("update-config", false, "Add a configuration entry for the secret if it has none, asking for a title unless --title is given")
+	updateCmd.Flags().StringArray("attr", nil, "Config attribute to store on the entry, as key=value (repeatable, requires --update-config)")
+	updateCmd.Flags().Bool("echo", false, "Show the value as it is typed at the interactive prompt (for low-sensitivity values)")
+	updateCmd.Flags().String("prompt", "", "Message shown at the interactive prompt (default: \"Enter new secret value:\")")
+	updateCmd.Flags().Int("max-size", 0, "Maximum secret value size in bytes (default: defaults.maxSecretSize or 65536)")
+}
+
+// buildLabelUpdateArgs converts the label flags into 'gcloud secrets update' arguments.
+// --labels replaces all labels (clear, then set) and cannot be combined with the others.
+func buildLabelUpdateArgs(labels, updateLabels, removeLabels []string) ([]string, error) {
+	if len(labels) > 0 && (len(updateLabels) > 0 || len(removeLabels) > 0) {
+		return nil, fmt.Errorf("--labels cannot be combined with --update-labels or --remove-labels")
+	}
+
+	for _, label := range append(append([]string{}, labels...), updateLabels...) {
+		if key, _, ok := strings.Cut(label, "="); !ok || key == "" {
+			return nil, fmt.Errorf("invalid label '%s': expected key=value", label)
+		}
+	}
+	for _, key := range removeLabels {
+		if key == "" || strings.Contains(key, "=") {
+			return nil, fmt.Errorf("invalid label key '%s' for --remove-labels: expected a key without a value", key)
+		}
+	}
+
+	var args []string
+	if len(labels) > 0 {
+		// gcloud applies --clear-labels before --update-labels
+		args = append(args, "--clear-labels", "--update-labels", strings.Join(labels, ","))
+	}
+	if len(updateLabels) > 0 {
+		args = append(args, "--update-labels", strings.Join(updateLabels, ","))
+	}
+	if len(removeLabels) > 0 {
+		args = append(args, "--remove-labels", strings.Join(removeLabels, ","))
+	}
+	return args, nil
+}
+
+// setLabelsArgs returns the 'gcloud secrets update' arguments that make the
+// current labels exactly equal desired: new or changed labels are updated and
+// labels missing from desired are removed. It returns nil when they already match.
+func setLabelsArgs(current map[string]string, desired []string) ([]string, error) {
+	wanted := make(map[string]string, len(desired))
+	for _, label := range desired {
+		key, value, ok := strings.Cut(label, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid label '%s' for --set-labels: expected key=value", label)
+		}
+		wanted[key] = value
+	}
+
+	var updates, removals []string
+	for key, value := range wanted {
+		if currentValue, exists := current[key]; !exists || currentValue != value {
+			updates = append(updates, key+"="+value)
+		}
+	}
+	for key := range current {
+		if _, exists := wanted[key]; !exists {
+			removals = append(removals, key)
+		}
+	}
+	sort.Strings(updates)
+	sort.Strings(removals)
+
+	var args []string
+	if len(updates) > 0 {
+		args = append(args, "--update-labels", strings.Join(updates, ","))
+	}
+	if len(removals) > 0 {
+		args = append(args, "--remove-labels", strings.Join(removals, ","))
+	}
+	return args, nil
+}
+
+// updateSecretLabels applies label changes to an existing secret
+func updateSecretLabels(secretName, project string, labelArgs []string) error {
+	gcloudArgs := []string{"secrets", "update", secretName}
+	if project != "" {
+		gcloudArgs = append(gcloudArgs, "--project", project)
+	}
+	gcloudArgs = append(gcloudArgs, labelArgs...)
+
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
+	output, err := gcloudCmd.CombinedOutput()
+	if err != nil {
+		if typed := classifyGcloudFailure(string(output), secretName, ""); typed != nil {
+			return typed
+		}
+		return fmt.Errorf("gcloud command failed: %s", string(output))
+	}
+	return nil
+}
+
+// withUserInputName returns a missing-secret error with the user's name
+// filled in for the suggested command, or err unchanged
+func withUserInputName(err error, userInputName string) error {
+	var notFound *NotFoundError
+	if errors.As(err, &notFound) && notFound.Version == "" {
+		return &NotFoundError{Secret: notFound.Secret, Name: userInputName}
+	}
+	return err
 }
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestBuildLabelUpdateArgs tests conversion of update label flags into gcloud arguments
+func TestBuildLabelUpdateArgs(t *testing.T) {
+	tests := []struct {
+		name         string
+		labels       []string
+		updateLabels []string
+		removeLabels []string
+		expected     []string
+		expectError  bool
+	}{
+		{
+			name:     "No label flags",
+			expected: nil,
+		},
+		{
+			name:     "Replace all labels",
+			labels:   []string{"env=prod", "team=backend"},
+			expected: []string{"--clear-labels", "--update-labels", "env=prod,team=backend"},
+		},
+		{
+			name:         "Update and remove",
+			updateLabels: []string{"env=prod"},
+			removeLabels: []string{"deprecated", "old"},
+			expected:     []string{"--update-labels", "env=prod", "--remove-labels", "deprecated,old"},
+		},
+		{
+			name:         "Empty label value is allowed",
+			updateLabels: []string{"note="},
+			expected:     []string{"--update-labels", "note="},
+		},
+		{
+			name:         "Replace combined with update",
+			labels:       []string{"env=prod"},
+			updateLabels: []string{"team=backend"},
+			expectError:  true,
+		},
+		{
+			name:        "Missing value separator",
+			labels:      []string{"env"},
+			expectError: true,
+		},
+		{
+			name:         "Missing key",
+			updateLabels: []string{"=prod"},
+			expectError:  true,
+		},
+		{
+			name:         "Remove with value",
+			removeLabels: []string{"env=prod"},
+			expectError:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := buildLabelUpdateArgs(tt.labels, tt.updateLabels, tt.removeLabels)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("buildLabelUpdateArgs() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestSetLabelsArgs tests the label changes that make a secret's labels
+// exactly match --set-labels
+func TestSetLabelsArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		current     map[string]string
+		desired     []string
+		expected    []string
+		expectError bool
+	}{
+		{
+			name:     "Already matching",
+			current:  map[string]string{"env": "prod", "team": "backend"},
+			desired:  []string{"team=backend", "env=prod"},
+			expected: nil,
+		},
+		{
+			name:     "Add, change, and remove",
+			current:  map[string]string{"env": "dev", "team": "backend", "old": "yes", "deprecated": ""},
+			desired:  []string{"env=prod", "team=backend", "owner=alice"},
+			expected: []string{"--update-labels", "env=prod,owner=alice", "--remove-labels", "deprecated,old"},
+		},
+		{
+			name:     "Secret without labels",
+			desired:  []string{"env=prod"},
+			expected: []string{"--update-labels", "env=prod"},
+		},
+		{
+			name:     "Empty set removes every label",
+			current:  map[string]string{"env": "prod", "team": "backend"},
+			expected: []string{"--remove-labels", "env,team"},
+		},
+		{
+			name:        "Missing value separator",
+			desired:     []string{"env"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := setLabelsArgs(tt.current, tt.desired)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("setLabelsArgs() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"math/rand"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/mattn/go-runewidth"
+	"gopkg.in/yaml.v3"
 )
 
 // displayWidth returns the terminal display width of a string, correctly
@@ -25,6 +31,238 @@ func padRight(s string, width int) string {
 	return s + strings.Repeat(" ", width-sw)
 }
 
+// printStructuredOutput prints value as indented JSON or as YAML. Output
+// types carry matching json and yaml tags so both formats share one schema.
+func printStructuredOutput(value interface{}, format string) error {
+	if format == "yaml" {
+		yamlOutput, err := yaml.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal YAML output: %w", err)
+		}
+		fmt.Print(string(yamlOutput))
+		return nil
+	}
+
+	jsonOutput, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON output: %w", err)
+	}
+	fmt.Println(string(jsonOutput))
+	return nil
+}
+
+// markdownFormat is the --format value that renders a report as a
+// GitHub-flavored Markdown table, for pasting into tickets and wikis
+const markdownFormat = "markdown"
+
+// printTextTable prints rows under header as space-aligned columns with a
+// dashed separator, the layout of the list and report tables
+func printTextTable(header []string, rows [][]string) {
+	widths := make([]int, len(header))
+	for i, cell := range header {
+		widths[i] = displayWidth(cell)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if w := displayWidth(cell); w > widths[i] {
+				widths[i] = w
+			}
+		}
+	}
+
+	line := func(cells []string) string {
+		padded := make([]string, len(cells))
+		for i, cell := range cells {
+			padded[i] = padRight(cell, widths[i])
+		}
+		return strings.Join(padded, "  ")
+	}
+	separator := make([]string, len(header))
+	for i, width := range widths {
+		separator[i] = strings.Repeat("-", width)
+	}
+
+	fmt.Println(line(header))
+	fmt.Println(line(separator))
+	for _, row := range rows {
+		fmt.Println(line(row))
+	}
+}
+
+// escapeMarkdownCell makes a value safe inside a Markdown table cell: pipes
+// are escaped and line breaks become <br>
+func escapeMarkdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	value = strings.ReplaceAll(value, "\r\n", "<br>")
+	return strings.ReplaceAll(value, "\n", "<br>")
+}
+
+// formatMarkdownTable renders rows under header as a GitHub-flavored Markdown table
+func formatMarkdownTable(header []string, rows [][]string) string {
+	var b strings.Builder
+	line := func(cells []string) {
+		escaped := make([]string, len(cells))
+		for i, cell := range cells {
+			escaped[i] = escapeMarkdownCell(cell)
+		}
+		b.WriteString("| " + strings.Join(escaped, " | ") + " |\n")
+	}
+	line(header)
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	line(separator)
+	for _, row := range rows {
+		line(row)
+	}
+	return b.String()
+}
+
+// printCsvTable prints rows under header as CSV
+func printCsvTable(header []string, rows [][]string) error {
+	writer := csv.NewWriter(os.Stdout)
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV output: %w", err)
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write CSV output: %w", err)
+	}
+	return nil
+}
+
+// printMarkdownTable prints rows under header as a Markdown table
+func printMarkdownTable(header []string, rows [][]string) {
+	fmt.Print(formatMarkdownTable(header, rows))
+}
+
+// atomicWriteFile writes data to path by writing a temporary file in the same
+// directory and renaming it into place, so an interrupted write never leaves a
+// truncated file behind. A symlink is followed, so the link keeps pointing at
+// the rewritten file. An existing file keeps its mode; a new one is created
+// with perm, subject to the umask. When perm grants nothing to group and
+// others, as for files holding secret values, any group and other bits of an
+// existing file are dropped as well.
+func atomicWriteFile(path string, data []byte, perm os.FileMode) error {
+	path, err := resolveSymlinks(path)
+	if err != nil {
+		return err
+	}
+
+	var keepMode os.FileMode
+	existing := false
+	if info, err := os.Stat(path); err == nil {
+		keepMode, existing = info.Mode().Perm(), true
+		if perm&0077 == 0 {
+			keepMode &= perm
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	tmp, err := createTempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp-", perm)
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	committed := false
+	defer func() {
+		if !committed {
+			tmp.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// The umask may have cleared bits of the existing mode; restore them
+	if existing {
+		if err := os.Chmod(tmpPath, keepMode); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+// maxSymlinkDepth bounds symlink resolution, so a link cycle is an error
+const maxSymlinkDepth = 40
+
+// resolveSymlinks follows path while it is a symlink and returns the final
+// target, which need not exist yet (a dangling link is written through)
+func resolveSymlinks(path string) (string, error) {
+	for i := 0; i < maxSymlinkDepth; i++ {
+		info, err := os.Lstat(path)
+		if os.IsNotExist(err) {
+			return path, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			return path, nil
+		}
+		target, err := os.Readlink(path)
+		if err != nil {
+			return "", err
+		}
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(filepath.Dir(path), target)
+		}
+		path = target
+	}
+	return "", fmt.Errorf("too many levels of symbolic links: %s", path)
+}
+
+// createTempFile creates a new file in dir with a random name starting with
+// prefix. Unlike os.CreateTemp, which always uses 0600, it creates the file
+// with perm, so the umask applies as it does for os.Create.
+func createTempFile(dir, prefix string, perm os.FileMode) (*os.File, error) {
+	for i := 0; i < 100; i++ {
+		name := filepath.Join(dir, prefix+strconv.FormatUint(uint64(rand.Uint32()), 10))
+		file, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		if os.IsExist(err) {
+			continue
+		}
+		return file, err
+	}
+	return nil, fmt.Errorf("failed to create a temporary file in %s", dir)
+}
+
+// progressPrinter prints per-item progress lines ("Created secret: x"),
+// which --summary-only suppresses. Warnings, errors, and summaries are
+// printed directly and are never suppressed.
+type progressPrinter struct {
+	suppressed bool
+}
+
+// Printf prints a progress line unless progress output is suppressed
+func (p progressPrinter) Printf(format string, args ...interface{}) {
+	if !p.suppressed {
+		fmt.Printf(format, args...)
+	}
+}
+
+// gcloudBinary returns the gcloud executable to run: the GSECUTIL_GCLOUD
+// environment variable when set (a wrapper script, a pinned SDK install, or a
+// stub in tests), otherwise gcloud from PATH
+func gcloudBinary() string {
+	if path := os.Getenv("GSECUTIL_GCLOUD"); path != "" {
+		return path
+	}
+	return "gcloud"
+}
+
 // extractSecretName extracts the secret name from the full resource name
 // Full name format: "projects/PROJECT_ID/secrets/SECRET_NAME"
 func extractSecretName(fullName string) string {
@@ -37,6 +275,21 @@ func extractSecretName(fullName string) string {
 
 // fetchSecrets retrieves secrets list from Google Secret Manager
 func fetchSecrets(project, filter string, limit int) ([]SecretInfo, error) {
+	output, err := runGcloudSecretsList(project, filter, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	var secrets []SecretInfo
+	if err := json.Unmarshal(output, &secrets); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets list: %w", err)
+	}
+
+	return secrets, nil
+}
+
+// runGcloudSecretsList returns the raw JSON output of 'gcloud secrets list'
+func runGcloudSecretsList(project, filter string, limit int) ([]byte, error) {
 	gcloudArgs := []string{"secrets", "list", "--format", "json"}
 
 	if project != "" {
@@ -51,7 +304,7 @@ func fetchSecrets(project, filter string, limit int) ([]SecretInfo, error) {
 		gcloudArgs = append(gcloudArgs, "--limit", fmt.Sprintf("%d", limit))
 	}
 
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
 	output, err := gcloudCmd.Output()
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
@@ -60,12 +313,7 @@ func fetchSecrets(project, filter string, limit int) ([]SecretInfo, error) {
 		return nil, fmt.Errorf("failed to execute gcloud command: %v", err)
 	}
 
-	var secrets []SecretInfo
-	if err := json.Unmarshal(output, &secrets); err != nil {
-		return nil, fmt.Errorf("failed to parse secrets list: %w", err)
-	}
-
-	return secrets, nil
+	return output, nil
 }
 
 // sortSecrets sorts secrets by name
@@ -75,18 +323,115 @@ func sortSecrets(secrets []SecretInfo) {
 	})
 }
 
+// sortSecretsByAttribute orders secrets by the value of a configuration
+// attribute (see GetAttributeValue). Secrets without a value, including those
+// with no config entry, sort last; ties keep their current order. An empty
+// attribute leaves the order unchanged.
+func sortSecretsByAttribute(secrets []SecretInfo, attribute string) {
+	if attribute == "" {
+		return
+	}
+	prefix := GetPrefix()
+	values := make(map[string]string, len(secrets))
+	for _, secret := range secrets {
+		bareName := strings.TrimPrefix(extractSecretName(secret.Name), prefix) // config stores bare names
+		value := GetAttributeValue(GetCredentialInfo(bareName), attribute)
+		if value == "(unknown)" || value == "(no title)" {
+			value = ""
+		}
+		values[secret.Name] = value
+	}
+	sort.SliceStable(secrets, func(i, j int) bool {
+		a, b := values[secrets[i].Name], values[secrets[j].Name]
+		if a == "" || b == "" {
+			return a != "" && b == ""
+		}
+		return a < b
+	})
+}
+
+// secretValueErrorPlaceholder is returned by getSecretValue when a value cannot be read
+const secretValueErrorPlaceholder = "(error retrieving value)"
+
 // getSecretValue retrieves the latest version value of a secret
 func getSecretValue(secretName, project string) string {
-	gcloudArgs := []string{"secrets", "versions", "access", "latest", "--secret", secretName}
+	return getSecretVersionValue(secretName, "latest", project)
+}
+
+// getSecretVersionValue reads the value of one version of a secret, or returns
+// secretValueErrorPlaceholder when it cannot be read
+func getSecretVersionValue(secretName, version, project string) string {
+	gcloudArgs := []string{"secrets", "versions", "access", version, "--secret", secretName}
 	if project != "" {
 		gcloudArgs = append(gcloudArgs, "--project", project)
 	}
 
-	gcloudCmd := exec.Command("gcloud", gcloudArgs...)
+	gcloudCmd := exec.Command(gcloudBinary(), gcloudArgs...)
 	output, err := gcloudCmd.Output()
 	if err != nil {
-		return "(error retrieving value)"
+		return secretValueErrorPlaceholder
 	}
 
 	return strings.TrimSpace(string(output))
 }
+
+// labelExclusion is a single --filter-not condition. When AnyValue is set the
+// condition matches any secret that has the label, regardless of its value.
+type labelExclusion struct {
+	Key      string
+	Value    string
+	AnyValue bool
+}
+
+// parseLabelExclusions parses a --filter-not expression of comma-separated
+// "key=value" or "key" conditions. A "labels." prefix on keys is accepted so
+// the syntax mirrors --filter.
+func parseLabelExclusions(expr string) ([]labelExclusion, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+
+	var exclusions []labelExclusion
+	for _, part := range strings.Split(expr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key, value, hasValue := strings.Cut(part, "=")
+		key = strings.TrimPrefix(strings.TrimSpace(key), "labels.")
+		if key == "" {
+			return nil, fmt.Errorf("invalid filter-not condition '%s': missing label key", part)
+		}
+		exclusions = append(exclusions, labelExclusion{
+			Key:      key,
+			Value:    strings.TrimSpace(value),
+			AnyValue: !hasValue,
+		})
+	}
+
+	return exclusions, nil
+}
+
+// excludeSecretsByLabels removes secrets matching any of the exclusion conditions
+func excludeSecretsByLabels(secrets []SecretInfo, exclusions []labelExclusion) []SecretInfo {
+	if len(exclusions) == 0 {
+		return secrets
+	}
+
+	var kept []SecretInfo
+	for _, secret := range secrets {
+		excluded := false
+		for _, exclusion := range exclusions {
+			value, ok := secret.Labels[exclusion.Key]
+			if ok && (exclusion.AnyValue || value == exclusion.Value) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			kept = append(kept, secret)
+		}
+	}
+	return kept
+}
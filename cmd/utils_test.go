@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -152,3 +155,322 @@ func TestSortSecrets(t *testing.T) {
 		})
 	}
 }
+
+// TestSortSecretsByAttribute tests ordering secrets by a config attribute, unknowns last
+func TestSortSecretsByAttribute(t *testing.T) {
+	originalConfig := globalConfig
+	defer func() { globalConfig = originalConfig }()
+	globalConfig = &Config{
+		Prefix: "app-",
+		Credentials: []CredentialInfo{
+			{Name: "db", Title: "Database", Attributes: map[string]interface{}{"owner": "backend"}},
+			{Name: "api", Attributes: map[string]interface{}{"owner": "platform"}},
+			{Name: "cache", Title: "Cache", Attributes: map[string]interface{}{"owner": "platform"}},
+			{Name: "queue"},
+		},
+	}
+
+	names := func() []SecretInfo {
+		return []SecretInfo{
+			{Name: "projects/p/secrets/app-api"},
+			{Name: "projects/p/secrets/app-cache"},
+			{Name: "projects/p/secrets/app-db"},
+			{Name: "projects/p/secrets/app-orphan"},
+			{Name: "projects/p/secrets/app-queue"},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		attribute string
+		expected  []string
+	}{
+		{
+			name:      "custom attribute with ties in name order",
+			attribute: "owner",
+			expected:  []string{"app-db", "app-api", "app-cache", "app-orphan", "app-queue"},
+		},
+		{
+			name:      "title with missing titles last",
+			attribute: "title",
+			expected:  []string{"app-cache", "app-db", "app-api", "app-orphan", "app-queue"},
+		},
+		{
+			name:      "attribute nobody has keeps name order",
+			attribute: "environment",
+			expected:  []string{"app-api", "app-cache", "app-db", "app-orphan", "app-queue"},
+		},
+		{
+			name:      "empty attribute keeps order",
+			attribute: "",
+			expected:  []string{"app-api", "app-cache", "app-db", "app-orphan", "app-queue"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secrets := names()
+			sortSecretsByAttribute(secrets, tt.attribute)
+
+			var got []string
+			for _, secret := range secrets {
+				got = append(got, extractSecretName(secret.Name))
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("sortSecretsByAttribute(%q) = %v, want %v", tt.attribute, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseLabelExclusions tests parsing of --filter-not expressions
+func TestParseLabelExclusions(t *testing.T) {
+	tests := []struct {
+		name        string
+		expr        string
+		expected    []labelExclusion
+		expectError bool
+	}{
+		{
+			name:     "Empty expression",
+			expr:     "",
+			expected: nil,
+		},
+		{
+			name:     "Key and value",
+			expr:     "env=prod",
+			expected: []labelExclusion{{Key: "env", Value: "prod"}},
+		},
+		{
+			name: "Labels prefix and bare key",
+			expr: "labels.env=prod, deprecated",
+			expected: []labelExclusion{
+				{Key: "env", Value: "prod"},
+				{Key: "deprecated", AnyValue: true},
+			},
+		},
+		{
+			name:     "Empty value matches only empty labels",
+			expr:     "team=",
+			expected: []labelExclusion{{Key: "team", Value: ""}},
+		},
+		{
+			name:        "Missing key",
+			expr:        "=prod",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseLabelExclusions(tt.expr)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("parseLabelExclusions(%q) = %+v, expected %+v", tt.expr, result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestExcludeSecretsByLabels tests post-filtering secrets with --filter-not conditions
+func TestExcludeSecretsByLabels(t *testing.T) {
+	secrets := []SecretInfo{
+		{Name: "projects/p/secrets/prod-db", Labels: map[string]string{"env": "prod"}},
+		{Name: "projects/p/secrets/dev-db", Labels: map[string]string{"env": "dev"}},
+		{Name: "projects/p/secrets/old-key", Labels: map[string]string{"env": "dev", "deprecated": "true"}},
+		{Name: "projects/p/secrets/unlabeled"},
+	}
+
+	tests := []struct {
+		name       string
+		exclusions []labelExclusion
+		expected   []string
+	}{
+		{
+			name:       "No exclusions",
+			exclusions: nil,
+			expected:   []string{"prod-db", "dev-db", "old-key", "unlabeled"},
+		},
+		{
+			name:       "Exclude by value",
+			exclusions: []labelExclusion{{Key: "env", Value: "prod"}},
+			expected:   []string{"dev-db", "old-key", "unlabeled"},
+		},
+		{
+			name:       "Exclude by key presence",
+			exclusions: []labelExclusion{{Key: "deprecated", AnyValue: true}},
+			expected:   []string{"prod-db", "dev-db", "unlabeled"},
+		},
+		{
+			name: "Any condition excludes",
+			exclusions: []labelExclusion{
+				{Key: "env", Value: "prod"},
+				{Key: "deprecated", AnyValue: true},
+			},
+			expected: []string{"dev-db", "unlabeled"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := excludeSecretsByLabels(secrets, tt.exclusions)
+			var names []string
+			for _, secret := range result {
+				names = append(names, extractSecretName(secret.Name))
+			}
+			if !reflect.DeepEqual(names, tt.expected) {
+				t.Errorf("excludeSecretsByLabels() = %v, expected %v", names, tt.expected)
+			}
+		})
+	}
+}
+
+// TestAtomicWriteFile tests that files are written in place without leaving temp files behind
+func TestAtomicWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "out.yaml")
+
+	if err := atomicWriteFile(path, []byte("first"), 0644); err != nil {
+		t.Fatalf("atomicWriteFile() error = %v", err)
+	}
+	if err := atomicWriteFile(path, []byte("second"), 0600); err != nil {
+		t.Fatalf("atomicWriteFile() overwrite error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read written file: %v", err)
+	}
+	if string(data) != "second" {
+		t.Errorf("content = %q, want %q", data, "second")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("failed to stat written file: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("permissions = %v, want %v", info.Mode().Perm(), os.FileMode(0600))
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to read directory: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want only the written file", len(entries))
+	}
+
+	if err := atomicWriteFile(filepath.Join(dir, "missing", "out.yaml"), []byte("x"), 0644); err == nil {
+		t.Error("atomicWriteFile() into a missing directory should fail")
+	}
+}
+
+// TestAtomicWriteFileModes tests that atomicWriteFile keeps an existing
+// file's mode, applies the umask to new files, and writes through symlinks
+func TestAtomicWriteFileModes(t *testing.T) {
+	dir := t.TempDir()
+	modeOf := func(path string) os.FileMode {
+		t.Helper()
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("failed to stat %s: %v", path, err)
+		}
+		return info.Mode().Perm()
+	}
+
+	// A new file gets the mode os.OpenFile gives it under the current umask
+	reference := filepath.Join(dir, "reference")
+	if f, err := os.OpenFile(reference, os.O_CREATE|os.O_WRONLY, 0666); err != nil {
+		t.Fatalf("failed to create reference file: %v", err)
+	} else {
+		f.Close()
+	}
+	created := filepath.Join(dir, "created")
+	if err := atomicWriteFile(created, []byte("x"), 0666); err != nil {
+		t.Fatalf("atomicWriteFile() error = %v", err)
+	}
+	if modeOf(created) != modeOf(reference) {
+		t.Errorf("new file mode = %v, want %v (the umask applied)", modeOf(created), modeOf(reference))
+	}
+
+	// An existing file keeps the mode the user chose
+	kept := filepath.Join(dir, "kept")
+	if err := os.WriteFile(kept, []byte("old"), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.Chmod(kept, 0640); err != nil {
+		t.Fatalf("failed to chmod file: %v", err)
+	}
+	if err := atomicWriteFile(kept, []byte("new"), 0644); err != nil {
+		t.Fatalf("atomicWriteFile() error = %v", err)
+	}
+	if modeOf(kept) != 0640 {
+		t.Errorf("existing file mode = %v, want 0640 kept", modeOf(kept))
+	}
+
+	// A symlink still points at the target, which holds the new content
+	target := filepath.Join(dir, "target")
+	if err := os.WriteFile(target, []byte("old"), 0600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	link := filepath.Join(dir, "link")
+	if err := os.Symlink("target", link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	if err := atomicWriteFile(link, []byte("new"), 0644); err != nil {
+		t.Fatalf("atomicWriteFile() through symlink error = %v", err)
+	}
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("link was replaced by a plain file")
+	}
+	if data, _ := os.ReadFile(target); string(data) != "new" {
+		t.Errorf("target content = %q, want %q", data, "new")
+	}
+	if modeOf(target) != 0600 {
+		t.Errorf("target mode = %v, want 0600 kept", modeOf(target))
+	}
+}
+
+// TestProgressPrinter tests that --summary-only suppresses progress lines
+func TestProgressPrinter(t *testing.T) {
+	output := captureStdout(func() {
+		progressPrinter{}.Printf("Created secret: %s\n", "db")
+	})
+	if output != "Created secret: db\n" {
+		t.Errorf("Expected the progress line, got %q", output)
+	}
+
+	output = captureStdout(func() {
+		progressPrinter{suppressed: true}.Printf("Created secret: %s\n", "db")
+	})
+	if output != "" {
+		t.Errorf("Expected no output when suppressed, got %q", output)
+	}
+}
+
+// TestFormatMarkdownTable tests Markdown table rendering and cell escaping
+func TestFormatMarkdownTable(t *testing.T) {
+	got := formatMarkdownTable(
+		[]string{"NAME", "TITLE"},
+		[][]string{{"db", "Prod | primary"}, {"api", "Line one\nline two"}},
+	)
+	expected := "| NAME | TITLE |\n" +
+		"| --- | --- |\n" +
+		"| db | Prod \\| primary |\n" +
+		"| api | Line one<br>line two |\n"
+	if got != expected {
+		t.Errorf("formatMarkdownTable() =\n%s\nexpected\n%s", got, expected)
+	}
+
+	if got := formatMarkdownTable([]string{"NAME"}, nil); got != "| NAME |\n| --- |\n" {
+		t.Errorf("Expected only the header for no rows, got %q", got)
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// versionStateChange is one of the 'versions' subcommands
+type versionStateChange struct {
+	Verb string // gcloud secrets versions subcommand
+	Done string // past tense for the result message
+}
+
+var (
+	versionDisable = versionStateChange{Verb: "disable", Done: "disabled"}
+	versionEnable  = versionStateChange{Verb: "enable", Done: "enabled"}
+	versionDestroy = versionStateChange{Verb: "destroy", Done: "destroyed"}
+)
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions",
+	Short: "List, disable, enable, or destroy individual secret versions",
+	Long: `List the versions of a secret, or disable, enable, or destroy one of them.
+
+A disabled version cannot be accessed until it is enabled again. Destroying a
+version permanently deletes its value; it asks for confirmation unless --force
+is given. The version is given by number with --version.`,
+}
+
+var versionsListCmd = &cobra.Command{
+	Use:   "list SECRET_NAME",
+	Short: "List the versions of a secret",
+	Long: `List every version of a secret with its state, creation time, and ETag,
+newest first. Use --format json or yaml to get the version metadata as a list
+for scripts.
+
+Examples:
+  gsecutil versions list my-secret
+  gsecutil versions list my-secret --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		secretName := AddPrefixToSecretName(args[0]) // Add prefix if configured
+		project, _ := cmd.Flags().GetString("project")
+		project, err := resolveProject(project)
+		if err != nil {
+			return err
+		}
+		format, _ := cmd.Flags().GetString("format")
+		if format != "" && format != "json" && format != "yaml" {
+			return fmt.Errorf("unsupported format '%s' (use json or yaml)", format)
+		}
+
+		return listSecretVersions(secretName, project, format)
+	},
+}
+
+var versionsDisableCmd = &cobra.Command{
+	Use:   "disable SECRET_NAME --version N",
+	Short: "Disable a secret version",
+	Long: `Disable a version of a secret so that it can no longer be accessed.
+It can be enabled again with 'versions enable'.
+
+Examples:
+  gsecutil versions disable my-secret --version 3`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVersionStateChange(cmd, args, versionDisable)
+	},
+}
+
+var versionsEnableCmd = &cobra.Command{
+	Use:   "enable SECRET_NAME --version N",
+	Short: "Enable a disabled secret version",
+	Long: `Enable a disabled version of a secret so that it can be accessed again.
+
+Examples:
+  gsecutil versions enable my-secret --version 3`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVersionStateChange(cmd, args, versionEnable)
+	},
+}
+
+var versionsDestroyCmd = &cobra.Command{
+	Use:   "destroy SECRET_NAME --version N",
+	Short: "Permanently destroy a secret version",
+	Long: `Destroy a version of a secret. Its value is permanently deleted and cannot
+be recovered; the version stays listed in the DESTROYED state. You are asked
+to confirm unless --force is given.
+
+Examples:
+  gsecutil versions destroy my-secret --version 2
+  gsecutil versions destroy my-secret --version 2 --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runVersionStateChange(cmd, args, versionDestroy)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(versionsCmd)
+	versionsCmd.AddCommand(versionsListCmd)
+	versionsListCmd.Flags().String("format", "", "Output format: json or yaml (default: text)")
+
+	for _, sub := range []*cobra.Command{versionsDisableCmd, versionsEnableCmd, versionsDestroyCmd} {
+		versionsCmd.AddCommand(sub)
+		sub.Flags().StringP("version", "v", "", "Version number to change (required)")
+		if err := sub.MarkFlagRequired("version"); err != nil {
+			panic(fmt.Sprintf("Failed to mark version flag as required for %s command: %v", sub.Name(), err))
+		}
+	}
+	versionsDestroyCmd.Flags().BoolP("force", "f", false, "Destroy without confirmation prompt")
+}
+
+// runVersionStateChange handles the versions subcommands
+func runVersionStateChange(cmd *cobra.Command, args []string, change versionStateChange) error {
+	userInputName := args[0]                           // What the user typed
+	secretName := AddPrefixToSecretName(userInputName) // Add prefix if configured
+	project, _ := cmd.Flags().GetString("project")
+	project, err := resolveProject(project)
+	if err != nil {
+		return err
+	}
+	version, _ := cmd.Flags().GetString("version")
+	if n, err := strconv.Atoi(version); err != nil || n < 1 {
+		return fmt.Errorf("invalid --version '%s': use a version number such as 3", version)
+	}
+
+	if change == versionDestroy {
+		force, _ := cmd.Flags().GetBool("force")
+		if !force {
+			confirmed, err := confirmVersionDestroy(bufio.NewReader(os.Stdin), secretName, version)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				fmt.Println("Destroy operation cancelled.")
+				return nil
+			}
+		}
+	}
+
+	gcloudArgs := []string{"secrets", "versions", change.Verb, version, "--secret", secretName, "--quiet"}
+	if project != "" {
+		gcloudArgs = append(gcloudArgs, "--project", project)
+	}
+	if err := runGcloudWrite(gcloudArgs, ""); err != nil {
+		return classifyWriteFailure(err, secretName, userInputName)
+	}
+
+	fmt.Printf("Secret '%s': version %s %s\n", secretName, version, change.Done)
+	return nil
+}
+
+// confirmVersionDestroy asks y/N before destroying a version, like delete
+func confirmVersionDestroy(reader *bufio.Reader, secretName, version string) (bool, error) {
+	fmt.Printf("Are you sure you want to destroy version %s of secret '%s'? Its value is permanently deleted. (y/N): ", version, secretName)
+	response, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read confirmation input: %w", err)
+	}
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response == "y" || response == "yes", nil
+}